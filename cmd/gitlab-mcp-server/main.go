@@ -4,22 +4,33 @@ import (
 	"context"    // Added for signal handling
 	"fmt"        // Added for stdio logging
 	stdlog "log" // Use standard log for initial fatal errors
+	"net/http"
 	"os"
 	"os/signal" // Added for signal handling
-	"strings"   // Added for toolset parsing
-	"syscall"   // Added for signal handling
+	"slices"
+	"strings" // Added for toolset parsing
+	"syscall" // Added for signal handling
+	"time"
 
 	"github.com/LuisCusihuaman/gitlab-mcp-server/pkg/gitlab" // Reference pkg/gitlab
 	// Reference pkg/toolsets
-	// iolog "github.com/github/github-mcp-server/pkg/log" // TODO: Consider adding if command logging is needed
 	"github.com/mark3labs/mcp-go/server" // MCP server components
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	gl "gitlab.com/gitlab-org/api/client-go" // Alias for GitLab client library
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	// MCP types
 )
 
+// cacheStatsInterval is how often the response cache statistics are logged while they change.
+const cacheStatsInterval = 10 * time.Minute
+
+// shutdownTimeout bounds the wait for in-flight metrics scrapes and webhook deliveries, and
+// the export of pending spans when the server stops.
+const shutdownTimeout = 5 * time.Second
+
 // Injected by goreleaser
 var version = "dev"
 var commit = "none"
@@ -62,6 +73,33 @@ var (
 			}
 			host := viper.GetString("host") // Optional, defaults handled by NewClient
 			readOnly := viper.GetBool("read-only")
+			outputFormat := viper.GetString("output-format")
+			if !slices.Contains(gitlab.OutputFormats, outputFormat) {
+				logger.Fatalf("Invalid output format %q: must be one of %s", outputFormat, strings.Join(gitlab.OutputFormats, ", "))
+			}
+			webhookAddr, webhookSecret := viper.GetString("webhook-addr"), viper.GetString("webhook-secret")
+			if webhookAddr != "" && webhookSecret == "" {
+				logger.Fatal("A webhook secret is required to receive GitLab webhooks: set GITLAB_WEBHOOK_SECRET (or --webhook-secret).")
+			}
+			toolLimits, err := gitlab.ParseToolByteLimits(viper.GetString("tool-max-response-bytes"))
+			if err != nil {
+				logger.Fatalf("Invalid per-tool response limits: %v", err)
+			}
+			responseLimits := gitlab.ResponseLimits{
+				MaxBytes: viper.GetInt("max-response-bytes"),
+				PerTool:  toolLimits,
+			}
+			clientCfg := gitlab.ClientConfig{
+				MaxRetries:        viper.GetInt("max-retries"),
+				RetryWaitMin:      viper.GetDuration("retry-wait-min"),
+				RetryWaitMax:      viper.GetDuration("retry-wait-max"),
+				MaxRetryAfter:     viper.GetDuration("max-retry-after"),
+				RequestsPerSecond: viper.GetFloat64("rate-limit"),
+				Burst:             viper.GetInt("rate-limit-burst"),
+			}
+			toolsetCfg := gitlab.ToolsetConfig{
+				AllowProtectedBranchWrites: viper.GetBool("allow-protected-branch-writes"),
+			}
 
 			// Special handling for toolsets slice from env var
 			var enabledToolsets []string
@@ -80,15 +118,69 @@ var (
 			// }
 			logger.Infof("Enabled toolsets: %v", enabledToolsets)
 			logger.Infof("Read-only mode: %t", readOnly)
+			if toolsetCfg.AllowProtectedBranchWrites {
+				logger.Warn("Branch write tools may modify protected branches")
+			}
 			if host != "" {
 				logger.Infof("Using custom GitLab host: %s", host)
 			}
+			logger.Infof("GitLab API retries: %d (backoff %s-%s, honoring waits up to %s)", clientCfg.MaxRetries, clientCfg.RetryWaitMin, clientCfg.RetryWaitMax, clientCfg.MaxRetryAfter)
+			if clientCfg.RequestsPerSecond > 0 {
+				logger.Infof("Client-side rate limit: %g requests/s", clientCfg.RequestsPerSecond)
+			}
 
 			// Initialize GitLab Client directly
-			clientOpts := []gl.ClientOptionFunc{}
+			clientOpts := gitlab.ClientOptions(clientCfg, logger)
 			if host != "" {
 				clientOpts = append(clientOpts, gl.WithBaseURL(host))
 			}
+			var transport http.RoundTripper = http.DefaultTransport.(*http.Transport).Clone()
+			var metrics *gitlab.Metrics
+			metricsAddr := viper.GetString("metrics-addr")
+			if metricsAddr != "" {
+				metrics = gitlab.NewMetrics()
+				transport = metrics.Transport(transport)
+			}
+			var tracerProvider *sdktrace.TracerProvider
+			otlpEndpoint := viper.GetString("otlp-endpoint")
+			if otlpEndpoint != "" {
+				tracerProvider, err = gitlab.NewTracerProvider(ctx, otlpEndpoint, "gitlab-mcp-server", version)
+				if err != nil {
+					logger.Fatalf("Failed to initialize tracing: %v", err)
+				}
+				otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+					logger.Warnf("Tracing error: %v", err)
+				}))
+				transport = gitlab.TracingTransport(transport, tracerProvider)
+				logger.Infof("Exporting traces to %s", otlpEndpoint)
+			}
+			var cache *gitlab.CachingTransport
+			if viper.GetBool("no-cache") {
+				logger.Info("GitLab response cache disabled")
+			} else {
+				cacheTTLs, err := gitlab.ParseCacheTTLs(viper.GetString("cache-ttl"))
+				if err != nil {
+					logger.Fatalf("Invalid cache TTLs: %v", err)
+				}
+				cacheCfg := gitlab.CacheConfig{
+					TTLs:         cacheTTLs,
+					MaxEntries:   viper.GetInt("cache-max-entries"),
+					MaxBytes:     int64(viper.GetInt("cache-max-mb")) << 20,
+					Dir:          viper.GetString("cache-dir"),
+					MaxDiskBytes: int64(viper.GetInt("cache-dir-max-mb")) << 20,
+				}
+				cache, err = gitlab.NewCachingTransport(transport, cacheCfg)
+				if err != nil {
+					logger.Fatalf("Failed to initialize GitLab response cache: %v", err)
+				}
+				transport = cache
+				if metrics != nil {
+					metrics.RegisterCache(cache)
+				}
+				logger.Infof("GitLab response cache enabled (TTLs: %v, max entries: %d, max MB: %d, directory: %q)", cacheTTLs, cacheCfg.MaxEntries, cacheCfg.MaxBytes>>20, cacheCfg.Dir)
+				go cache.LogStatsEvery(ctx, logger, cacheStatsInterval)
+			}
+			clientOpts = append(clientOpts, gl.WithHTTPClient(&http.Client{Transport: transport}))
 			glClient, err := gl.NewClient(token, clientOpts...)
 			if err != nil {
 				logger.Fatalf("Failed to initialize GitLab client: %v", err)
@@ -104,7 +196,7 @@ var (
 			// t, dumpTranslations := translations.TranslationHelper()
 
 			// Initialize Toolsets, passing the getClient function
-			toolsetGroup, err := gitlab.InitToolsets(enabledToolsets, readOnly, getClient /*, t */)
+			toolsetGroup, err := gitlab.InitToolsets(enabledToolsets, readOnly, getClient, toolsetCfg /*, t */)
 			if err != nil {
 				logger.Fatalf("Failed to initialize toolsets: %v", err)
 			}
@@ -112,11 +204,42 @@ var (
 
 			// Create MCP Server
 			// Use app name and version
-			mcpServer := gitlab.NewServer("gitlab-mcp-server", version)
+			var serverOpts []server.ServerOption
+			if outputFormat != gitlab.FormatJSON {
+				logger.Infof("Default output format: %s", outputFormat)
+				serverOpts = append(serverOpts, gitlab.WithDefaultOutputFormat(outputFormat))
+			}
+			if responseLimits.MaxBytes > 0 || len(responseLimits.PerTool) > 0 {
+				logger.Infof("Response size limit: %d bytes (per-tool overrides: %v)", responseLimits.MaxBytes, responseLimits.PerTool)
+				serverOpts = append(serverOpts, gitlab.WithResponseLimits(responseLimits))
+			}
+			auditPath := viper.GetString("audit-log")
+			if auditPath != "" {
+				sink, err := gitlab.OpenAuditSink(auditPath)
+				if err != nil {
+					logger.Fatalf("Failed to open audit log: %v", err)
+				}
+				defer sink.Close()
+				logger.Infof("Audit log of tool calls: %s", auditPath)
+				auditLog := gitlab.NewAuditLog(sink, getClient, toolsetGroup.WriteToolNames(), logger)
+				serverOpts = append([]server.ServerOption{gitlab.WithAuditLog(auditLog)}, serverOpts...)
+			}
+			if metrics != nil {
+				serverOpts = append(serverOpts, gitlab.WithMetrics(metrics))
+			}
+			subscriptions := gitlab.NewSubscriptions(getClient, logger)
+			serverOpts = append(serverOpts, gitlab.WithSubscriptions(subscriptions))
+			if tracerProvider != nil {
+				// Outermost, so that the span covers the other middlewares
+				serverOpts = append([]server.ServerOption{gitlab.WithTracing(tracerProvider)}, serverOpts...)
+			}
+			mcpServer := gitlab.NewServer("gitlab-mcp-server", version, serverOpts...)
 			logger.Info("MCP server wrapper created")
 
 			// Register Toolsets with the server (does not return error)
 			toolsetGroup.RegisterTools(mcpServer)
+			toolsetGroup.RegisterResourceTemplates(mcpServer)
+			toolsetGroup.RegisterPrompts(mcpServer)
 			logger.Info("Toolsets registered with MCP server")
 
 			// Create Stdio Server
@@ -128,15 +251,39 @@ var (
 
 			// Start Listening in a goroutine
 			errC := make(chan error, 1)
+			var metricsServer *http.Server
+			if metrics != nil {
+				mux := http.NewServeMux()
+				mux.Handle("/metrics", metrics.Handler())
+				metricsServer = &http.Server{Addr: metricsAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+				go func() {
+					logger.Infof("Serving Prometheus metrics on %s/metrics", metricsAddr)
+					if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+						errC <- fmt.Errorf("metrics server: %w", err)
+					}
+				}()
+			}
+			var webhookServer *http.Server
+			if webhookAddr != "" {
+				mux := http.NewServeMux()
+				mux.Handle("/webhook", gitlab.NewWebhookHandler(webhookSecret, subscriptions, logger))
+				webhookServer = &http.Server{Addr: webhookAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+				go func() {
+					logger.Infof("Receiving GitLab webhooks on %s/webhook", webhookAddr)
+					if err := webhookServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+						errC <- fmt.Errorf("webhook server: %w", err)
+					}
+				}()
+			}
+			if pollInterval := viper.GetDuration("subscription-poll-interval"); pollInterval > 0 {
+				logger.Infof("Checking subscribed resources every %s", pollInterval)
+				go subscriptions.PollEvery(ctx, pollInterval)
+			} else {
+				logger.Info("Polling of subscribed resources disabled")
+			}
 			go func() {
 				logger.Info("Starting to listen on stdio...")
-				// TODO: Add command logging wrapper if flag is enabled
-				// in, out := io.Reader(os.Stdin), io.Writer(os.Stdout)
-				// if viper.GetBool("enable-command-logging") {
-				// 	 loggedIO := iolog.NewIOLogger(in, out, logger)
-				// 	 in, out = loggedIO, loggedIO
-				// }
-				errC <- stdioServer.Listen(ctx, os.Stdin, os.Stdout)
+				errC <- subscriptions.ListenStdio(ctx, stdioServer, os.Stdin, os.Stdout)
 			}()
 
 			// Announce readiness on stderr
@@ -156,6 +303,30 @@ var (
 				}
 			}
 
+			if metricsServer != nil {
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+				if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+					logger.Warnf("Failed to stop metrics server: %v", err)
+				}
+				cancel()
+			}
+			if webhookServer != nil {
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+				if err := webhookServer.Shutdown(shutdownCtx); err != nil {
+					logger.Warnf("Failed to stop webhook server: %v", err)
+				}
+				cancel()
+			}
+			if tracerProvider != nil {
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+				if err := tracerProvider.Shutdown(shutdownCtx); err != nil {
+					logger.Warnf("Failed to flush traces: %v", err)
+				}
+				cancel()
+			}
+			if cache != nil {
+				cache.LogStats(logger)
+			}
 			logger.Info("Server shutting down.")
 		},
 	}
@@ -170,16 +341,71 @@ func init() {
 	// Define persistent flags for the root command (and inherited by subcommands)
 	rootCmd.PersistentFlags().StringSlice("toolsets", gitlab.DefaultTools, "Comma-separated list of toolsets to enable (e.g., 'projects,issues' or 'all')")
 	rootCmd.PersistentFlags().Bool("read-only", false, "Restrict the server to read-only operations")
+	rootCmd.PersistentFlags().Bool("allow-protected-branch-writes", false, "Allow branch write tools to create or delete protected branches")
+	rootCmd.PersistentFlags().String("output-format", gitlab.FormatJSON, "Default format of tool results when a call does not pass 'format' (json, markdown or table)")
+	rootCmd.PersistentFlags().Int("max-response-bytes", 0, "Maximum size in bytes of a tool result before it is cut with a continuation token (0 for no limit)")
+	rootCmd.PersistentFlags().String("tool-max-response-bytes", "", "Comma-separated per-tool overrides of --max-response-bytes (e.g., 'getProjectFile=200000,getCommitDiff=50000')")
+	rootCmd.PersistentFlags().Int("max-retries", gitlab.DefaultMaxRetries, "Maximum retries of a GitLab API request that was rate limited (429) or failed with a server error (5xx); 0 disables retries")
+	rootCmd.PersistentFlags().Duration("retry-wait-min", gitlab.DefaultRetryWaitMin, "Initial backoff between retries of a GitLab API request")
+	rootCmd.PersistentFlags().Duration("retry-wait-max", gitlab.DefaultRetryWaitMax, "Maximum backoff between retries of a GitLab API request")
+	rootCmd.PersistentFlags().Duration("max-retry-after", gitlab.DefaultMaxRetryAfter, "Longest Retry-After or RateLimit-Reset wait to honor before giving up on a rate-limited request (0 for no limit)")
+	rootCmd.PersistentFlags().Float64("rate-limit", 0, "Maximum GitLab API requests per second, shared by all tool calls (0 to pace requests from GitLab's RateLimit-Limit header)")
+	rootCmd.PersistentFlags().Int("rate-limit-burst", 0, "Requests allowed in a burst above --rate-limit (0 for one second's worth)")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Disable the cache of GitLab API GET responses")
+	rootCmd.PersistentFlags().String("cache-dir", "", "Optional: Directory where cached GitLab API responses are also stored, to reuse them across restarts")
+	rootCmd.PersistentFlags().Int("cache-max-entries", gitlab.DefaultCacheMaxEntries, "Maximum number of GitLab API responses cached in memory")
+	rootCmd.PersistentFlags().Int("cache-max-mb", gitlab.DefaultCacheMaxBytes>>20, "Maximum size in megabytes of the GitLab API responses cached in memory")
+	rootCmd.PersistentFlags().Int("cache-dir-max-mb", gitlab.DefaultCacheMaxDiskBytes>>20, "Maximum size in megabytes of the responses stored in --cache-dir; the least recently stored are removed first")
+	rootCmd.PersistentFlags().String("cache-ttl", "", "Comma-separated overrides of the cache TTL per endpoint class (e.g., 'metadata=10m,repository=1m,activity=0s')")
+	rootCmd.PersistentFlags().Duration("subscription-poll-interval", gitlab.DefaultSubscriptionPollInterval, "Interval between checks of the issues, merge requests and pipelines that clients subscribed to (0 disables polling)")
 	rootCmd.PersistentFlags().String("gitlab-host", "", "Optional: Specify the GitLab hostname for self-managed instances (e.g., gitlab.example.com)")
 	rootCmd.PersistentFlags().String("gitlab-token", "", "GitLab Personal Access Token (required)")
 	rootCmd.PersistentFlags().String("log-file", "", "Optional: Path to write log output to a file")
 	rootCmd.PersistentFlags().String("log-level", "info", "Log level (e.g., debug, info, warn, error)")
-	// TODO: Add optional flags like --enable-command-logging if needed later
+	rootCmd.PersistentFlags().String("metrics-addr", "", "Optional: Address to serve Prometheus metrics on at /metrics (e.g., ':9090')")
+	rootCmd.PersistentFlags().String("webhook-addr", "", "Optional: Address to receive GitLab webhooks on at /webhook, sent to the sessions subscribed to their project (e.g., ':8080')")
+	rootCmd.PersistentFlags().String("webhook-secret", "", "Secret token of the GitLab webhooks, checked against their X-Gitlab-Token header (required with --webhook-addr)")
+	rootCmd.PersistentFlags().String("otlp-endpoint", "", "Optional: OTLP/HTTP endpoint to export traces of tool calls and GitLab API requests to (e.g., 'http://localhost:4318')")
+	rootCmd.PersistentFlags().String("audit-log", "", "Optional: File to append a JSON-lines audit log of every tool call to, or 'stderr'")
 
 	// Bind persistent flags to Viper
 	// Note the mapping from flag name (kebab-case) to viper key (often snake_case or kept kebab-case) and ENV var (UPPER_SNAKE_CASE)
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
 	_ = viper.BindPFlag("read-only", rootCmd.PersistentFlags().Lookup("read-only"))
+	// Viper key "allow-protected-branch-writes" -> GITLAB_ALLOW_PROTECTED_BRANCH_WRITES
+	_ = viper.BindPFlag("allow-protected-branch-writes", rootCmd.PersistentFlags().Lookup("allow-protected-branch-writes"))
+	// Viper key "output-format" -> GITLAB_OUTPUT_FORMAT
+	_ = viper.BindPFlag("output-format", rootCmd.PersistentFlags().Lookup("output-format"))
+	// Viper keys "max-response-bytes" and "tool-max-response-bytes" -> GITLAB_MAX_RESPONSE_BYTES and GITLAB_TOOL_MAX_RESPONSE_BYTES
+	_ = viper.BindPFlag("max-response-bytes", rootCmd.PersistentFlags().Lookup("max-response-bytes"))
+	_ = viper.BindPFlag("tool-max-response-bytes", rootCmd.PersistentFlags().Lookup("tool-max-response-bytes"))
+	// Viper keys "max-retries", "retry-wait-min", "retry-wait-max", "max-retry-after", "rate-limit" and "rate-limit-burst"
+	// -> GITLAB_MAX_RETRIES, GITLAB_RETRY_WAIT_MIN, GITLAB_RETRY_WAIT_MAX, GITLAB_MAX_RETRY_AFTER, GITLAB_RATE_LIMIT and GITLAB_RATE_LIMIT_BURST
+	_ = viper.BindPFlag("max-retries", rootCmd.PersistentFlags().Lookup("max-retries"))
+	_ = viper.BindPFlag("retry-wait-min", rootCmd.PersistentFlags().Lookup("retry-wait-min"))
+	_ = viper.BindPFlag("retry-wait-max", rootCmd.PersistentFlags().Lookup("retry-wait-max"))
+	_ = viper.BindPFlag("max-retry-after", rootCmd.PersistentFlags().Lookup("max-retry-after"))
+	_ = viper.BindPFlag("rate-limit", rootCmd.PersistentFlags().Lookup("rate-limit"))
+	_ = viper.BindPFlag("rate-limit-burst", rootCmd.PersistentFlags().Lookup("rate-limit-burst"))
+	// Viper keys "no-cache", "cache-dir", "cache-dir-max-mb", "cache-max-entries", "cache-max-mb" and "cache-ttl"
+	// -> GITLAB_NO_CACHE, GITLAB_CACHE_DIR, GITLAB_CACHE_DIR_MAX_MB, GITLAB_CACHE_MAX_ENTRIES, GITLAB_CACHE_MAX_MB and GITLAB_CACHE_TTL
+	_ = viper.BindPFlag("no-cache", rootCmd.PersistentFlags().Lookup("no-cache"))
+	_ = viper.BindPFlag("cache-dir", rootCmd.PersistentFlags().Lookup("cache-dir"))
+	_ = viper.BindPFlag("cache-dir-max-mb", rootCmd.PersistentFlags().Lookup("cache-dir-max-mb"))
+	_ = viper.BindPFlag("cache-max-entries", rootCmd.PersistentFlags().Lookup("cache-max-entries"))
+	_ = viper.BindPFlag("cache-max-mb", rootCmd.PersistentFlags().Lookup("cache-max-mb"))
+	_ = viper.BindPFlag("cache-ttl", rootCmd.PersistentFlags().Lookup("cache-ttl"))
+	// Viper key "subscription-poll-interval" -> GITLAB_SUBSCRIPTION_POLL_INTERVAL
+	_ = viper.BindPFlag("subscription-poll-interval", rootCmd.PersistentFlags().Lookup("subscription-poll-interval"))
+	// Viper key "metrics-addr" -> GITLAB_METRICS_ADDR
+	_ = viper.BindPFlag("metrics-addr", rootCmd.PersistentFlags().Lookup("metrics-addr"))
+	// Viper keys "webhook-addr" and "webhook-secret" -> GITLAB_WEBHOOK_ADDR and GITLAB_WEBHOOK_SECRET
+	_ = viper.BindPFlag("webhook-addr", rootCmd.PersistentFlags().Lookup("webhook-addr"))
+	_ = viper.BindPFlag("webhook-secret", rootCmd.PersistentFlags().Lookup("webhook-secret"))
+	// Viper key "otlp-endpoint" -> GITLAB_OTLP_ENDPOINT
+	_ = viper.BindPFlag("otlp-endpoint", rootCmd.PersistentFlags().Lookup("otlp-endpoint"))
+	// Viper key "audit-log" -> GITLAB_AUDIT_LOG
+	_ = viper.BindPFlag("audit-log", rootCmd.PersistentFlags().Lookup("audit-log"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gitlab-host"))    // Viper key "host" -> GITLAB_HOST
 	_ = viper.BindPFlag("token", rootCmd.PersistentFlags().Lookup("gitlab-token"))  // Viper key "token" -> GITLAB_TOKEN
 	_ = viper.BindPFlag("log.file", rootCmd.PersistentFlags().Lookup("log-file"))   // Viper key "log.file" -> GITLAB_LOG_FILE
@@ -193,6 +419,8 @@ func init() {
 func initConfig() {
 	// Set ENV var prefix
 	viper.SetEnvPrefix("GITLAB")
+	// Map keys like "read-only" and "log.file" to GITLAB_READ_ONLY and GITLAB_LOG_FILE
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
 	// Read in environment variables that match defined flags/keys
 	viper.AutomaticEnv()
 
@@ -0,0 +1,259 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+// maxAuditValueBytes is the length beyond which string arguments, such as file contents,
+// are cut in audit entries.
+const maxAuditValueBytes = 1024
+
+// Outcomes of a tool call recorded in the audit log.
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeError   = "error"
+)
+
+// sensitiveArgumentNames are the substrings of argument names whose values are redacted.
+var sensitiveArgumentNames = []string{"token", "password", "secret"}
+
+// AuditEntry is the record of one tool call, written as a JSON line.
+type AuditEntry struct {
+	Time        time.Time      `json:"time"`
+	Session     string         `json:"session,omitempty"`
+	Tool        string         `json:"tool"`
+	Arguments   map[string]any `json:"arguments,omitempty"`
+	User        string         `json:"user,omitempty"`
+	Project     string         `json:"project,omitempty"`
+	DurationMS  int64          `json:"duration_ms"`
+	Outcome     string         `json:"outcome"`
+	Error       string         `json:"error,omitempty"`
+	ResourceURL string         `json:"resource_url,omitempty"`
+}
+
+// AuditLog records every tool call as a JSON line written to a sink.
+type AuditLog struct {
+	w          io.Writer
+	getClient  GetClientFn
+	writeTools map[string]bool
+	logger     log.FieldLogger
+	now        func() time.Time
+
+	mu   sync.Mutex // Guards w and user
+	user string     // Username of the token's user, resolved on the first call
+}
+
+// NewAuditLog returns an audit log writing to w. The GitLab user is resolved with getClient;
+// the resource URL is recorded for the tools named in writeTools. Failures to write the log
+// are reported to logger.
+func NewAuditLog(w io.Writer, getClient GetClientFn, writeTools []string, logger log.FieldLogger) *AuditLog {
+	a := &AuditLog{
+		w:          w,
+		getClient:  getClient,
+		writeTools: make(map[string]bool, len(writeTools)),
+		logger:     logger,
+		now:        time.Now,
+	}
+	for _, name := range writeTools {
+		a.writeTools[name] = true
+	}
+	return a
+}
+
+// OpenAuditSink opens the destination of an audit log: "stderr", or a file that is created
+// if needed and appended to. Standard output is reserved for the MCP protocol.
+func OpenAuditSink(path string) (io.WriteCloser, error) {
+	if path == "stderr" {
+		return nopCloser{os.Stderr}, nil
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file %q: %w", path, err)
+	}
+	return file, nil
+}
+
+// nopCloser is a writer whose Close does nothing, for sinks that must stay open.
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+// WithAuditLog returns a server option recording every tool call in audit.
+func WithAuditLog(audit *AuditLog) server.ServerOption {
+	return server.WithToolHandlerMiddleware(audit.middleware)
+}
+
+// middleware wraps a tool handler to record its calls.
+func (a *AuditLog) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := a.now()
+		result, err := next(ctx, request)
+
+		args := request.GetArguments()
+		entry := AuditEntry{
+			Time:       start.UTC(),
+			Tool:       request.Params.Name,
+			Arguments:  sanitizeArguments(args),
+			User:       a.currentUser(ctx),
+			DurationMS: a.now().Sub(start).Milliseconds(),
+			Outcome:    AuditOutcomeSuccess,
+		}
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			entry.Session = session.SessionID()
+		}
+		if project, ok := args["projectId"]; ok && project != nil {
+			entry.Project = fmt.Sprint(project)
+		}
+		switch {
+		case err != nil:
+			entry.Outcome, entry.Error = AuditOutcomeError, err.Error()
+		case result != nil && result.IsError:
+			entry.Outcome, entry.Error = AuditOutcomeError, resultText(result)
+		case a.writeTools[entry.Tool]:
+			entry.ResourceURL = resourceURL(result)
+		}
+		a.record(entry)
+		return result, err
+	}
+}
+
+// record writes entry as a JSON line.
+func (a *AuditLog) record(entry AuditEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		a.logger.WithError(err).WithField("tool", entry.Tool).Error("Failed to encode audit log entry")
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(append(data, '\n')); err != nil {
+		a.logger.WithError(err).WithField("tool", entry.Tool).Error("Failed to write audit log entry")
+	}
+}
+
+// currentUser returns the username of the token's user. It is looked up on the first call
+// and again after a failed lookup, so that a transient error does not leave every entry
+// without a user.
+func (a *AuditLog) currentUser(ctx context.Context) string {
+	a.mu.Lock()
+	user := a.user
+	a.mu.Unlock()
+	if user != "" {
+		return user
+	}
+
+	client, err := a.getClient(ctx)
+	if err != nil {
+		a.logger.WithError(err).Warn("Failed to get GitLab client for the audit log")
+		return ""
+	}
+	current, _, err := client.Users.CurrentUser(gl.WithContext(ctx))
+	if err != nil {
+		a.logger.WithError(err).Warn("Failed to look up the GitLab user for the audit log")
+		return ""
+	}
+	a.mu.Lock()
+	a.user = current.Username
+	a.mu.Unlock()
+	return current.Username
+}
+
+// sanitizeArguments returns a copy of args with the values of sensitive arguments redacted
+// and long strings cut.
+func sanitizeArguments(args map[string]any) map[string]any {
+	if len(args) == 0 {
+		return nil
+	}
+	sanitized := make(map[string]any, len(args))
+	for name, value := range args {
+		if sensitiveArgument(name) {
+			sanitized[name] = "[REDACTED]"
+			continue
+		}
+		sanitized[name] = sanitizeValue(value)
+	}
+	return sanitized
+}
+
+// sanitizeValue applies sanitizeArguments to the objects nested in value and cuts long strings.
+func sanitizeValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		if cut, truncated := truncateText(v, maxAuditValueBytes); truncated {
+			return fmt.Sprintf("%s... (%d bytes)", cut, len(v))
+		}
+		return v
+	case map[string]any:
+		return sanitizeArguments(v)
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = sanitizeValue(item)
+		}
+		return items
+	}
+	return value
+}
+
+// sensitiveArgument reports whether the value of the named argument must not be logged.
+func sensitiveArgument(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveArgumentNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// resultText returns the text content of result, such as the message of an error result.
+func resultText(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := mcp.AsTextContent(content); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// resourceURL returns the web URL of the resource a write tool created or modified, found in
+// the 'web_url' or '_links.self' field of its result, or "" when the result has neither.
+func resourceURL(result *mcp.CallToolResult) string {
+	if result == nil {
+		return ""
+	}
+	var data []byte
+	if result.StructuredContent != nil {
+		data, _ = json.Marshal(result.StructuredContent)
+	} else {
+		data = []byte(resultText(result))
+	}
+	var resource struct {
+		WebURL string `json:"web_url"`
+		Links  struct {
+			Self string `json:"self"`
+		} `json:"_links"`
+	}
+	if json.Unmarshal(data, &resource) != nil {
+		return ""
+	}
+	if resource.WebURL != "" {
+		return resource.WebURL
+	}
+	return resource.Links.Self
+}
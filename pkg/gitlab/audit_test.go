@@ -0,0 +1,115 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gl "gitlab.com/gitlab-org/api/client-go"
+	mock_gitlab "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuditLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockBranches := mock_gitlab.NewMockBranchesServiceInterface(ctrl)
+	mockUsers := mock_gitlab.NewMockUsersServiceInterface(ctrl)
+	client := &gl.Client{Branches: mockBranches, Users: mockUsers}
+	getClient := func(_ context.Context) (*gl.Client, error) { return client, nil }
+
+	// The user is looked up once, then reused for every entry
+	mockUsers.EXPECT().CurrentUser(gomock.Any()).Return(&gl.User{Username: "alice"}, nil, nil).Times(1)
+	mockBranches.EXPECT().
+		CreateBranch("group/project", gomock.Any(), gomock.Any()).
+		Return(&gl.Branch{Name: "feature", WebURL: "https://gitlab.com/group/project/-/tree/feature"}, &gl.Response{Response: &http.Response{StatusCode: 201}}, nil)
+	mockBranches.EXPECT().
+		CreateBranch("group/project", gomock.Any(), gomock.Any()).
+		Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, gl.ErrNotFound)
+
+	var sink bytes.Buffer
+	logger, _ := test.NewNullLogger()
+	audit := NewAuditLog(&sink, getClient, []string{"createBranch"}, logger)
+	s := NewServer("test", "0.0.0", WithAuditLog(audit))
+	tool, handler := CreateBranch(getClient, true)
+	s.AddTool(tool, handler)
+	s.AddTool(mcp.NewTool("echo"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(`{"web_url": "https://gitlab.com/ignored"}`), nil
+	})
+
+	callTool(t, s, "createBranch", map[string]any{"projectId": "group/project", "branch": "feature", "ref": "main"})
+	callTool(t, s, "createBranch", map[string]any{"projectId": "group/project", "branch": "missing", "ref": "nope"})
+	callTool(t, s, "echo", map[string]any{"private_token": "glpat-secret", "content": strings.Repeat("x\n", 1000)})
+
+	lines := strings.Split(strings.TrimSuffix(sink.String(), "\n"), "\n")
+	require.Len(t, lines, 3, "One JSON line per tool call")
+	entries := make([]AuditEntry, len(lines))
+	for i, line := range lines {
+		require.NoError(t, json.Unmarshal([]byte(line), &entries[i]))
+		assert.Equal(t, "alice", entries[i].User)
+		assert.False(t, entries[i].Time.IsZero())
+		assert.GreaterOrEqual(t, entries[i].DurationMS, int64(0))
+	}
+
+	created := entries[0]
+	assert.Equal(t, "createBranch", created.Tool)
+	assert.Equal(t, "group/project", created.Project)
+	assert.Equal(t, AuditOutcomeSuccess, created.Outcome)
+	assert.Equal(t, "https://gitlab.com/group/project/-/tree/feature", created.ResourceURL)
+	assert.Equal(t, map[string]any{"projectId": "group/project", "branch": "feature", "ref": "main"}, created.Arguments)
+
+	failed := entries[1]
+	assert.Equal(t, AuditOutcomeError, failed.Outcome)
+	assert.Contains(t, failed.Error, "not found, or access denied (404)")
+	assert.Empty(t, failed.ResourceURL)
+
+	read := entries[2]
+	assert.Empty(t, read.Project)
+	assert.Empty(t, read.ResourceURL, "Resource URLs are only recorded for write tools")
+	assert.Equal(t, "[REDACTED]", read.Arguments["private_token"])
+	assert.NotContains(t, lines[2], "glpat-secret")
+	content, ok := read.Arguments["content"].(string)
+	require.True(t, ok)
+	assert.Less(t, len(content), maxAuditValueBytes+32, "Long arguments are cut")
+	assert.True(t, strings.HasSuffix(content, "... (2000 bytes)"))
+}
+
+func TestSanitizeArguments(t *testing.T) {
+	args := map[string]any{
+		"projectId": "group/project",
+		"options":   map[string]any{"secretValue": "s3cr3t", "tags": []any{"a", map[string]any{"password": "p"}}},
+		"count":     float64(2),
+	}
+	expected := map[string]any{
+		"projectId": "group/project",
+		"options":   map[string]any{"secretValue": "[REDACTED]", "tags": []any{"a", map[string]any{"password": "[REDACTED]"}}},
+		"count":     float64(2),
+	}
+	assert.Equal(t, expected, sanitizeArguments(args))
+	assert.Nil(t, sanitizeArguments(nil))
+}
+
+func TestOpenAuditSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	for _, line := range []string{"first\n", "second\n"} {
+		sink, err := OpenAuditSink(path)
+		require.NoError(t, err)
+		_, err = sink.Write([]byte(line))
+		require.NoError(t, err)
+		require.NoError(t, sink.Close())
+	}
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond\n", string(data), "Existing entries are appended to")
+
+	_, err = OpenAuditSink(filepath.Join(t.TempDir(), "missing", "audit.jsonl"))
+	assert.Error(t, err)
+}
@@ -2,9 +2,9 @@ package gitlab
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
+	"regexp"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -18,7 +18,7 @@ func GetProjectBranches(getClient GetClientFn) (tool mcp.Tool, handler server.To
 			mcp.WithDescription("Retrieves a list of repository branches from a project, sorted by name alphabetically."),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        "List Project Branches",
-				ReadOnlyHint: true,
+				ReadOnlyHint: mcp.ToBoolPtr(true),
 			}),
 			mcp.WithString("projectId",
 				mcp.Required(),
@@ -29,6 +29,7 @@ func GetProjectBranches(getClient GetClientFn) (tool mcp.Tool, handler server.To
 			),
 			// Add standard MCP pagination parameters
 			WithPagination(),
+			WithListOutput(branchSchema),
 		),
 		// Handler function implementation
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -41,7 +42,11 @@ func GetProjectBranches(getClient GetClientFn) (tool mcp.Tool, handler server.To
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
-			page, perPage, err := OptionalPaginationParams(&request)
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, branchSchema)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
@@ -49,8 +54,7 @@ func GetProjectBranches(getClient GetClientFn) (tool mcp.Tool, handler server.To
 			// --- Construct GitLab API options
 			opts := &gl.ListBranchesOptions{
 				ListOptions: gl.ListOptions{
-					Page:    page,
-					PerPage: perPage,
+					PerPage: listParams.PerPage,
 				},
 			}
 			if search != "" {
@@ -64,31 +68,433 @@ func GetProjectBranches(getClient GetClientFn) (tool mcp.Tool, handler server.To
 			}
 
 			// --- Call GitLab API
-			branches, resp, err := glClient.Branches.ListBranches(projectIDStr, opts, gl.WithContext(ctx))
+			branches, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.Branch, *gl.Response, error) {
+				opts.Page = page
+				return glClient.Branches.ListBranches(projectIDStr, opts, gl.WithContext(ctx))
+			})
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list branches for project %q", projectIDStr),
+					fmt.Sprintf("project %q not found or access denied", projectIDStr),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newListResult(branches, pagination, output, "branch list data")
+		}
+}
+
+// protectedBranchPattern reports whether a branch name matches a protected branch rule name,
+// which may contain '*' wildcards (e.g. "release/*").
+func protectedBranchPattern(pattern, branch string) bool {
+	if !strings.Contains(pattern, "*") {
+		return pattern == branch
+	}
+	parts := strings.Split(pattern, "*")
+	expr := "^" + regexp.QuoteMeta(parts[0])
+	for _, part := range parts[1:] {
+		expr += ".*" + regexp.QuoteMeta(part)
+	}
+	matched, err := regexp.MatchString(expr+"$", branch)
+	return err == nil && matched
+}
+
+// GetBranch defines the MCP tool for retrieving a single branch.
+func GetBranch(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getBranch",
+			mcp.WithDescription("Retrieves a single repository branch, including its latest commit and protection status."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get Branch",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("The name of the branch."),
+			),
+			WithOutput(branchSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, branchSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			branchName, err := requiredParam[string](&request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			branch, resp, err := glClient.Branches.GetBranch(projectIDStr, branchName, gl.WithContext(ctx))
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get branch %q from project %q", branchName, projectIDStr),
+					fmt.Sprintf("branch %q not found in project %q or access denied", branchName, projectIDStr),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newProjectedResult(branch, output, "branch data")
+		}
+}
+
+// CreateBranch defines the MCP tool for creating a new branch from a ref.
+// Unless allowProtected is set, it refuses to create branches matching a protected branch rule.
+func CreateBranch(getClient GetClientFn, allowProtected bool) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"createBranch",
+			mcp.WithDescription("Creates a new repository branch from an existing branch, tag or commit SHA. Branches matching a protected branch rule are refused unless the server allows protected branch writes."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          "Create Branch",
+				ReadOnlyHint:   mcp.ToBoolPtr(false),
+				IdempotentHint: mcp.ToBoolPtr(false),
+			}),
+			WithResultSchema(gl.Branch{}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("The name of the new branch."),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("The branch name, tag or commit SHA to create the branch from."),
+			),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			branchName, err := requiredParam[string](&request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			ref, err := requiredParam[string](&request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Refuse to create branches covered by a protection rule
+			if !allowProtected {
+				// Every rule must be checked, as a wildcard on any page may match
+				opts := &gl.ListProtectedBranchesOptions{ListOptions: gl.ListOptions{PerPage: MaxPerPage}}
+				rules, pagination, resp, err := collectPages(ListParams{Page: 1, PerPage: MaxPerPage, MaxItems: MaxItemsLimit}, func(page int) ([]*gl.ProtectedBranch, *gl.Response, error) {
+					opts.Page = page
+					return glClient.ProtectedBranches.ListProtectedBranches(projectIDStr, opts, gl.WithContext(ctx))
+				})
+				if err != nil {
+					return apiErrorResult(err, resp,
+						fmt.Sprintf("failed to list protected branches for project %q", projectIDStr),
+						fmt.Sprintf("project %q not found or access denied", projectIDStr),
+					), nil
+				}
+				if pagination.HasMore {
+					msg := fmt.Sprintf("project %q has more than %d protected branch rules; cannot check that branch %q is not protected", projectIDStr, MaxItemsLimit, branchName)
+					return mcp.NewToolResultError(msg), nil
+				}
+				for _, rule := range rules {
+					if protectedBranchPattern(rule.Name, branchName) {
+						msg := fmt.Sprintf("branch %q matches protected branch rule %q in project %q; protected branch writes are disabled on this server", branchName, rule.Name, projectIDStr)
+						return mcp.NewToolResultError(msg), nil
+					}
+				}
+			}
+
+			// --- Call GitLab API
+			opts := &gl.CreateBranchOptions{
+				Branch: &branchName,
+				Ref:    &ref,
+			}
+			branch, resp, err := glClient.Branches.CreateBranch(projectIDStr, opts, gl.WithContext(ctx))
 
 			// --- Handle API errors
 			if err != nil {
-				code := http.StatusInternalServerError
-				if resp != nil {
-					code = resp.StatusCode
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to create branch %q from %q in project %q", branchName, ref, projectIDStr),
+					fmt.Sprintf("project %q or ref %q not found, or access denied", projectIDStr, ref),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newJSONResult(branch, "branch data")
+		}
+}
+
+// DeleteBranch defines the MCP tool for deleting a branch.
+// Unless allowProtected is set, it refuses to delete protected branches and the default branch.
+func DeleteBranch(getClient GetClientFn, allowProtected bool) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"deleteBranch",
+			mcp.WithDescription("Deletes a repository branch. Protected branches are refused unless the server allows protected branch writes."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Delete Branch",
+				ReadOnlyHint:    mcp.ToBoolPtr(false),
+				DestructiveHint: mcp.ToBoolPtr(true),
+				IdempotentHint:  mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("The name of the branch to delete."),
+			),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			branchName, err := requiredParam[string](&request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Refuse to delete protected branches
+			if !allowProtected {
+				branch, resp, err := glClient.Branches.GetBranch(projectIDStr, branchName, gl.WithContext(ctx))
+				if err != nil {
+					return apiErrorResult(err, resp,
+						fmt.Sprintf("failed to get branch %q from project %q", branchName, projectIDStr),
+						fmt.Sprintf("branch %q not found in project %q or access denied", branchName, projectIDStr),
+					), nil
+				}
+				if branch.Protected {
+					msg := fmt.Sprintf("branch %q in project %q is protected; protected branch writes are disabled on this server", branchName, projectIDStr)
+					return mcp.NewToolResultError(msg), nil
 				}
-				if code == http.StatusNotFound {
-					msg := fmt.Sprintf("project %q not found or access denied (%d)", projectIDStr, code)
+				if branch.Default {
+					msg := fmt.Sprintf("branch %q is the default branch of project %q and cannot be deleted; set another default branch first", branchName, projectIDStr)
 					return mcp.NewToolResultError(msg), nil
 				}
-				return nil, fmt.Errorf("failed to list branches for project %q: %w (status: %d)", projectIDStr, err, code)
+			}
+
+			// --- Call GitLab API
+			resp, err := glClient.Branches.DeleteBranch(projectIDStr, branchName, gl.WithContext(ctx))
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to delete branch %q from project %q", branchName, projectIDStr),
+					fmt.Sprintf("branch %q not found in project %q or access denied", branchName, projectIDStr),
+				), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("branch %q deleted from project %q", branchName, projectIDStr)), nil
+		}
+}
+
+// DeleteMergedBranches defines the MCP tool for deleting all branches merged into the default branch.
+// GitLab itself never deletes protected branches through this endpoint.
+func DeleteMergedBranches(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"deleteMergedBranches",
+			mcp.WithDescription("Deletes all branches that are merged into the project's default branch. Protected branches are never deleted by this operation."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Delete Merged Branches",
+				ReadOnlyHint:    mcp.ToBoolPtr(false),
+				DestructiveHint: mcp.ToBoolPtr(true),
+				IdempotentHint:  mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			resp, err := glClient.Branches.DeleteMergedBranches(projectIDStr, gl.WithContext(ctx))
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to delete merged branches from project %q", projectIDStr),
+					fmt.Sprintf("project %q not found or access denied", projectIDStr),
+				), nil
+			}
+
+			// GitLab processes the deletion asynchronously
+			return mcp.NewToolResultText(fmt.Sprintf("deletion of merged branches scheduled for project %q", projectIDStr)), nil
+		}
+}
+
+// ListProtectedBranches defines the MCP tool for listing protected branch rules in a project.
+func ListProtectedBranches(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"listProtectedBranches",
+			mcp.WithDescription("Retrieves the protected branch rules of a project, including who can push and merge, force-push permission and code owner approval requirements."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "List Protected Branches",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("search",
+				mcp.Description("Return protected branches matching the search criteria."),
+			),
+			// Add standard MCP pagination parameters
+			WithPagination(),
+			WithListOutput(protectedBranchSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			search, err := OptionalParam[string](&request, "search")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, protectedBranchSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			opts := &gl.ListProtectedBranchesOptions{
+				ListOptions: gl.ListOptions{
+					PerPage: listParams.PerPage,
+				},
+			}
+			if search != "" {
+				opts.Search = &search
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			branches, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.ProtectedBranch, *gl.Response, error) {
+				opts.Page = page
+				return glClient.ProtectedBranches.ListProtectedBranches(projectIDStr, opts, gl.WithContext(ctx))
+			})
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list protected branches for project %q", projectIDStr),
+					fmt.Sprintf("project %q not found or access denied", projectIDStr),
+				), nil
 			}
 
 			// --- Marshal and return success
-			// Handle empty list gracefully
-			if len(branches) == 0 {
-				return mcp.NewToolResultText("[]"), nil // Return empty JSON array
+			return newListResult(branches, pagination, output, "protected branch list data")
+		}
+}
+
+// GetProtectedBranch defines the MCP tool for retrieving a single protected branch rule.
+func GetProtectedBranch(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getProtectedBranch",
+			mcp.WithDescription("Retrieves a single protected branch rule (or wildcard rule) with its push, merge and unprotect access levels."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get Protected Branch",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			WithResultSchema(gl.ProtectedBranch{}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("The name of the protected branch or wildcard rule (e.g., 'main' or 'release/*')."),
+			),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			branchName, err := requiredParam[string](&request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
 
-			data, err := json.Marshal(branches)
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal branch list data: %w", err)
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
 			}
-			return mcp.NewToolResultText(string(data)), nil
+
+			// --- Call GitLab API
+			branch, resp, err := glClient.ProtectedBranches.GetProtectedBranch(projectIDStr, branchName, gl.WithContext(ctx))
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get protected branch %q from project %q", branchName, projectIDStr),
+					fmt.Sprintf("protected branch %q not found in project %q or access denied", branchName, projectIDStr),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newJSONResult(branch, "protected branch data")
 		}
 }
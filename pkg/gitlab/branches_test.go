@@ -156,9 +156,9 @@ func TestGetProjectBranchesHandler(t *testing.T) {
 					ListBranches(projectID, gomock.Any(), gomock.Any()).
 					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
 			},
-			expectedResult:     nil,
-			expectHandlerError: true,
-			errorContains:      fmt.Sprintf("failed to list branches for project %q", projectID),
+			expectedResult:    nil,
+			expectResultError: true,
+			errorContains:     fmt.Sprintf("failed to list branches for project %q", projectID),
 		},
 		{
 			name:               "Error - Missing projectId",
@@ -178,13 +178,7 @@ func TestGetProjectBranchesHandler(t *testing.T) {
 
 			// Create the request
 			req := mcp.CallToolRequest{
-				Params: struct {
-					Name      string                 `json:"name"`
-					Arguments map[string]interface{} `json:"arguments,omitempty"`
-					Meta      *struct {
-						ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-					} `json:"_meta,omitempty"`
-				}{
+				Params: mcp.CallToolParams{
 					Name:      getProjectBranchesTool.Name,
 					Arguments: tc.inputArgs,
 				},
@@ -207,8 +201,9 @@ func TestGetProjectBranchesHandler(t *testing.T) {
 					assert.Contains(t, textContent.Text, tc.errorContains, "Error message mismatch")
 				} else {
 					// Unmarshal expected and actual results
+					items, _ := getListResult(t, result)
 					var actualBranches []*gl.Branch
-					err = json.Unmarshal([]byte(textContent.Text), &actualBranches)
+					err = json.Unmarshal([]byte(items), &actualBranches)
 					require.NoError(t, err, "Failed to unmarshal actual result JSON")
 
 					// Compare lengths first
@@ -223,3 +218,305 @@ func TestGetProjectBranchesHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestProtectedBranchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		branch  string
+		want    bool
+	}{
+		{"main", "main", true},
+		{"main", "main-2", false},
+		{"release/*", "release/1.0", true},
+		{"release/*", "release", false},
+		{"*-stable", "2-3-stable", true},
+		{"*", "anything/goes", true},
+		{"v1.*", "v1x0", false},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, protectedBranchPattern(tc.pattern, tc.branch), "pattern %q vs branch %q", tc.pattern, tc.branch)
+	}
+}
+
+func TestGetBranchHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockBranches, ctrl := setupMockClientForBranches(t)
+	defer ctrl.Finish()
+
+	mockGetClientBranches := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	getBranchTool, getBranchHandler := GetBranch(mockGetClientBranches)
+	projectID := "group/project"
+
+	t.Run("Success - Get Branch", func(t *testing.T) {
+		mockBranches.EXPECT().
+			GetBranch(projectID, "feature", gomock.Any()).
+			Return(&gl.Branch{Name: "feature", Protected: false}, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+
+		result, err := getBranchHandler(ctx, createCallToolRequest(getBranchTool.Name, map[string]any{"projectId": projectID, "branch": "feature"}))
+		require.NoError(t, err)
+		var actual gl.Branch
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &actual))
+		assert.Equal(t, "feature", actual.Name)
+	})
+
+	t.Run("Error - Branch Not Found (404)", func(t *testing.T) {
+		mockBranches.EXPECT().
+			GetBranch(projectID, "missing", gomock.Any()).
+			Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Not Found"))
+
+		result, err := getBranchHandler(ctx, createCallToolRequest(getBranchTool.Name, map[string]any{"projectId": projectID, "branch": "missing"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, `branch "missing" not found`)
+	})
+}
+
+func TestCreateBranchHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockBranches, mockProtected, ctrl := setupMockClientForProtectedBranches(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	projectID := "group/project"
+	rules := []*gl.ProtectedBranch{{Name: "main"}, {Name: "release/*"}}
+	okResp := &gl.Response{Response: &http.Response{StatusCode: 200}}
+
+	tests := []struct {
+		name               string
+		allowProtected     bool
+		inputArgs          map[string]any
+		mockSetup          func()
+		expectHandlerError bool
+		expectResultError  bool
+		errorContains      string
+	}{
+		{
+			name:      "Success - Unprotected Branch",
+			inputArgs: map[string]any{"projectId": projectID, "branch": "feature/x", "ref": "main"},
+			mockSetup: func() {
+				mockProtected.EXPECT().ListProtectedBranches(projectID, gomock.Any(), gomock.Any()).Return(rules, okResp, nil)
+				mockBranches.EXPECT().
+					CreateBranch(projectID, &gl.CreateBranchOptions{Branch: gl.Ptr("feature/x"), Ref: gl.Ptr("main")}, gomock.Any()).
+					Return(&gl.Branch{Name: "feature/x"}, &gl.Response{Response: &http.Response{StatusCode: 201}}, nil)
+			},
+		},
+		{
+			name:      "Error - Matches Protected Wildcard",
+			inputArgs: map[string]any{"projectId": projectID, "branch": "release/2.0", "ref": "main"},
+			mockSetup: func() {
+				mockProtected.EXPECT().ListProtectedBranches(projectID, gomock.Any(), gomock.Any()).Return(rules, okResp, nil)
+			},
+			expectResultError: true,
+			errorContains:     `matches protected branch rule "release/*"`,
+		},
+		{
+			name:      "Error - Matches Protected Wildcard On Later Page",
+			inputArgs: map[string]any{"projectId": projectID, "branch": "hotfix/login", "ref": "main"},
+			mockSetup: func() {
+				gomock.InOrder(
+					mockProtected.EXPECT().
+						ListProtectedBranches(projectID, gomock.Any(), gomock.Any()).
+						Return(rules, &gl.Response{Response: &http.Response{StatusCode: 200}, NextPage: 2}, nil),
+					mockProtected.EXPECT().
+						ListProtectedBranches(projectID, gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ interface{}, opts *gl.ListProtectedBranchesOptions, _ ...gl.RequestOptionFunc) ([]*gl.ProtectedBranch, *gl.Response, error) {
+							assert.Equal(t, 2, opts.Page)
+							return []*gl.ProtectedBranch{{Name: "hotfix/*"}}, okResp, nil
+						}),
+				)
+			},
+			expectResultError: true,
+			errorContains:     `matches protected branch rule "hotfix/*"`,
+		},
+		{
+			name:           "Success - Protected Allowed By Config",
+			allowProtected: true,
+			inputArgs:      map[string]any{"projectId": projectID, "branch": "release/2.0", "ref": "main"},
+			mockSetup: func() {
+				mockBranches.EXPECT().
+					CreateBranch(projectID, gomock.Any(), gomock.Any()).
+					Return(&gl.Branch{Name: "release/2.0", Protected: true}, &gl.Response{Response: &http.Response{StatusCode: 201}}, nil)
+			},
+		},
+		{
+			name:      "Error - Branch Already Exists (400)",
+			inputArgs: map[string]any{"projectId": projectID, "branch": "feature/x", "ref": "main"},
+			mockSetup: func() {
+				mockProtected.EXPECT().ListProtectedBranches(projectID, gomock.Any(), gomock.Any()).Return(nil, okResp, nil)
+				mockBranches.EXPECT().
+					CreateBranch(projectID, gomock.Any(), gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 400}}, errors.New("Branch already exists"))
+			},
+			expectResultError: true,
+			errorContains:     "Branch already exists",
+		},
+		{
+			name:              "Error - Missing ref",
+			inputArgs:         map[string]any{"projectId": projectID, "branch": "feature/x"},
+			mockSetup:         func() {},
+			expectResultError: true,
+			errorContains:     "Validation Error: missing required parameter: ref",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+			createBranchTool, createBranchHandler := CreateBranch(mockGetClient, tc.allowProtected)
+
+			result, err := createBranchHandler(ctx, createCallToolRequest(createBranchTool.Name, tc.inputArgs))
+
+			if tc.expectHandlerError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectResultError {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.errorContains)
+				return
+			}
+			assert.False(t, result.IsError)
+			var actual gl.Branch
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &actual))
+			assert.Equal(t, tc.inputArgs["branch"], actual.Name)
+		})
+	}
+}
+
+func TestDeleteBranchHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockBranches, ctrl := setupMockClientForBranches(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	projectID := "group/project"
+	okResp := &gl.Response{Response: &http.Response{StatusCode: 200}}
+
+	t.Run("Success - Delete Unprotected Branch", func(t *testing.T) {
+		tool, handler := DeleteBranch(mockGetClient, false)
+		mockBranches.EXPECT().GetBranch(projectID, "feature", gomock.Any()).Return(&gl.Branch{Name: "feature"}, okResp, nil)
+		mockBranches.EXPECT().DeleteBranch(projectID, "feature", gomock.Any()).Return(&gl.Response{Response: &http.Response{StatusCode: 204}}, nil)
+
+		result, err := handler(ctx, createCallToolRequest(tool.Name, map[string]any{"projectId": projectID, "branch": "feature"}))
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, `branch "feature" deleted`)
+	})
+
+	t.Run("Error - Refuses Protected Branch", func(t *testing.T) {
+		tool, handler := DeleteBranch(mockGetClient, false)
+		mockBranches.EXPECT().GetBranch(projectID, "main", gomock.Any()).Return(&gl.Branch{Name: "main", Protected: true}, okResp, nil)
+
+		result, err := handler(ctx, createCallToolRequest(tool.Name, map[string]any{"projectId": projectID, "branch": "main"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "is protected")
+	})
+
+	t.Run("Error - Refuses Unprotected Default Branch", func(t *testing.T) {
+		tool, handler := DeleteBranch(mockGetClient, false)
+		mockBranches.EXPECT().GetBranch(projectID, "trunk", gomock.Any()).Return(&gl.Branch{Name: "trunk", Default: true}, okResp, nil)
+
+		result, err := handler(ctx, createCallToolRequest(tool.Name, map[string]any{"projectId": projectID, "branch": "trunk"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		text := getTextResult(t, result).Text
+		assert.Contains(t, text, `branch "trunk" is the default branch of project "group/project"`)
+		assert.NotContains(t, text, "protected")
+	})
+
+	t.Run("Success - Protected Allowed By Config", func(t *testing.T) {
+		tool, handler := DeleteBranch(mockGetClient, true)
+		mockBranches.EXPECT().DeleteBranch(projectID, "main", gomock.Any()).Return(&gl.Response{Response: &http.Response{StatusCode: 204}}, nil)
+
+		result, err := handler(ctx, createCallToolRequest(tool.Name, map[string]any{"projectId": projectID, "branch": "main"}))
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("Error - GitLab API Error (500)", func(t *testing.T) {
+		tool, handler := DeleteBranch(mockGetClient, true)
+		mockBranches.EXPECT().DeleteBranch(projectID, "feature", gomock.Any()).Return(&gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
+
+		result, err := handler(ctx, createCallToolRequest(tool.Name, map[string]any{"projectId": projectID, "branch": "feature"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "failed to delete branch")
+	})
+}
+
+func TestDeleteMergedBranchesHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockBranches, ctrl := setupMockClientForBranches(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	tool, handler := DeleteMergedBranches(mockGetClient)
+	projectID := "group/project"
+
+	mockBranches.EXPECT().DeleteMergedBranches(projectID, gomock.Any()).Return(&gl.Response{Response: &http.Response{StatusCode: 202}}, nil)
+
+	result, err := handler(ctx, createCallToolRequest(tool.Name, map[string]any{"projectId": projectID}))
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "scheduled")
+}
+
+func TestProtectedBranchReadHandlers(t *testing.T) {
+	ctx := context.Background()
+	mockClient, _, mockProtected, ctrl := setupMockClientForProtectedBranches(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	projectID := "group/project"
+	okResp := &gl.Response{Response: &http.Response{StatusCode: 200}}
+	rule := &gl.ProtectedBranch{
+		Name:                      "main",
+		CodeOwnerApprovalRequired: true,
+		PushAccessLevels:          []*gl.BranchAccessDescription{{AccessLevel: gl.MaintainerPermissions, AccessLevelDescription: "Maintainers"}},
+		MergeAccessLevels:         []*gl.BranchAccessDescription{{AccessLevel: gl.DeveloperPermissions, AccessLevelDescription: "Developers + Maintainers"}},
+	}
+
+	t.Run("List Protected Branches", func(t *testing.T) {
+		tool, handler := ListProtectedBranches(mockGetClient)
+		mockProtected.EXPECT().
+			ListProtectedBranches(projectID, gomock.AssignableToTypeOf(&gl.ListProtectedBranchesOptions{}), gomock.Any()).
+			DoAndReturn(func(_ interface{}, opts *gl.ListProtectedBranchesOptions, _ ...gl.RequestOptionFunc) ([]*gl.ProtectedBranch, *gl.Response, error) {
+				assert.Equal(t, "ma", *opts.Search)
+				return []*gl.ProtectedBranch{rule}, okResp, nil
+			})
+
+		result, err := handler(ctx, createCallToolRequest(tool.Name, map[string]any{"projectId": projectID, "search": "ma"}))
+		require.NoError(t, err)
+		items, _ := getListResult(t, result)
+		var actual []*gl.ProtectedBranch
+		require.NoError(t, json.Unmarshal([]byte(items), &actual))
+		require.Len(t, actual, 1)
+		assert.True(t, actual[0].CodeOwnerApprovalRequired)
+	})
+
+	t.Run("Get Protected Branch Not Found", func(t *testing.T) {
+		tool, handler := GetProtectedBranch(mockGetClient)
+		mockProtected.EXPECT().
+			GetProtectedBranch(projectID, "dev", gomock.Any()).
+			Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Not Found"))
+
+		result, err := handler(ctx, createCallToolRequest(tool.Name, map[string]any{"projectId": projectID, "branch": "dev"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, `protected branch "dev" not found`)
+	})
+}
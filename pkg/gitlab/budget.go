@@ -0,0 +1,177 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// truncationNoticeReserve is the part of a text budget kept free for the notice appended
+// to truncated plain-text results.
+const truncationNoticeReserve = 256
+
+// ResponseLimits bounds the size of tool results, measured on their text content.
+type ResponseLimits struct {
+	// MaxBytes applies to every tool without an override; 0 disables the limit.
+	MaxBytes int
+	// PerTool overrides MaxBytes for the named tools; 0 disables the limit for that tool.
+	PerTool map[string]int
+}
+
+// For returns the byte budget of the named tool, 0 when it is unlimited.
+func (l ResponseLimits) For(tool string) int {
+	if limit, ok := l.PerTool[tool]; ok {
+		return limit
+	}
+	return l.MaxBytes
+}
+
+// ParseToolByteLimits parses comma-separated "tool=bytes" pairs, as accepted by the
+// --tool-max-response-bytes flag.
+func ParseToolByteLimits(spec string) (map[string]int, error) {
+	limits := map[string]int{}
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		tool, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(tool) == "" {
+			return nil, fmt.Errorf("invalid tool limit %q: expected tool=bytes", pair)
+		}
+		bytes, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || bytes < 0 {
+			return nil, fmt.Errorf("invalid tool limit %q: bytes must be a non-negative integer", pair)
+		}
+		limits[strings.TrimSpace(tool)] = bytes
+	}
+	return limits, nil
+}
+
+// responseBudgetKey is the context key under which WithResponseLimits stores the byte
+// budget of the current tool call.
+type responseBudgetKey struct{}
+
+// WithResponseLimits returns a server option that makes the byte budget of each tool
+// call available to its handler.
+func WithResponseLimits(limits ResponseLimits) server.ServerOption {
+	return server.WithToolHandlerMiddleware(func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return next(context.WithValue(ctx, responseBudgetKey{}, limits.For(request.Params.Name)), request)
+		}
+	})
+}
+
+// responseBudget returns the byte budget of the current tool call, 0 when unlimited.
+func responseBudget(ctx context.Context) int {
+	budget, _ := ctx.Value(responseBudgetKey{}).(int)
+	return budget
+}
+
+// continuation is the decoded form of the opaque 'continuation' token returned when a
+// result was cut to fit the response budget. The follow-up call repeats the same request
+// and skips the units (bytes, items or files) already returned.
+type continuation struct {
+	Tool   string `json:"tool"`
+	Offset int    `json:"offset"`
+	// Version identifies the content the offset refers to (e.g., a blob ID), when known.
+	Version string `json:"version,omitempty"`
+}
+
+// WithContinuation returns a ToolOption adding the 'continuation' parameter.
+func WithContinuation() mcp.ToolOption {
+	return mcp.WithString("continuation",
+		mcp.Description("Opaque token returned as 'continuation' when a previous result was cut to fit the server's response size limit. "+
+			"Repeat the call with the same other parameters and this token to get the rest."),
+	)
+}
+
+// optionalContinuationParam decodes the 'continuation' parameter, returning the zero
+// continuation when it is absent.
+func optionalContinuationParam(r *mcp.CallToolRequest) (continuation, error) {
+	token, err := OptionalParam[string](r, "continuation")
+	if err != nil || token == "" {
+		return continuation{}, err
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return continuation{}, fmt.Errorf("invalid 'continuation' parameter: malformed token")
+	}
+	var c continuation
+	if err := json.Unmarshal(data, &c); err != nil || c.Offset < 0 {
+		return continuation{}, fmt.Errorf("invalid 'continuation' parameter: malformed token")
+	}
+	if c.Tool != r.Params.Name {
+		return continuation{}, fmt.Errorf("invalid 'continuation' parameter: token was issued by tool %q", c.Tool)
+	}
+	return c, nil
+}
+
+// advance returns the continuation for tool pointing n units past c.
+func (c continuation) advance(tool string, n int) continuation {
+	return continuation{Tool: tool, Offset: c.Offset + n, Version: c.Version}
+}
+
+// encode returns the opaque token form of c.
+func (c continuation) encode() string {
+	data, _ := json.Marshal(c) // Plain struct of strings and ints: cannot fail
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// fitToBudget returns how many of n units can be returned within budget and the
+// continuation token for the rest. size(k, next) is the encoded size of a response holding
+// the first k units and the token next. Everything is returned when it fits or the budget
+// is 0; otherwise at least one unit is kept so every call makes progress.
+func fitToBudget(n, budget int, cont continuation, tool string, size func(k int, next string) int) (int, string) {
+	if budget <= 0 || n <= 1 || size(n, "") <= budget {
+		return n, ""
+	}
+	token := func(k int) string { return cont.advance(tool, k).encode() }
+	// Largest k in [1, n-1] whose response fits; sizes grow with k
+	lo, hi := 1, n-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if size(mid, token(mid)) <= budget {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo, token(lo)
+}
+
+// jsonSize returns the size of the JSON encoding of v, as returned in a tool result.
+func jsonSize(v any) int {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0 // Encoding errors are reported when the result itself is marshalled
+	}
+	return len(data)
+}
+
+// truncateText cuts text to at most maxBytes at the last line boundary, or at a character
+// boundary when the first line alone is longer. At least one character is kept.
+func truncateText(text string, maxBytes int) (string, bool) {
+	if maxBytes <= 0 || len(text) <= maxBytes {
+		return text, false
+	}
+	cut := text[:maxBytes]
+	if idx := strings.LastIndex(cut, "\n"); idx >= 0 {
+		return cut[:idx+1], true
+	}
+	for len(cut) > 0 && !utf8.RuneStart(text[len(cut)]) {
+		cut = cut[:len(cut)-1]
+	}
+	if cut == "" {
+		_, width := utf8.DecodeRuneInString(text)
+		cut = text[:width]
+	}
+	return cut, true
+}
@@ -0,0 +1,316 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+// budgetContext returns a context carrying the byte budget set by WithResponseLimits.
+func budgetContext(budget int) context.Context {
+	return context.WithValue(context.Background(), responseBudgetKey{}, budget)
+}
+
+func TestParseToolByteLimits(t *testing.T) {
+	limits, err := ParseToolByteLimits(" getProjectFile=200000, getCommitDiff=0 ,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"getProjectFile": 200000, "getCommitDiff": 0}, limits)
+
+	rl := ResponseLimits{MaxBytes: 1000, PerTool: limits}
+	assert.Equal(t, 200000, rl.For("getProjectFile"))
+	assert.Equal(t, 0, rl.For("getCommitDiff"))
+	assert.Equal(t, 1000, rl.For("listIssues"))
+
+	for _, spec := range []string{"getProjectFile", "=10", "getProjectFile=big", "getProjectFile=-1"} {
+		_, err := ParseToolByteLimits(spec)
+		assert.Error(t, err, "spec %q should be rejected", spec)
+	}
+}
+
+func TestOptionalContinuationParam(t *testing.T) {
+	token := continuation{Tool: "listIssues", Offset: 40}.encode()
+
+	req := createCallToolRequest("listIssues", map[string]any{"continuation": token})
+	cont, err := optionalContinuationParam(&req)
+	require.NoError(t, err)
+	assert.Equal(t, continuation{Tool: "listIssues", Offset: 40}, cont)
+
+	req = createCallToolRequest("listIssues", map[string]any{})
+	cont, err = optionalContinuationParam(&req)
+	require.NoError(t, err)
+	assert.Zero(t, cont)
+
+	req = createCallToolRequest("listMergeRequests", map[string]any{"continuation": token})
+	_, err = optionalContinuationParam(&req)
+	assert.ErrorContains(t, err, `token was issued by tool "listIssues"`)
+
+	req = createCallToolRequest("listIssues", map[string]any{"continuation": "not a token"})
+	_, err = optionalContinuationParam(&req)
+	assert.ErrorContains(t, err, "malformed token")
+}
+
+func TestTruncateText(t *testing.T) {
+	text := "line one\nline two\nline three\n"
+	cut, truncated := truncateText(text, 20)
+	assert.True(t, truncated)
+	assert.Equal(t, "line one\nline two\n", cut)
+
+	cut, truncated = truncateText(text, 100)
+	assert.False(t, truncated)
+	assert.Equal(t, text, cut)
+
+	// A single long line is cut at a character boundary
+	cut, truncated = truncateText("héllo wörld", 2)
+	assert.True(t, truncated)
+	assert.Equal(t, "h", cut)
+
+	// At least one character is always kept
+	cut, _ = truncateText("éa", 1)
+	assert.Equal(t, "é", cut)
+}
+
+func TestFitToBudget(t *testing.T) {
+	size := func(k int, next string) int { return k*10 + len(next) }
+
+	k, next := fitToBudget(5, 0, continuation{}, "tool", size)
+	assert.Equal(t, 5, k)
+	assert.Empty(t, next)
+
+	k, next = fitToBudget(5, 1000, continuation{}, "tool", size)
+	assert.Equal(t, 5, k)
+	assert.Empty(t, next)
+
+	token := continuation{Tool: "tool", Offset: 12}.encode()
+	k, next = fitToBudget(20, 10*10+len(token), continuation{Tool: "tool", Offset: 2}, "tool", size)
+	assert.Equal(t, 10, k)
+	assert.Equal(t, token, next)
+
+	// A single oversized unit is still returned
+	k, next = fitToBudget(3, 1, continuation{}, "tool", size)
+	assert.Equal(t, 1, k)
+	assert.NotEmpty(t, next)
+}
+
+func TestListIssuesHandlerResponseBudget(t *testing.T) {
+	mockClient, mockIssues, ctrl := setupMockClientForIssues(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	listIssuesTool, listIssuesHandler := ListIssues(mockGetClient)
+
+	issues := make([]*gl.Issue, 10)
+	for i := range issues {
+		issues[i] = &gl.Issue{ID: 100 + i, IID: i + 1, Title: strings.Repeat("x", 100)}
+	}
+	mockIssues.EXPECT().
+		ListProjectIssues("group/project", gomock.Any(), gomock.Any()).
+		Return(issues, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil).
+		AnyTimes()
+
+	args := map[string]any{"projectId": "group/project", "fields": "iid,title"}
+	var returned []int
+	for calls := 0; calls < 10; calls++ {
+		result, err := listIssuesHandler(budgetContext(600), createCallToolRequest(listIssuesTool.Name, args))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+		text := getTextResult(t, result).Text
+		assert.LessOrEqual(t, len(text), 600, "Result exceeds the response budget")
+
+		items, pagination := getListResult(t, result)
+		var page []struct {
+			IID int `json:"iid"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(items), &page))
+		require.NotEmpty(t, page)
+		for _, issue := range page {
+			returned = append(returned, issue.IID)
+		}
+		if pagination.Continuation == "" {
+			assert.False(t, pagination.Truncated)
+			break
+		}
+		assert.True(t, pagination.Truncated)
+		args["continuation"] = pagination.Continuation
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, returned, "Continuations should return every item exactly once")
+}
+
+func TestGetProjectFileHandlerResponseBudget(t *testing.T) {
+	mockClient, mockFiles, ctrl := setupMockClientForFiles(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	getProjectFileTool, getProjectFileHandler := GetProjectFile(mockGetClient)
+
+	var content strings.Builder
+	for i := 1; i <= 100; i++ {
+		fmt.Fprintf(&content, "line %03d of the file\n", i)
+	}
+	file := &gl.File{Content: base64.StdEncoding.EncodeToString([]byte(content.String())), BlobID: "blob1"}
+	mockFiles.EXPECT().
+		GetFile("group/project", "big.txt", gomock.Any(), gomock.Any()).
+		Return(file, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil).
+		AnyTimes()
+
+	notice := regexp.MustCompile(`\n\[truncated: bytes \d+-\d+ of \d+ returned; call again with continuation "([^"]+)" for the rest\]$`)
+	args := map[string]any{"projectId": "group/project", "filePath": "big.txt"}
+	var reassembled strings.Builder
+	var lastToken string
+	for calls := 0; calls < 20; calls++ {
+		result, err := getProjectFileHandler(budgetContext(truncationNoticeReserve+500), createCallToolRequest(getProjectFileTool.Name, args))
+		require.NoError(t, err)
+		text := getTextResult(t, result).Text
+		assert.LessOrEqual(t, len(text), truncationNoticeReserve+500)
+
+		m := notice.FindStringSubmatch(text)
+		if m == nil {
+			reassembled.WriteString(text)
+			break
+		}
+		chunk := strings.TrimSuffix(text, m[0])
+		assert.True(t, strings.HasSuffix(chunk, "\n"), "Chunks should end at a line boundary")
+		reassembled.WriteString(chunk)
+		lastToken = m[1]
+		args["continuation"] = lastToken
+	}
+	assert.Equal(t, content.String(), reassembled.String())
+
+	// A continuation for a different version of the file is refused
+	file.BlobID = "blob2"
+	args["continuation"] = lastToken
+	result, err := getProjectFileHandler(budgetContext(truncationNoticeReserve+500), createCallToolRequest(getProjectFileTool.Name, args))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "changed since the continuation was issued")
+}
+
+func TestGetCommitDiffHandlerResponseBudget(t *testing.T) {
+	mockClient, mockCommits, ctrl := setupMockClientForCommits(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	getCommitDiffTool, getCommitDiffHandler := GetCommitDiff(mockGetClient)
+
+	diffs := make([]*gl.Diff, 6)
+	for i := range diffs {
+		path := fmt.Sprintf("file%d.go", i)
+		diffs[i] = &gl.Diff{OldPath: path, NewPath: path, Diff: "@@ -1 +1 @@\n-" + strings.Repeat("a", 200) + "\n+" + strings.Repeat("b", 200) + "\n"}
+	}
+	mockCommits.EXPECT().
+		GetCommitDiff("group/project", "abc", gomock.Any(), gomock.Any()).
+		Return(diffs, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil).
+		AnyTimes()
+
+	args := map[string]any{"projectId": "group/project", "sha": "abc"}
+	var paths []string
+	for calls := 0; calls < 10; calls++ {
+		result, err := getCommitDiffHandler(budgetContext(1500), createCallToolRequest(getCommitDiffTool.Name, args))
+		require.NoError(t, err)
+		text := getTextResult(t, result).Text
+		assert.LessOrEqual(t, len(text), 1500)
+
+		var diff commitDiffResult
+		require.NoError(t, json.Unmarshal([]byte(text), &diff))
+		assert.Equal(t, 6, diff.Summary.FilesChanged, "The summary covers every file on each call")
+		for _, fd := range diff.Diffs {
+			paths = append(paths, fd.NewPath)
+		}
+		if diff.Continuation == "" {
+			break
+		}
+		args["continuation"] = diff.Continuation
+	}
+	assert.Equal(t, []string{"file0.go", "file1.go", "file2.go", "file3.go", "file4.go", "file5.go"}, paths)
+}
+
+func TestCompareRefsHandlerResponseBudget(t *testing.T) {
+	mockClient, mockRepos, ctrl := setupMockClientForRepos(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	compareRefsTool, compareRefsHandler := CompareRefs(mockGetClient)
+
+	cmp := &gl.Compare{}
+	for i := 0; i < 6; i++ {
+		cmp.Commits = append(cmp.Commits, &gl.Commit{ID: fmt.Sprintf("sha%d", i), Message: strings.Repeat("m", 300)})
+		path := fmt.Sprintf("file%d.go", i)
+		cmp.Diffs = append(cmp.Diffs, &gl.Diff{OldPath: path, NewPath: path, Diff: "@@ -1 +1 @@\n-" + strings.Repeat("a", 200) + "\n+" + strings.Repeat("b", 200) + "\n"})
+	}
+	mockRepos.EXPECT().
+		Compare("group/project", gomock.Any(), gomock.Any()).
+		Return(cmp, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil).
+		AnyTimes()
+
+	args := map[string]any{"projectId": "group/project", "from": "main", "to": "feature"}
+	var commits, paths []string
+	for calls := 0; calls < 20; calls++ {
+		result, err := compareRefsHandler(budgetContext(1500), createCallToolRequest(compareRefsTool.Name, args))
+		require.NoError(t, err)
+		text := getTextResult(t, result).Text
+		assert.LessOrEqual(t, len(text), 1500)
+
+		var res compareResult
+		require.NoError(t, json.Unmarshal([]byte(text), &res))
+		assert.Equal(t, 6, res.Summary.Commits, "The summary covers every commit on each call")
+		for _, c := range res.Commits {
+			commits = append(commits, c.ID)
+		}
+		for _, fd := range res.Diffs {
+			paths = append(paths, fd.NewPath)
+		}
+		if res.Continuation == "" {
+			break
+		}
+		args["continuation"] = res.Continuation
+	}
+	assert.Equal(t, []string{"sha0", "sha1", "sha2", "sha3", "sha4", "sha5"}, commits, "Commits are returned once, not with every continuation")
+	assert.Equal(t, []string{"file0.go", "file1.go", "file2.go", "file3.go", "file4.go", "file5.go"}, paths)
+}
+
+func TestGetCommitHandlerResponseBudget(t *testing.T) {
+	mockClient, mockCommits, ctrl := setupMockClientForCommits(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	getCommitTool, getCommitHandler := GetCommit(mockGetClient)
+
+	message := "Rewrite the parser\n\n" + strings.Repeat("A long line of the commit body.\n", 200)
+	mockCommits.EXPECT().
+		GetCommit("group/project", "abc", gomock.Any(), gomock.Any()).
+		Return(&gl.Commit{ID: "abc", Title: "Rewrite the parser", Message: message}, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+	mockCommits.EXPECT().
+		GetGPGSignature("group/project", "abc", gomock.Any()).
+		Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, fmt.Errorf("gitlab: 404 Not Found"))
+
+	result, err := getCommitHandler(budgetContext(2000), createCallToolRequest(getCommitTool.Name, map[string]any{"projectId": "group/project", "sha": "abc"}))
+	require.NoError(t, err)
+	text := getTextResult(t, result).Text
+	assert.LessOrEqual(t, len(text), 2000)
+
+	var detail commitDetail
+	require.NoError(t, json.Unmarshal([]byte(text), &detail))
+	assert.True(t, detail.MessageTruncated)
+	assert.True(t, strings.HasPrefix(message, detail.Message))
+	assert.True(t, strings.HasSuffix(detail.Message, "\n"), "The message is cut at a line boundary")
+}
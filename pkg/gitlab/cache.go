@@ -0,0 +1,549 @@
+package gitlab
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Endpoint classes of cached GitLab API responses, each with its own TTL.
+const (
+	// CacheClassMetadata covers slowly changing resources: projects, groups, users.
+	CacheClassMetadata = "metadata"
+	// CacheClassRepository covers repository content addressed by branch or tag: files, trees, refs, commits.
+	CacheClassRepository = "repository"
+	// CacheClassActivity covers frequently updated resources: issues, merge requests, notes, pipelines.
+	CacheClassActivity = "activity"
+)
+
+// DefaultCacheMaxEntries bounds the number of responses kept in memory.
+const DefaultCacheMaxEntries = 1000
+
+// DefaultCacheMaxBytes bounds the size of the responses kept in memory.
+const DefaultCacheMaxBytes = 64 << 20
+
+// DefaultCacheMaxDiskBytes bounds the size of the responses kept in the cache directory.
+const DefaultCacheMaxDiskBytes = 256 << 20
+
+// maxCachedBodyBytes is the size above which responses are not cached, lowered to MaxBytes.
+const maxCachedBodyBytes = 8 << 20
+
+// rateLimitHeaders are the response headers reporting the rate limit, left out of cached responses.
+var rateLimitHeaders = []string{"RateLimit-Limit", "RateLimit-Observed", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-ResetTime"}
+
+// cacheStatusHeader reports on responses served by the cache whether they were a fresh hit
+// or revalidated with GitLab.
+const cacheStatusHeader = "X-Gitlab-Mcp-Cache"
+
+// DefaultCacheTTLs returns the default time-to-live of each endpoint class. Responses past
+// their TTL are revalidated with GitLab using their ETag, when they have one.
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		CacheClassMetadata:   5 * time.Minute,
+		CacheClassRepository: time.Minute,
+		CacheClassActivity:   10 * time.Second,
+	}
+}
+
+// ParseCacheTTLs parses comma-separated "class=duration" pairs, as accepted by the
+// --cache-ttl flag, over the default TTLs.
+func ParseCacheTTLs(spec string) (map[string]time.Duration, error) {
+	ttls := DefaultCacheTTLs()
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		class, value, ok := strings.Cut(pair, "=")
+		class = strings.TrimSpace(class)
+		if _, known := ttls[class]; !ok || !known {
+			return nil, fmt.Errorf("invalid cache TTL %q: expected class=duration with class one of %s, %s, %s", pair, CacheClassMetadata, CacheClassRepository, CacheClassActivity)
+		}
+		ttl, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || ttl < 0 {
+			return nil, fmt.Errorf("invalid cache TTL %q: duration must be non-negative (e.g., 30s, 5m)", pair)
+		}
+		ttls[class] = ttl
+	}
+	return ttls, nil
+}
+
+// CacheConfig configures the response cache of the GitLab client.
+type CacheConfig struct {
+	// TTLs maps endpoint classes to how long responses are served without contacting GitLab.
+	TTLs map[string]time.Duration
+	// MaxEntries bounds the responses kept in memory; the least recently used are evicted.
+	MaxEntries int
+	// MaxBytes bounds the size of the response bodies kept in memory, evicted likewise.
+	MaxBytes int64
+	// Dir, when set, also stores responses on disk so they survive restarts.
+	Dir string
+	// MaxDiskBytes bounds the size of the responses stored in Dir; the least recently stored
+	// are removed first.
+	MaxDiskBytes int64
+}
+
+// CacheStats counts how GET requests were served by the cache.
+type CacheStats struct {
+	Hits        int64 `json:"hits"`
+	Revalidated int64 `json:"revalidated"`
+	Misses      int64 `json:"misses"`
+	Evictions   int64 `json:"evictions"`
+	Entries     int   `json:"entries"`
+}
+
+// HitRatio returns the share of GET requests answered without downloading the response again.
+func (s CacheStats) HitRatio() float64 {
+	total := s.Hits + s.Revalidated + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits+s.Revalidated) / float64(total)
+}
+
+// cacheEntry is a cached response, as kept in memory and on disk.
+type cacheEntry struct {
+	Key       string      `json:"key"`
+	Header    http.Header `json:"header"`
+	Body      []byte      `json:"body"`
+	ETag      string      `json:"etag,omitempty"`
+	StoredAt  time.Time   `json:"stored_at"`
+	Immutable bool        `json:"immutable,omitempty"`
+}
+
+// readOnlyRequestKey is the context key marking requests that change nothing although they are
+// not GETs, such as GraphQL queries, so that their success does not invalidate cached responses.
+type readOnlyRequestKey struct{}
+
+// withReadOnlyRequest returns a copy of ctx marking the requests sent with it as read-only.
+func withReadOnlyRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyRequestKey{}, true)
+}
+
+// CachingTransport is an http.RoundTripper caching successful GitLab API GET responses.
+// Entries are keyed by token and URL, served directly while within the TTL of their endpoint
+// class, then revalidated with If-None-Match. Content addressed by a blob SHA, or the diff of a
+// commit SHA, never changes and is served without revalidation. Successful writes drop the
+// other responses cached for their token, since the same project is reached both by ID and by
+// path; requests marked with withReadOnlyRequest are passed through without invalidating anything.
+type CachingTransport struct {
+	next http.RoundTripper
+	cfg  CacheConfig
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	// bytes is the size of the bodies of the entries in memory.
+	bytes int64
+	stats CacheStats
+	// disk indexes the files of cfg.Dir by name, so writes can invalidate the responses that
+	// are no longer in memory and the directory stays within cfg.MaxDiskBytes.
+	disk      map[string]diskFile
+	diskBytes int64
+}
+
+// diskFile describes a response file of the cache directory.
+type diskFile struct {
+	size    int64
+	modTime time.Time
+}
+
+// NewCachingTransport returns a CachingTransport sending requests through next.
+func NewCachingTransport(next http.RoundTripper, cfg CacheConfig) (*CachingTransport, error) {
+	if cfg.TTLs == nil {
+		cfg.TTLs = DefaultCacheTTLs()
+	}
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = DefaultCacheMaxEntries
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = DefaultCacheMaxBytes
+	}
+	if cfg.MaxDiskBytes <= 0 {
+		cfg.MaxDiskBytes = DefaultCacheMaxDiskBytes
+	}
+	t := &CachingTransport{
+		next:    next,
+		cfg:     cfg,
+		now:     time.Now,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+		disk:    map[string]diskFile{},
+	}
+	if cfg.Dir != "" {
+		if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create cache directory %q: %w", cfg.Dir, err)
+		}
+		files, err := os.ReadDir(cfg.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cache directory %q: %w", cfg.Dir, err)
+		}
+		for _, file := range files {
+			info, err := file.Info()
+			if err != nil || !info.Mode().IsRegular() || !strings.HasSuffix(file.Name(), ".json") {
+				continue
+			}
+			if _, kind, _ := strings.Cut(file.Name(), "-"); !strings.HasPrefix(kind, mutableFileKind+"-") && !strings.HasPrefix(kind, immutableFileKind+"-") {
+				// Named by an older version, which writes could no longer find
+				_ = os.Remove(filepath.Join(cfg.Dir, file.Name()))
+				continue
+			}
+			t.disk[file.Name()] = diskFile{size: info.Size(), modTime: info.ModTime()}
+			t.diskBytes += info.Size()
+		}
+		t.pruneDiskLocked()
+	}
+	return t, nil
+}
+
+// Stats returns the cache statistics so far.
+func (t *CachingTransport) Stats() CacheStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stats := t.stats
+	stats.Entries = t.lru.Len()
+	return stats
+}
+
+// LogStats logs the cache statistics so far.
+func (t *CachingTransport) LogStats(logger log.FieldLogger) {
+	stats := t.Stats()
+	logger.WithFields(log.Fields{
+		"hits":        stats.Hits,
+		"revalidated": stats.Revalidated,
+		"misses":      stats.Misses,
+		"evictions":   stats.Evictions,
+		"entries":     stats.Entries,
+		"hit_ratio":   fmt.Sprintf("%.2f", stats.HitRatio()),
+	}).Info("GitLab response cache statistics")
+}
+
+// LogStatsEvery logs the cache statistics every interval in which they changed, until ctx is done.
+func (t *CachingTransport) LogStatsEvery(ctx context.Context, logger log.FieldLogger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var last CacheStats
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if stats := t.Stats(); stats != last {
+				t.LogStats(logger)
+				last = stats
+			}
+		}
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *CachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token := tokenFingerprint(req)
+	if req.Method != http.MethodGet {
+		resp, err := t.next.RoundTrip(req)
+		readOnly, _ := req.Context().Value(readOnlyRequestKey{}).(bool)
+		if err == nil && resp.StatusCode < http.StatusBadRequest && req.Method != http.MethodHead && !readOnly {
+			t.invalidate(token)
+		}
+		return resp, err
+	}
+
+	key := token + " " + req.URL.String()
+	class, immutable := classifyEndpoint(req.URL)
+	entry := t.lookup(key, immutable)
+	if entry != nil && (entry.Immutable || t.now().Sub(entry.StoredAt) < t.cfg.TTLs[class]) {
+		t.count(func(s *CacheStats) { s.Hits++ })
+		return entry.response(req, "hit"), nil
+	}
+
+	if entry != nil && entry.ETag != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		resp.Body.Close()
+		refreshed := *entry
+		refreshed.StoredAt = t.now()
+		t.store(&refreshed)
+		t.count(func(s *CacheStats) { s.Revalidated++ })
+		return refreshed.response(req, "revalidated"), nil
+	}
+	t.count(func(s *CacheStats) { s.Misses++ })
+	maxBody := min(maxCachedBodyBytes, t.cfg.MaxBytes)
+	if resp.StatusCode != http.StatusOK || resp.ContentLength > maxBody {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBody+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if int64(len(body)) > maxBody {
+		// Too large to cache: hand back the whole body unbuffered
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	header := resp.Header.Clone()
+	for _, name := range rateLimitHeaders {
+		// Only live responses report the current rate limit
+		header.Del(name)
+	}
+	t.store(&cacheEntry{
+		Key:       key,
+		Header:    header,
+		Body:      body,
+		ETag:      resp.Header.Get("ETag"),
+		StoredAt:  t.now(),
+		Immutable: immutable,
+	})
+	return resp, nil
+}
+
+// response returns e as the response to req, tagged with how the cache served it.
+func (e *cacheEntry) response(req *http.Request, status string) *http.Response {
+	header := e.Header.Clone()
+	header.Set(cacheStatusHeader, status)
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// count updates the statistics under the lock.
+func (t *CachingTransport) count(update func(*CacheStats)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	update(&t.stats)
+}
+
+// lookup returns the entry stored under key, from memory or else from disk, or nil. immutable
+// locates the file of the entry on disk.
+func (t *CachingTransport) lookup(key string, immutable bool) *cacheEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if elem, ok := t.entries[key]; ok {
+		t.lru.MoveToFront(elem)
+		return elem.Value.(*cacheEntry)
+	}
+	if t.cfg.Dir == "" {
+		return nil
+	}
+	name := entryFileName(key, immutable)
+	if _, ok := t.disk[name]; !ok {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(t.cfg.Dir, name))
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if json.Unmarshal(data, &entry) != nil || entry.Key != key {
+		return nil
+	}
+	t.insertLocked(&entry)
+	return &entry
+}
+
+// store saves entry in memory and, when configured, on disk.
+func (t *CachingTransport) store(entry *cacheEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.insertLocked(entry)
+	if t.cfg.Dir == "" {
+		return
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	// The disk copy is best effort: failures only cost a later download
+	name := entryFileName(entry.Key, entry.Immutable)
+	if os.WriteFile(filepath.Join(t.cfg.Dir, name), data, 0o600) != nil {
+		return
+	}
+	t.diskBytes += int64(len(data)) - t.disk[name].size
+	t.disk[name] = diskFile{size: int64(len(data)), modTime: t.now()}
+	t.pruneDiskLocked()
+}
+
+// pruneDiskLocked removes the least recently stored files of the cache directory until they
+// fit in MaxDiskBytes, leaving some room so that the next stores do not prune again.
+func (t *CachingTransport) pruneDiskLocked() {
+	if t.diskBytes <= t.cfg.MaxDiskBytes {
+		return
+	}
+	names := make([]string, 0, len(t.disk))
+	for name := range t.disk {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return t.disk[names[i]].modTime.Before(t.disk[names[j]].modTime) })
+	for _, name := range names {
+		if t.diskBytes <= t.cfg.MaxDiskBytes*9/10 {
+			break
+		}
+		t.removeDiskLocked(name)
+	}
+}
+
+// removeDiskLocked deletes the named file of the cache directory.
+func (t *CachingTransport) removeDiskLocked(name string) {
+	_ = os.Remove(filepath.Join(t.cfg.Dir, name))
+	t.diskBytes -= t.disk[name].size
+	delete(t.disk, name)
+}
+
+// insertLocked adds entry to the in-memory LRU list, evicting the oldest entries past
+// MaxEntries or MaxBytes.
+func (t *CachingTransport) insertLocked(entry *cacheEntry) {
+	if elem, ok := t.entries[entry.Key]; ok {
+		t.bytes += int64(len(entry.Body) - len(elem.Value.(*cacheEntry).Body))
+		elem.Value = entry
+		t.lru.MoveToFront(elem)
+	} else {
+		t.entries[entry.Key] = t.lru.PushFront(entry)
+		t.bytes += int64(len(entry.Body))
+	}
+	for t.lru.Len() > t.cfg.MaxEntries || t.bytes > t.cfg.MaxBytes {
+		t.removeLocked(t.lru.Back())
+		t.stats.Evictions++
+	}
+}
+
+// removeLocked drops the entry of elem from memory.
+func (t *CachingTransport) removeLocked(elem *list.Element) {
+	entry := elem.Value.(*cacheEntry)
+	t.lru.Remove(elem)
+	delete(t.entries, entry.Key)
+	t.bytes -= int64(len(entry.Body))
+}
+
+// invalidate drops the mutable entries of token, in memory and on disk.
+func (t *CachingTransport) invalidate(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, elem := range t.entries {
+		entry := elem.Value.(*cacheEntry)
+		if !entry.Immutable && strings.HasPrefix(entry.Key, token+" ") {
+			t.removeLocked(elem)
+		}
+	}
+	for name := range t.disk {
+		if strings.HasPrefix(name, token+"-"+mutableFileKind+"-") {
+			t.removeDiskLocked(name)
+		}
+	}
+}
+
+// File kinds in the names of the files of the cache directory.
+const (
+	mutableFileKind   = "mutable"
+	immutableFileKind = "immutable"
+)
+
+// entryFileName returns the name of the file storing the entry with the given key, made of its
+// token, whether it is immutable and a hash of its key, so that writes find the files to
+// invalidate without reading them.
+func entryFileName(key string, immutable bool) string {
+	token, _, _ := strings.Cut(key, " ")
+	kind := mutableFileKind
+	if immutable {
+		kind = immutableFileKind
+	}
+	sum := sha256.Sum256([]byte(key))
+	return token + "-" + kind + "-" + hex.EncodeToString(sum[:]) + ".json"
+}
+
+// tokenFingerprint identifies the credentials of req without keeping them, so responses are
+// never shared between tokens.
+func tokenFingerprint(req *http.Request) string {
+	h := sha256.New()
+	for _, name := range []string{"PRIVATE-TOKEN", "Authorization", "JOB-TOKEN"} {
+		fmt.Fprintf(h, "%s=%s\n", name, req.Header.Get(name))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// shaPattern matches full commit and blob SHAs (SHA-1 or SHA-256).
+var shaPattern = regexp.MustCompile(`^(?:[0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// apiPath returns the segments of u's escaped path after the API version prefix.
+func apiPath(u *url.URL) []string {
+	path := u.EscapedPath()
+	if idx := strings.Index(path, "/api/v4/"); idx >= 0 {
+		path = path[idx+len("/api/v4/"):]
+	}
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// classifyEndpoint returns the endpoint class of a GET request to u and whether its response
+// is content addressed by a commit or blob SHA, hence immutable.
+func classifyEndpoint(u *url.URL) (class string, immutable bool) {
+	segments := apiPath(u)
+	query := u.Query()
+	isSHA := func(values []string) bool { return len(values) == 1 && shaPattern.MatchString(values[0]) }
+
+	for i, segment := range segments {
+		switch segment {
+		case "issues", "merge_requests", "notes", "discussions", "pipelines", "jobs", "events", "statuses":
+			return CacheClassActivity, false
+		case "repository":
+			rest := segments[i+1:]
+			if len(rest) == 0 {
+				return CacheClassRepository, false
+			}
+			switch rest[0] {
+			case "commits":
+				// The diff of a commit never changes; the commit itself reports its latest
+				// pipeline and status, and its refs, statuses or MRs change too
+				immutable = len(rest) == 3 && shaPattern.MatchString(rest[1]) && rest[2] == "diff"
+			case "blobs":
+				immutable = len(rest) >= 2 && shaPattern.MatchString(rest[1])
+			case "files", "tree":
+				immutable = isSHA(query["ref"])
+			case "compare":
+				immutable = isSHA(query["from"]) && isSHA(query["to"])
+			}
+			for _, segment := range rest[1:] {
+				if segment == "merge_requests" || segment == "statuses" {
+					return CacheClassActivity, false
+				}
+			}
+			return CacheClassRepository, immutable
+		}
+	}
+	return CacheClassMetadata, false
+}
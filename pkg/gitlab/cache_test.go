@@ -0,0 +1,345 @@
+package gitlab
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+const testSHA = "0123456789abcdef0123456789abcdef01234567"
+
+func TestClassifyEndpoint(t *testing.T) {
+	tests := []struct {
+		path      string
+		class     string
+		immutable bool
+	}{
+		{"/api/v4/projects/group%2Fproject", CacheClassMetadata, false},
+		{"/api/v4/users/1", CacheClassMetadata, false},
+		{"/api/v4/projects/1/issues/2/notes", CacheClassActivity, false},
+		{"/api/v4/projects/1/merge_requests", CacheClassActivity, false},
+		{"/api/v4/projects/1/repository/branches", CacheClassRepository, false},
+		{"/api/v4/projects/1/repository/files/src%2Fmain.go?ref=main", CacheClassRepository, false},
+		{"/api/v4/projects/1/repository/files/src%2Fmain.go?ref=" + testSHA, CacheClassRepository, true},
+		{"/api/v4/projects/1/repository/tree?ref=" + testSHA + "&path=src", CacheClassRepository, true},
+		{"/api/v4/projects/1/repository/commits/" + testSHA, CacheClassRepository, false},
+		{"/api/v4/projects/1/repository/commits/" + testSHA + "/diff", CacheClassRepository, true},
+		{"/api/v4/projects/1/repository/commits/" + testSHA + "/refs", CacheClassRepository, false},
+		{"/api/v4/projects/1/repository/commits/" + testSHA + "/statuses", CacheClassActivity, false},
+		{"/api/v4/projects/1/repository/commits/" + testSHA + "/merge_requests", CacheClassActivity, false},
+		{"/api/v4/projects/1/repository/commits/main", CacheClassRepository, false},
+		{"/api/v4/projects/1/repository/compare?from=" + testSHA + "&to=" + testSHA, CacheClassRepository, true},
+		{"/api/v4/projects/1/repository/compare?from=main&to=" + testSHA, CacheClassRepository, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			u, err := url.Parse("https://gitlab.com" + tc.path)
+			require.NoError(t, err)
+			class, immutable := classifyEndpoint(u)
+			assert.Equal(t, tc.class, class)
+			assert.Equal(t, tc.immutable, immutable)
+		})
+	}
+}
+
+func TestParseCacheTTLs(t *testing.T) {
+	ttls, err := ParseCacheTTLs("activity=0s, metadata=10m")
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), ttls[CacheClassActivity])
+	assert.Equal(t, 10*time.Minute, ttls[CacheClassMetadata])
+	assert.Equal(t, DefaultCacheTTLs()[CacheClassRepository], ttls[CacheClassRepository])
+
+	for _, spec := range []string{"activity", "unknown=1m", "metadata=soon", "metadata=-1s"} {
+		_, err := ParseCacheTTLs(spec)
+		assert.Error(t, err, "spec %q should be rejected", spec)
+	}
+}
+
+// cacheTestServer serves a project and a commit with ETags, answering If-None-Match with 304
+// and counting the requests and full responses it sent.
+type cacheTestServer struct {
+	*httptest.Server
+	requests atomic.Int32
+	bodies   atomic.Int32
+	writes   atomic.Int32
+}
+
+func newCacheTestServer(t *testing.T) *cacheTestServer {
+	srv := &cacheTestServer{}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			srv.writes.Add(1)
+			if strings.HasSuffix(r.URL.Path, "/api/graphql") {
+				_, _ = w.Write([]byte(`{"data": {}}`))
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 1}`))
+			return
+		}
+		srv.requests.Add(1)
+		w.Header().Set("ETag", `W/"v1"`)
+		w.Header().Set("RateLimit-Limit", "600")
+		w.Header().Set("RateLimit-Remaining", "599")
+		if r.Header.Get("If-None-Match") == `W/"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		srv.bodies.Add(1)
+		if strings.HasSuffix(r.URL.Path, "/diff") {
+			_, _ = w.Write([]byte(`[{"new_path": "README.md", "diff": "@@ -0,0 +1 @@\n+Hello\n"}]`))
+			return
+		}
+		if strings.Contains(r.URL.Path, "/repository/commits/") {
+			_, _ = w.Write([]byte(`{"id": "` + testSHA + `", "title": "Initial commit"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 1, "path_with_namespace": "group/project"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newCachedClient returns a GitLab client for baseURL using the token and the caching transport.
+func newCachedClient(t *testing.T, baseURL, token string, cache *CachingTransport) *gl.Client {
+	t.Helper()
+	client, err := gl.NewClient(token, gl.WithBaseURL(baseURL), gl.WithHTTPClient(&http.Client{Transport: cache}), gl.WithoutRetries())
+	require.NoError(t, err)
+	return client
+}
+
+func TestCachingTransport(t *testing.T) {
+	srv := newCacheTestServer(t)
+	cache, err := NewCachingTransport(http.DefaultTransport, CacheConfig{})
+	require.NoError(t, err)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	client := newCachedClient(t, srv.URL, "token-a", cache)
+
+	getProject := func(client *gl.Client) *gl.Response {
+		project, resp, err := client.Projects.GetProject("group/project", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "group/project", project.PathWithNamespace)
+		return resp
+	}
+
+	// First request downloads, the next one within the TTL is served from memory
+	getProject(client)
+	resp := getProject(client)
+	assert.EqualValues(t, 1, srv.requests.Load())
+	assert.Equal(t, "hit", resp.Header.Get(cacheStatusHeader))
+	assert.Empty(t, resp.Header.Get("RateLimit-Remaining"), "Cached responses should not report a stale rate limit")
+
+	// Past the TTL the response is revalidated with its ETag
+	now = now.Add(DefaultCacheTTLs()[CacheClassMetadata] + time.Second)
+	resp = getProject(client)
+	assert.EqualValues(t, 2, srv.requests.Load())
+	assert.EqualValues(t, 1, srv.bodies.Load(), "A 304 should not resend the body")
+	assert.Equal(t, "revalidated", resp.Header.Get(cacheStatusHeader))
+
+	// Another token never sees responses cached for the first one
+	getProject(newCachedClient(t, srv.URL, "token-b", cache))
+	assert.EqualValues(t, 2, srv.bodies.Load())
+
+	// A write drops the cached responses, also when it names the project by ID
+	_, _, err = client.Issues.CreateIssue(1, &gl.CreateIssueOptions{Title: gl.Ptr("Bug")})
+	require.NoError(t, err)
+	getProject(client)
+	assert.EqualValues(t, 4, srv.requests.Load())
+
+	// Commit diffs addressed by SHA are served without revalidation, whatever their age
+	for range 2 {
+		diffs, _, err := client.Commits.GetCommitDiff("group/project", testSHA, nil)
+		require.NoError(t, err)
+		require.Len(t, diffs, 1)
+		now = now.Add(24 * time.Hour)
+	}
+	assert.EqualValues(t, 5, srv.requests.Load())
+
+	// The commit itself reports its latest pipeline, so it is revalidated past its TTL
+	getCommit := func() string {
+		commit, resp, err := client.Commits.GetCommit("group/project", testSHA, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Initial commit", commit.Title)
+		return resp.Header.Get(cacheStatusHeader)
+	}
+	getCommit()
+	assert.Equal(t, "hit", getCommit())
+	now = now.Add(DefaultCacheTTLs()[CacheClassRepository] + time.Second)
+	assert.Equal(t, "revalidated", getCommit())
+	assert.EqualValues(t, 7, srv.requests.Load())
+
+	stats := cache.Stats()
+	assert.Equal(t, CacheStats{Hits: 3, Revalidated: 2, Misses: 5, Entries: 4}, stats)
+	assert.InDelta(t, 5.0/10.0, stats.HitRatio(), 0.001)
+}
+
+func TestCachingTransportGraphQL(t *testing.T) {
+	srv := newCacheTestServer(t)
+	cache, err := NewCachingTransport(http.DefaultTransport, CacheConfig{})
+	require.NoError(t, err)
+	client := newCachedClient(t, srv.URL, "token", cache)
+	getProject := func() string {
+		_, resp, err := client.Projects.GetProject("group/project", nil)
+		require.NoError(t, err)
+		return resp.Header.Get(cacheStatusHeader)
+	}
+
+	// A GraphQL query is a POST but changes nothing: cached responses are kept
+	getProject()
+	_, err = doGraphQL(context.Background(), client, "\nquery($fullPath: ID!) { project(fullPath: $fullPath) { id } }", map[string]any{"fullPath": "group/project"}, &struct{}{})
+	require.NoError(t, err)
+	assert.Equal(t, "hit", getProject())
+	assert.EqualValues(t, 1, srv.requests.Load())
+
+	// A mutation may change anything the token can see
+	_, err = doGraphQL(context.Background(), client, "mutation { vulnerabilityDismiss(input: {id: 1}) { errors } }", nil, &struct{}{})
+	require.NoError(t, err)
+	getProject()
+	assert.EqualValues(t, 2, srv.requests.Load())
+	assert.EqualValues(t, 2, srv.writes.Load())
+}
+
+func TestCachingTransportDisk(t *testing.T) {
+	srv := newCacheTestServer(t)
+	dir := t.TempDir()
+
+	for range 2 {
+		// Each transport starts with an empty memory, as after a restart
+		cache, err := NewCachingTransport(http.DefaultTransport, CacheConfig{Dir: dir})
+		require.NoError(t, err)
+		diffs, _, err := newCachedClient(t, srv.URL, "token", cache).Commits.GetCommitDiff("group/project", testSHA, nil)
+		require.NoError(t, err)
+		require.Len(t, diffs, 1)
+	}
+	assert.EqualValues(t, 1, srv.requests.Load())
+}
+
+func TestCachingTransportDiskInvalidation(t *testing.T) {
+	srv := newCacheTestServer(t)
+	dir := t.TempDir()
+	cache, err := NewCachingTransport(http.DefaultTransport, CacheConfig{Dir: dir, MaxEntries: 1})
+	require.NoError(t, err)
+	client := newCachedClient(t, srv.URL, "token", cache)
+	getProject := func(client *gl.Client) {
+		_, _, err := client.Projects.GetProject("group/project", nil)
+		require.NoError(t, err)
+	}
+
+	// The project falls out of memory but stays on disk
+	getProject(client)
+	_, _, err = client.Commits.GetCommitDiff("group/project", testSHA, nil)
+	require.NoError(t, err)
+	getProject(client)
+	assert.EqualValues(t, 2, srv.requests.Load(), "The project should be read back from disk")
+
+	// A write drops the disk copy too, also for the next process, but keeps immutable responses
+	_, _, err = client.Commits.GetCommitDiff("group/project", testSHA, nil)
+	require.NoError(t, err)
+	_, _, err = client.Issues.CreateIssue("group/project", &gl.CreateIssueOptions{Title: gl.Ptr("Bug")})
+	require.NoError(t, err)
+	restarted, err := NewCachingTransport(http.DefaultTransport, CacheConfig{Dir: dir})
+	require.NoError(t, err)
+	getProject(newCachedClient(t, srv.URL, "token", restarted))
+	assert.EqualValues(t, 3, srv.requests.Load())
+	_, _, err = newCachedClient(t, srv.URL, "token", restarted).Commits.GetCommitDiff("group/project", testSHA, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, srv.requests.Load(), "The commit diff addressed by SHA should survive the write")
+}
+
+func TestCachingTransportDiskLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"id": 1, "username": "`+strings.Repeat("x", 1000)+`"}`)
+	}))
+	defer srv.Close()
+	dir := t.TempDir()
+	cache, err := NewCachingTransport(http.DefaultTransport, CacheConfig{Dir: dir, MaxDiskBytes: 5000})
+	require.NoError(t, err)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	client := newCachedClient(t, srv.URL, "token", cache)
+
+	for id := 1; id <= 10; id++ {
+		_, _, err := client.Users.GetUser(id, gl.GetUsersOptions{})
+		require.NoError(t, err)
+		now = now.Add(time.Second)
+	}
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var total int64
+	for _, file := range files {
+		info, err := file.Info()
+		require.NoError(t, err)
+		total += info.Size()
+	}
+	assert.LessOrEqual(t, total, int64(5000))
+	assert.NotEmpty(t, files)
+
+	// The most recent response is kept
+	_, resp, err := client.Users.GetUser(10, gl.GetUsersOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "hit", resp.Header.Get(cacheStatusHeader))
+}
+
+func TestCachingTransportEviction(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = io.WriteString(w, `{"id": 1}`)
+	}))
+	defer srv.Close()
+	cache, err := NewCachingTransport(http.DefaultTransport, CacheConfig{MaxEntries: 2})
+	require.NoError(t, err)
+	client := newCachedClient(t, srv.URL, "token", cache)
+
+	for _, id := range []int{1, 2, 1, 3, 1, 2} {
+		_, _, err := client.Users.GetUser(id, gl.GetUsersOptions{})
+		require.NoError(t, err)
+	}
+	// 1 and 2 are downloaded, 1 hits, 3 evicts 2, 1 hits, 2 is downloaded again
+	assert.EqualValues(t, 4, requests.Load())
+	assert.Equal(t, int64(2), cache.Stats().Evictions)
+}
+
+func TestCachingTransportMemoryLimit(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = io.WriteString(w, `{"id": 1, "username": "`+strings.Repeat("x", 1000)+`"}`)
+	}))
+	defer srv.Close()
+	cache, err := NewCachingTransport(http.DefaultTransport, CacheConfig{MaxBytes: 2500})
+	require.NoError(t, err)
+	client := newCachedClient(t, srv.URL, "token", cache)
+
+	for _, id := range []int{1, 2, 3, 3, 1} {
+		_, _, err := client.Users.GetUser(id, gl.GetUsersOptions{})
+		require.NoError(t, err)
+	}
+	// Two responses fit: 3 evicts 1, which is downloaded again
+	assert.EqualValues(t, 4, requests.Load())
+	assert.Equal(t, 2, cache.Stats().Entries)
+
+	// A response larger than the limit is passed through without being cached
+	small, err := NewCachingTransport(http.DefaultTransport, CacheConfig{MaxBytes: 500})
+	require.NoError(t, err)
+	client = newCachedClient(t, srv.URL, "token", small)
+	for range 2 {
+		_, _, err := client.Users.GetUser(1, gl.GetUsersOptions{})
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 6, requests.Load())
+	assert.Zero(t, small.Stats().Entries)
+}
@@ -0,0 +1,167 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	log "github.com/sirupsen/logrus"
+	gl "gitlab.com/gitlab-org/api/client-go"
+	"golang.org/x/time/rate"
+)
+
+// Default retry policy of the GitLab client.
+const (
+	DefaultMaxRetries    = 3
+	DefaultRetryWaitMin  = 500 * time.Millisecond
+	DefaultRetryWaitMax  = 10 * time.Second
+	DefaultMaxRetryAfter = time.Minute
+)
+
+// rateLimitWarnRatio is the share of the rate limit left below which responses are logged as warnings.
+const rateLimitWarnRatio = 0.1
+
+// ClientConfig configures how the GitLab client retries and paces its API requests.
+type ClientConfig struct {
+	// MaxRetries is the number of retries of a rate-limited (429), failed (5xx) or interrupted
+	// (connection reset, timeout) request; 0 disables retries.
+	MaxRetries int
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between retries.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	// MaxRetryAfter is the longest wait asked by GitLab (Retry-After or RateLimit-Reset) that is honored;
+	// requests asking for longer fail at once. 0 honors any wait.
+	MaxRetryAfter time.Duration
+	// RequestsPerSecond and Burst configure a token bucket shared by all requests of the client.
+	// A RequestsPerSecond of 0 keeps the client's own limiter, derived from GitLab's RateLimit-Limit
+	// header; a Burst of 0 allows one second's worth of requests.
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// DefaultClientConfig returns the default retry policy, without client-side rate limiting.
+func DefaultClientConfig() ClientConfig {
+	return ClientConfig{
+		MaxRetries:    DefaultMaxRetries,
+		RetryWaitMin:  DefaultRetryWaitMin,
+		RetryWaitMax:  DefaultRetryWaitMax,
+		MaxRetryAfter: DefaultMaxRetryAfter,
+	}
+}
+
+// ClientOptions returns the GitLab client options implementing cfg. Retries and the rate
+// limit left after each response are logged to logger.
+func ClientOptions(cfg ClientConfig, logger log.FieldLogger) []gl.ClientOptionFunc {
+	opts := []gl.ClientOptionFunc{
+		gl.WithCustomRetryMax(cfg.MaxRetries),
+		gl.WithCustomRetryWaitMinMax(cfg.RetryWaitMin, cfg.RetryWaitMax),
+		gl.WithCustomRetry(cfg.checkRetry),
+		gl.WithCustomBackoff(cfg.backoff),
+		gl.WithRequestLogHook(func(_ retryablehttp.Logger, req *http.Request, attempt int) {
+			if attempt > 0 {
+				logger.WithFields(log.Fields{"method": req.Method, "path": req.URL.Path, "attempt": attempt}).Info("Retrying GitLab API request")
+			}
+		}),
+		gl.WithResponseLogHook(func(_ retryablehttp.Logger, resp *http.Response) {
+			logRateLimit(logger, resp)
+		}),
+	}
+	if cfg.RequestsPerSecond > 0 {
+		burst := cfg.Burst
+		if burst <= 0 {
+			burst = max(int(cfg.RequestsPerSecond), 1)
+		}
+		limiter := rate.NewLimiter(rate.Limit(cfg.RequestsPerSecond), burst)
+		opts = append(opts, gl.WithCustomLimiter(limiter))
+	}
+	return opts
+}
+
+// checkRetry decides whether a request is retried: rate-limited requests are, while server
+// errors and network errors are for idempotent methods only, since a failed write may still
+// have been applied. None is retried when GitLab asks to wait longer than MaxRetryAfter.
+func (cfg ClientConfig) checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	if err != nil {
+		// The request is not at hand, but the error of the HTTP client names its method
+		var urlErr *url.Error
+		if !errors.As(err, &urlErr) || !idempotentMethod(strings.ToUpper(urlErr.Op)) {
+			return false, err
+		}
+		// Leaves out errors no retry can fix, such as invalid certificates
+		if retry, _ := retryablehttp.DefaultRetryPolicy(ctx, nil, err); !retry {
+			return false, err
+		}
+		return true, nil
+	}
+	retry := false
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		retry = true
+	case resp.StatusCode >= http.StatusInternalServerError:
+		retry = resp.Request == nil || idempotentMethod(resp.Request.Method)
+	}
+	if retry && cfg.MaxRetryAfter > 0 {
+		retry = askedWait(resp) <= cfg.MaxRetryAfter
+	}
+	return retry, nil
+}
+
+// backoff returns the wait before retry attemptNum (from 0): the wait asked by GitLab when
+// it gave one, otherwise an exponential backoff from waitMin capped at waitMax, with jitter.
+func (cfg ClientConfig) backoff(waitMin, waitMax time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if wait := askedWait(resp); wait > 0 {
+		return wait
+	}
+	wait := waitMax
+	if attemptNum < 32 && waitMin<<attemptNum > 0 && waitMin<<attemptNum < waitMax {
+		wait = waitMin << attemptNum
+	}
+	// Spread concurrent retries over the upper half of the wait
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// askedWait returns how long GitLab asked to wait before retrying resp's request, 0 when it did
+// not. GitLab sends RateLimit-Reset on every response, so it only counts for rate-limited ones.
+func askedWait(resp *http.Response) time.Duration {
+	if resp == nil || (resp.StatusCode != http.StatusTooManyRequests && resp.Header.Get("Retry-After") == "") {
+		return 0
+	}
+	return retryAfterHeader(resp.Header, time.Now())
+}
+
+// idempotentMethod reports whether requests with the given method can safely be repeated.
+func idempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// logRateLimit logs the rate limit GitLab reports as left after resp, as a warning when
+// it runs low.
+func logRateLimit(logger log.FieldLogger, resp *http.Response) {
+	limit, err := strconv.Atoi(resp.Header.Get("RateLimit-Limit"))
+	if err != nil || limit <= 0 {
+		return
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get("RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	entry := logger.WithFields(log.Fields{"limit": limit, "remaining": remaining, "reset": resp.Header.Get("RateLimit-Reset")})
+	if float64(remaining) < float64(limit)*rateLimitWarnRatio {
+		entry.Warn("GitLab rate limit running low")
+		return
+	}
+	entry.Debug("GitLab rate limit")
+}
@@ -0,0 +1,180 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+// newTestClient returns a GitLab client for srv configured with cfg, and the hook capturing its logs.
+func newTestClient(t *testing.T, srv *httptest.Server, cfg ClientConfig) (*gl.Client, *test.Hook) {
+	t.Helper()
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(log.DebugLevel)
+	client, err := gl.NewClient("token", append(ClientOptions(cfg, logger), gl.WithBaseURL(srv.URL))...)
+	require.NoError(t, err)
+	return client, hook
+}
+
+func TestClientRetries(t *testing.T) {
+	cfg := ClientConfig{MaxRetries: 2, RetryWaitMin: time.Millisecond, RetryWaitMax: 5 * time.Millisecond, MaxRetryAfter: time.Second}
+
+	t.Run("Rate Limited Then Success", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if calls.Add(1) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_, _ = w.Write([]byte(`{"id": 1, "path_with_namespace": "group/project"}`))
+		}))
+		defer srv.Close()
+		client, hook := newTestClient(t, srv, cfg)
+
+		project, _, err := client.Projects.GetProject("group/project", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "group/project", project.PathWithNamespace)
+		assert.EqualValues(t, 2, calls.Load())
+		require.NotEmpty(t, hook.AllEntries())
+		assert.Equal(t, "Retrying GitLab API request", hook.AllEntries()[0].Message)
+	})
+
+	t.Run("Server Errors Exhaust Retries", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer srv.Close()
+		client, _ := newTestClient(t, srv, cfg)
+
+		_, resp, err := client.Projects.GetProject("group/project", nil)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		assert.EqualValues(t, 3, calls.Load(), "One attempt plus MaxRetries retries")
+	})
+
+	t.Run("Writes Are Not Retried On Server Errors", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+		client, _ := newTestClient(t, srv, cfg)
+
+		_, _, err := client.Issues.CreateIssue("group/project", &gl.CreateIssueOptions{Title: gl.Ptr("Bug")})
+		require.Error(t, err)
+		assert.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("Network Errors Retried For Reads Only", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if calls.Add(1) == 1 {
+				// Drop the connection without a response, as on a reset
+				conn, _, err := w.(http.Hijacker).Hijack()
+				require.NoError(t, err)
+				_ = conn.Close()
+				return
+			}
+			_, _ = w.Write([]byte(`{"id": 1, "path_with_namespace": "group/project"}`))
+		}))
+		defer srv.Close()
+		client, _ := newTestClient(t, srv, cfg)
+
+		_, _, err := client.Projects.GetProject("group/project", nil)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, calls.Load())
+
+		calls.Store(0)
+		_, _, err = client.Issues.CreateIssue("group/project", &gl.CreateIssueOptions{Title: gl.Ptr("Bug")})
+		require.Error(t, err)
+		assert.EqualValues(t, 1, calls.Load(), "A write may have been applied before the connection dropped")
+	})
+
+	t.Run("Long Retry-After On Server Error Fails At Once", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+		client, _ := newTestClient(t, srv, cfg)
+
+		_, _, err := client.Projects.GetProject("group/project", nil)
+		require.Error(t, err)
+		assert.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("Long Retry-After Fails At Once", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer srv.Close()
+		client, _ := newTestClient(t, srv, cfg)
+
+		_, resp, err := client.Projects.GetProject("group/project", nil)
+		require.Error(t, err)
+		assert.EqualValues(t, 1, calls.Load())
+		assert.Equal(t, 2*time.Minute, retryAfter(resp, time.Now()), "The wait is still reported to the caller")
+	})
+}
+
+func TestClientBackoff(t *testing.T) {
+	cfg := DefaultClientConfig()
+	now := time.Now()
+
+	for attempt, bound := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		wait := cfg.backoff(100*time.Millisecond, time.Second, attempt, nil)
+		assert.GreaterOrEqual(t, wait, bound/2, "attempt %d", attempt)
+		assert.LessOrEqual(t, wait, bound, "attempt %d", attempt)
+	}
+
+	limited := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	limited.Header.Set("RateLimit-Reset", strconv.FormatInt(now.Add(5*time.Second).Unix(), 10))
+	wait := cfg.backoff(100*time.Millisecond, time.Second, 0, limited)
+	assert.InDelta(t, 5*time.Second, wait, float64(time.Second), "RateLimit-Reset is honored beyond the backoff cap")
+
+	// GitLab sends RateLimit-Reset on every response; only rate-limited ones wait for it
+	failed := &http.Response{StatusCode: http.StatusBadGateway, Header: limited.Header}
+	assert.LessOrEqual(t, cfg.backoff(100*time.Millisecond, time.Second, 0, failed), 100*time.Millisecond)
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("RateLimit-Limit", "600")
+		w.Header().Set("RateLimit-Remaining", "30")
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer srv.Close()
+	client, hook := newTestClient(t, srv, ClientConfig{RequestsPerSecond: 20, Burst: 1})
+
+	start := time.Now()
+	for range 3 {
+		_, _, err := client.Projects.GetProject("group/project", nil, gl.WithContext(context.Background()))
+		require.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond, "Requests beyond the burst wait for the token bucket")
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, log.WarnLevel, entry.Level, "5% of the rate limit left is logged as a warning")
+	assert.Equal(t, 30, entry.Data["remaining"])
+}
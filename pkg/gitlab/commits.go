@@ -2,7 +2,6 @@ package gitlab
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -18,7 +17,7 @@ func GetProjectCommits(getClient GetClientFn) (tool mcp.Tool, handler server.Too
 			mcp.WithDescription("Retrieves a list of repository commits in a project, optionally filtered by ref, path, dates, and stats."),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        "List Project Commits",
-				ReadOnlyHint: true,
+				ReadOnlyHint: mcp.ToBoolPtr(true),
 			}),
 			mcp.WithString("projectId",
 				mcp.Required(),
@@ -41,6 +40,7 @@ func GetProjectCommits(getClient GetClientFn) (tool mcp.Tool, handler server.Too
 			),
 			// Add standard MCP pagination parameters
 			WithPagination(),
+			WithListOutput(commitSchema),
 		),
 		// Handler function implementation
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -69,7 +69,11 @@ func GetProjectCommits(getClient GetClientFn) (tool mcp.Tool, handler server.Too
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
-			page, perPage, err := OptionalPaginationParams(&request)
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, commitSchema)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
@@ -77,8 +81,7 @@ func GetProjectCommits(getClient GetClientFn) (tool mcp.Tool, handler server.Too
 			// --- Construct GitLab API options
 			opts := &gl.ListCommitsOptions{
 				ListOptions: gl.ListOptions{
-					Page:    page,
-					PerPage: perPage,
+					PerPage: listParams.PerPage,
 				},
 				Since:     since,     // Assign *time.Time pointer directly
 				Until:     until,     // Assign *time.Time pointer directly
@@ -98,31 +101,477 @@ func GetProjectCommits(getClient GetClientFn) (tool mcp.Tool, handler server.Too
 			}
 
 			// --- Call GitLab API
-			commits, resp, err := glClient.Commits.ListCommits(projectIDStr, opts, gl.WithContext(ctx))
+			commits, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.Commit, *gl.Response, error) {
+				opts.Page = page
+				return glClient.Commits.ListCommits(projectIDStr, opts, gl.WithContext(ctx))
+			})
 
 			// --- Handle API errors
 			if err != nil {
-				code := http.StatusInternalServerError
-				if resp != nil {
-					code = resp.StatusCode
-				}
-				if code == http.StatusNotFound {
-					msg := fmt.Sprintf("project %q not found or access denied (%d)", projectIDStr, code)
-					return mcp.NewToolResultError(msg), nil
-				}
-				return nil, fmt.Errorf("failed to list commits for project %q: %w (status: %d)", projectIDStr, err, code)
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list commits for project %q", projectIDStr),
+					fmt.Sprintf("project %q not found or access denied", projectIDStr),
+				), nil
 			}
 
 			// --- Marshal and return success
-			// Handle empty list gracefully
-			if len(commits) == 0 {
-				return mcp.NewToolResultText("[]"), nil // Return empty JSON array
+			return newListResult(commits, pagination, output, "commit list data")
+		}
+}
+
+// commitDetail is the payload returned by the getCommit tool: the commit plus its signature status.
+type commitDetail struct {
+	*gl.Commit
+	// SignatureStatus is GitLab's verification status, "unsigned" when the commit has no signature,
+	// or "unknown" when the signature could not be retrieved.
+	SignatureStatus string           `json:"signature_status"`
+	Signature       *gl.GPGSignature `json:"signature,omitempty"`
+	// MessageTruncated is set when the message was cut to fit the response size budget.
+	MessageTruncated bool `json:"message_truncated,omitempty"`
+}
+
+// commitDiffResult is the payload returned by the getCommitDiff tool.
+type commitDiffResult struct {
+	SHA          string      `json:"sha"`
+	Summary      diffSummary `json:"summary"`
+	Diffs        []*fileDiff `json:"diffs"`
+	DiffsOmitted int         `json:"diffs_omitted,omitempty"`
+	Pagination   *Pagination `json:"pagination"`
+	// Continuation is set when file diffs were left out to fit the response size budget.
+	Continuation string `json:"continuation,omitempty"`
+}
+
+// GetCommit defines the MCP tool for retrieving a single commit with its stats and signature status.
+func GetCommit(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getCommit",
+			mcp.WithDescription("Retrieves a single commit identified by SHA, branch or tag name, including stats, last pipeline and signature verification status."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get Commit",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			WithResultSchema(commitDetail{}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("The commit SHA, or the name of a branch or tag."),
+			),
+			mcp.WithBoolean("withStats",
+				mcp.Description("Include commit stats (additions, deletions). Default is true."),
+			),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			sha, err := requiredParam[string](&request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			withStats, err := OptionalBoolParam(&request, "withStats")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
+			if withStats == nil {
+				withStats = gl.Ptr(true)
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			commit, resp, err := glClient.Commits.GetCommit(projectIDStr, sha, &gl.GetCommitOptions{Stats: withStats}, gl.WithContext(ctx))
 
-			data, err := json.Marshal(commits)
+			// --- Handle API errors
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal commit list data: %w", err)
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get commit %q from project %q", sha, projectIDStr),
+					fmt.Sprintf("commit %q not found in project %q or access denied", sha, projectIDStr),
+				), nil
+			}
+
+			// --- Look up the signature; a 404 simply means the commit is unsigned
+			detail := &commitDetail{Commit: commit}
+			signature, resp, err := glClient.Commits.GetGPGSignature(projectIDStr, commit.ID, gl.WithContext(ctx))
+			switch {
+			case err == nil && signature != nil:
+				detail.Signature = signature
+				detail.SignatureStatus = signature.VerificationStatus
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				detail.SignatureStatus = "unsigned"
+			default:
+				detail.SignatureStatus = "unknown"
+			}
+
+			// --- Cut the message, the only unbounded field, when over the response budget
+			budget := responseBudget(ctx)
+			if over := jsonSize(detail) - budget; budget > 0 && over > 0 {
+				commit.Message, detail.MessageTruncated = truncateText(commit.Message, max(len(commit.Message)-over-truncationNoticeReserve, 1))
+			}
+
+			// --- Marshal and return success
+			return newJSONResult(detail, "commit data")
+		}
+}
+
+// GetCommitDiff defines the MCP tool for retrieving the file diffs of a single commit.
+func GetCommitDiff(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getCommitDiff",
+			mcp.WithDescription("Retrieves the file diffs introduced by a commit, with per-file size caps and a change summary."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get Commit Diff",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			WithResultSchema(commitDiffResult{}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("The commit SHA, or the name of a branch or tag."),
+			),
+			mcp.WithNumber("maxDiffBytes",
+				mcp.Description(fmt.Sprintf("Maximum size in bytes of each file diff; larger diffs are truncated at a line boundary (default: %d).", DefaultMaxDiffBytes)),
+			),
+			mcp.WithNumber("maxFiles",
+				mcp.Description(fmt.Sprintf("Maximum number of file diffs to return (default: %d).", DefaultMaxDiffFiles)),
+			),
+			// Add standard MCP pagination parameters
+			WithPagination(),
+			WithContinuation(),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			sha, err := requiredParam[string](&request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			maxDiffBytes, err := OptionalIntParamWithDefault(&request, "maxDiffBytes", DefaultMaxDiffBytes)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			maxFiles, err := OptionalIntParamWithDefault(&request, "maxFiles", DefaultMaxDiffFiles)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			cont, err := optionalContinuationParam(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			opts := &gl.GetCommitDiffOptions{
+				ListOptions: gl.ListOptions{
+					PerPage: listParams.PerPage,
+				},
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			diffs, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.Diff, *gl.Response, error) {
+				opts.Page = page
+				return glClient.Commits.GetCommitDiff(projectIDStr, sha, opts, gl.WithContext(ctx))
+			})
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get diff of commit %q from project %q", sha, projectIDStr),
+					fmt.Sprintf("commit %q not found in project %q or access denied", sha, projectIDStr),
+				), nil
+			}
+
+			// --- Build result
+			result := &commitDiffResult{SHA: sha, Pagination: pagination}
+			files, omitted := buildFileDiffs(diffs, true, maxDiffBytes, maxFiles, &result.Summary)
+			result.DiffsOmitted = omitted
+			result.Summary.Commits = 1
+			result.Diffs, result.Continuation = fitFileDiffs(files, responseBudget(ctx), cont, request.Params.Name, func(files []*fileDiff, next string) int {
+				trial := *result
+				trial.Diffs, trial.Continuation = files, next
+				return jsonSize(&trial)
+			})
+
+			// --- Marshal and return success
+			return newJSONResult(result, "commit diff data")
+		}
+}
+
+// GetCommitRefs defines the MCP tool for listing the branches and tags a commit is pushed to.
+func GetCommitRefs(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getCommitRefs",
+			mcp.WithDescription("Retrieves the branches and/or tags that contain a specific commit."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get Commit Refs",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("The commit SHA."),
+			),
+			mcp.WithString("type",
+				mcp.Description("The scope of refs to return ('branch', 'tag' or 'all'). Default: 'all'."),
+				mcp.Enum("branch", "tag", "all"),
+			),
+			// Add standard MCP pagination parameters
+			WithPagination(),
+			WithListOutput(commitRefSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			sha, err := requiredParam[string](&request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			refType, err := OptionalParam[string](&request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
-			return mcp.NewToolResultText(string(data)), nil
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, commitRefSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			opts := &gl.GetCommitRefsOptions{
+				ListOptions: gl.ListOptions{
+					PerPage: listParams.PerPage,
+				},
+			}
+			if refType != "" {
+				opts.Type = &refType
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			refs, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.CommitRef, *gl.Response, error) {
+				opts.Page = page
+				return glClient.Commits.GetCommitRefs(projectIDStr, sha, opts, gl.WithContext(ctx))
+			})
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get refs of commit %q from project %q", sha, projectIDStr),
+					fmt.Sprintf("commit %q not found in project %q or access denied", sha, projectIDStr),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newListResult(refs, pagination, output, "commit refs data")
+		}
+}
+
+// GetCommitMergeRequests defines the MCP tool for listing the merge requests that introduced a commit.
+func GetCommitMergeRequests(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getCommitMergeRequests",
+			mcp.WithDescription("Retrieves the merge requests associated with a specific commit."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get Commit Merge Requests",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("The commit SHA."),
+			),
+			WithListOutput(mergeRequestSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, mergeRequestSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			sha, err := requiredParam[string](&request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			mrs, resp, err := glClient.Commits.ListMergeRequestsByCommit(projectIDStr, sha, gl.WithContext(ctx))
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list merge requests of commit %q from project %q", sha, projectIDStr),
+					fmt.Sprintf("commit %q not found in project %q or access denied", sha, projectIDStr),
+				), nil
+			}
+
+			// --- Marshal and return success
+			// GitLab returns every merge request of a commit in one unpaginated response
+			pagination := &Pagination{Page: 1, PerPage: len(mrs), PagesFetched: 1}
+			return newListResult(mrs, pagination, output, "commit merge requests data")
+		}
+}
+
+// GetCommitStatuses defines the MCP tool for listing the CI statuses reported for a commit.
+func GetCommitStatuses(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getCommitStatuses",
+			mcp.WithDescription("Retrieves the pipeline job and external statuses reported for a specific commit."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get Commit Statuses",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("The commit SHA."),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Filter statuses by branch or tag name."),
+			),
+			mcp.WithString("stage",
+				mcp.Description("Filter statuses by pipeline stage (e.g., 'test')."),
+			),
+			mcp.WithString("name",
+				mcp.Description("Filter statuses by job name (e.g., 'unit-tests')."),
+			),
+			mcp.WithBoolean("includeRetried",
+				mcp.Description("Return all statuses, including those superseded by retried jobs, not only the latest ones. Default is false."),
+			),
+			// Add standard MCP pagination parameters
+			WithPagination(),
+			WithListOutput(commitStatusSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			sha, err := requiredParam[string](&request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			refName, err := OptionalParam[string](&request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			stage, err := OptionalParam[string](&request, "stage")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			name, err := OptionalParam[string](&request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			includeRetried, err := OptionalBoolParam(&request, "includeRetried")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, commitStatusSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			opts := &gl.GetCommitStatusesOptions{
+				ListOptions: gl.ListOptions{
+					PerPage: listParams.PerPage,
+				},
+				All: includeRetried,
+			}
+			if refName != "" {
+				opts.Ref = &refName
+			}
+			if stage != "" {
+				opts.Stage = &stage
+			}
+			if name != "" {
+				opts.Name = &name
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			statuses, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.CommitStatus, *gl.Response, error) {
+				opts.Page = page
+				return glClient.Commits.GetCommitStatuses(projectIDStr, sha, opts, gl.WithContext(ctx))
+			})
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get statuses of commit %q from project %q", sha, projectIDStr),
+					fmt.Sprintf("commit %q not found in project %q or access denied", sha, projectIDStr),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newListResult(statuses, pagination, output, "commit statuses data")
 		}
 }
@@ -174,9 +174,9 @@ func TestGetProjectCommitsHandler(t *testing.T) {
 					ListCommits(projectID, gomock.Any(), gomock.Any()).
 					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
 			},
-			expectedResult:     nil,
-			expectHandlerError: true,
-			errorContains:      fmt.Sprintf("failed to list commits for project %q", projectID),
+			expectedResult:    nil,
+			expectResultError: true,
+			errorContains:     fmt.Sprintf("failed to list commits for project %q", projectID),
 		},
 		{
 			name:               "Error - Missing projectId",
@@ -214,13 +214,7 @@ func TestGetProjectCommitsHandler(t *testing.T) {
 
 			// Create the request
 			req := mcp.CallToolRequest{
-				Params: struct {
-					Name      string                 `json:"name"`
-					Arguments map[string]interface{} `json:"arguments,omitempty"`
-					Meta      *struct {
-						ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-					} `json:"_meta,omitempty"`
-				}{
+				Params: mcp.CallToolParams{
 					Name:      getProjectCommitsTool.Name,
 					Arguments: tc.inputArgs,
 				},
@@ -243,19 +237,375 @@ func TestGetProjectCommitsHandler(t *testing.T) {
 					assert.Contains(t, textContent.Text, tc.errorContains, "Error message mismatch")
 				} else {
 					// Unmarshal expected and actual results
+					items, _ := getListResult(t, result)
 					var actualCommits []*gl.Commit
-					err = json.Unmarshal([]byte(textContent.Text), &actualCommits)
+					err = json.Unmarshal([]byte(items), &actualCommits)
 					require.NoError(t, err, "Failed to unmarshal actual result JSON")
 
 					// Compare lengths first
 					require.Equal(t, len(tc.expectedResult), len(actualCommits), "Number of commits mismatch")
 
-					// Compare content using JSONEq
-					expectedJSON, _ := json.Marshal(tc.expectedResult)
-					actualJSON, _ := json.Marshal(actualCommits)
-					assert.JSONEq(t, string(expectedJSON), string(actualJSON), "Commit list content mismatch")
+					// Compare content using JSONEq against the compact commit schema
+					assert.JSONEq(t, projectedJSON(t, tc.expectedResult, commitSchema.fields), items, "Commit list content mismatch")
 				}
 			}
 		})
 	}
 }
+
+func TestGetCommitHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockCommits, ctrl := setupMockClientForCommits(t)
+	defer ctrl.Finish()
+
+	mockGetClientCommits := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	getCommitTool, getCommitHandler := GetCommit(mockGetClientCommits)
+
+	projectID := "group/project"
+	sha := "abc123"
+	commit := &gl.Commit{
+		ID:    sha,
+		Title: "Add feature X",
+		Stats: &gl.CommitStats{Additions: 10, Deletions: 2, Total: 12},
+	}
+
+	tests := []struct {
+		name               string
+		inputArgs          map[string]any
+		mockSetup          func()
+		expectedStatus     string
+		expectHandlerError bool
+		expectResultError  bool
+		errorContains      string
+	}{
+		{
+			name:      "Success - Verified Signature",
+			inputArgs: map[string]any{"projectId": projectID, "sha": sha},
+			mockSetup: func() {
+				mockCommits.EXPECT().
+					GetCommit(projectID, sha, gomock.AssignableToTypeOf(&gl.GetCommitOptions{}), gomock.Any()).
+					DoAndReturn(func(_ interface{}, _ string, opts *gl.GetCommitOptions, _ ...gl.RequestOptionFunc) (*gl.Commit, *gl.Response, error) {
+						require.NotNil(t, opts.Stats)
+						assert.True(t, *opts.Stats, "stats should default to true")
+						return commit, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil
+					})
+				mockCommits.EXPECT().
+					GetGPGSignature(projectID, sha, gomock.Any()).
+					Return(&gl.GPGSignature{VerificationStatus: "verified"}, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+			},
+			expectedStatus: "verified",
+		},
+		{
+			name:      "Success - Unsigned Commit",
+			inputArgs: map[string]any{"projectId": projectID, "sha": sha, "withStats": false},
+			mockSetup: func() {
+				mockCommits.EXPECT().
+					GetCommit(projectID, sha, &gl.GetCommitOptions{Stats: gl.Ptr(false)}, gomock.Any()).
+					Return(commit, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+				mockCommits.EXPECT().
+					GetGPGSignature(projectID, sha, gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Not Found"))
+			},
+			expectedStatus: "unsigned",
+		},
+		{
+			name:      "Error - Commit Not Found (404)",
+			inputArgs: map[string]any{"projectId": projectID, "sha": "missing"},
+			mockSetup: func() {
+				mockCommits.EXPECT().
+					GetCommit(projectID, "missing", gomock.Any(), gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Not Found"))
+			},
+			expectResultError: true,
+			errorContains:     fmt.Sprintf("commit %q not found in project %q", "missing", projectID),
+		},
+		{
+			name:      "Error - GitLab API Error (500)",
+			inputArgs: map[string]any{"projectId": projectID, "sha": sha},
+			mockSetup: func() {
+				mockCommits.EXPECT().
+					GetCommit(projectID, sha, gomock.Any(), gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
+			},
+			expectResultError: true,
+			errorContains:     fmt.Sprintf("failed to get commit %q", sha),
+		},
+		{
+			name:              "Error - Missing sha",
+			inputArgs:         map[string]any{"projectId": projectID},
+			mockSetup:         func() {},
+			expectResultError: true,
+			errorContains:     "Validation Error: missing required parameter: sha",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			result, err := getCommitHandler(ctx, createCallToolRequest(getCommitTool.Name, tc.inputArgs))
+
+			if tc.expectHandlerError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectResultError {
+				assert.Contains(t, textContent.Text, tc.errorContains, "Error message mismatch")
+				return
+			}
+
+			var actual map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &actual))
+			assert.Equal(t, sha, actual["id"])
+			assert.Equal(t, tc.expectedStatus, actual["signature_status"])
+			assert.NotNil(t, actual["stats"])
+		})
+	}
+}
+
+func TestGetCommitDiffHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockCommits, ctrl := setupMockClientForCommits(t)
+	defer ctrl.Finish()
+
+	mockGetClientCommits := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	getCommitDiffTool, getCommitDiffHandler := GetCommitDiff(mockGetClientCommits)
+
+	projectID := "group/project"
+	sha := "abc123"
+	diffs := []*gl.Diff{
+		{OldPath: "a.go", NewPath: "a.go", Diff: "@@ -1 +1 @@\n-old line\n+new line\n"},
+		{OldPath: "b.go", NewPath: "b.go", DeletedFile: true, Diff: "@@ -1,2 +0,0 @@\n-one\n-two\n"},
+	}
+
+	tests := []struct {
+		name               string
+		inputArgs          map[string]any
+		mockSetup          func()
+		check              func(t *testing.T, result *commitDiffResult)
+		expectHandlerError bool
+		expectResultError  bool
+		errorContains      string
+	}{
+		{
+			name:      "Success - Diff With Summary",
+			inputArgs: map[string]any{"projectId": projectID, "sha": sha, "page": 2},
+			mockSetup: func() {
+				mockCommits.EXPECT().
+					GetCommitDiff(projectID, sha, gomock.AssignableToTypeOf(&gl.GetCommitDiffOptions{}), gomock.Any()).
+					DoAndReturn(func(_ interface{}, _ string, opts *gl.GetCommitDiffOptions, _ ...gl.RequestOptionFunc) ([]*gl.Diff, *gl.Response, error) {
+						assert.Equal(t, 2, opts.Page)
+						assert.Equal(t, DefaultPerPage, opts.PerPage)
+						return diffs, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil
+					})
+			},
+			check: func(t *testing.T, result *commitDiffResult) {
+				assert.Equal(t, diffSummary{Commits: 1, FilesChanged: 2, Additions: 1, Deletions: 3}, result.Summary)
+				require.Len(t, result.Diffs, 2)
+				assert.True(t, result.Diffs[1].DeletedFile)
+			},
+		},
+		{
+			name:      "Success - Per-File Cap",
+			inputArgs: map[string]any{"projectId": projectID, "sha": sha, "maxDiffBytes": 20},
+			mockSetup: func() {
+				mockCommits.EXPECT().
+					GetCommitDiff(projectID, sha, gomock.Any(), gomock.Any()).
+					Return(diffs, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+			},
+			check: func(t *testing.T, result *commitDiffResult) {
+				require.Len(t, result.Diffs, 2)
+				assert.True(t, result.Diffs[0].Truncated)
+				assert.Equal(t, "@@ -1 +1 @@\n", result.Diffs[0].Diff)
+			},
+		},
+		{
+			name:      "Error - Commit Not Found (404)",
+			inputArgs: map[string]any{"projectId": projectID, "sha": sha},
+			mockSetup: func() {
+				mockCommits.EXPECT().
+					GetCommitDiff(projectID, sha, gomock.Any(), gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Not Found"))
+			},
+			expectResultError: true,
+			errorContains:     "not found",
+		},
+		{
+			name:      "Error - GitLab API Error (500)",
+			inputArgs: map[string]any{"projectId": projectID, "sha": sha},
+			mockSetup: func() {
+				mockCommits.EXPECT().
+					GetCommitDiff(projectID, sha, gomock.Any(), gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
+			},
+			expectResultError: true,
+			errorContains:     "failed to get diff of commit",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			result, err := getCommitDiffHandler(ctx, createCallToolRequest(getCommitDiffTool.Name, tc.inputArgs))
+
+			if tc.expectHandlerError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectResultError {
+				assert.Contains(t, textContent.Text, tc.errorContains, "Error message mismatch")
+				return
+			}
+
+			var actual commitDiffResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &actual))
+			tc.check(t, &actual)
+		})
+	}
+}
+
+func TestGetCommitRefsHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockCommits, ctrl := setupMockClientForCommits(t)
+	defer ctrl.Finish()
+
+	mockGetClientCommits := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	getCommitRefsTool, getCommitRefsHandler := GetCommitRefs(mockGetClientCommits)
+
+	projectID := "group/project"
+	sha := "abc123"
+
+	t.Run("Success - Filter By Type", func(t *testing.T) {
+		mockCommits.EXPECT().
+			GetCommitRefs(projectID, sha, gomock.AssignableToTypeOf(&gl.GetCommitRefsOptions{}), gomock.Any()).
+			DoAndReturn(func(_ interface{}, _ string, opts *gl.GetCommitRefsOptions, _ ...gl.RequestOptionFunc) ([]*gl.CommitRef, *gl.Response, error) {
+				require.NotNil(t, opts.Type)
+				assert.Equal(t, "tag", *opts.Type)
+				return []*gl.CommitRef{{Type: "tag", Name: "v1.0.0"}}, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil
+			})
+
+		result, err := getCommitRefsHandler(ctx, createCallToolRequest(getCommitRefsTool.Name, map[string]any{"projectId": projectID, "sha": sha, "type": "tag"}))
+		require.NoError(t, err)
+		items, _ := getListResult(t, result)
+		assert.JSONEq(t, `[{"type":"tag","name":"v1.0.0"}]`, items)
+	})
+
+	t.Run("Success - Empty List", func(t *testing.T) {
+		mockCommits.EXPECT().
+			GetCommitRefs(projectID, sha, gomock.Any(), gomock.Any()).
+			Return([]*gl.CommitRef{}, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+
+		result, err := getCommitRefsHandler(ctx, createCallToolRequest(getCommitRefsTool.Name, map[string]any{"projectId": projectID, "sha": sha}))
+		require.NoError(t, err)
+		items, pagination := getListResult(t, result)
+		assert.Equal(t, "[]", items)
+		assert.False(t, pagination.HasMore)
+	})
+
+	t.Run("Error - Commit Not Found (404)", func(t *testing.T) {
+		mockCommits.EXPECT().
+			GetCommitRefs(projectID, sha, gomock.Any(), gomock.Any()).
+			Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Not Found"))
+
+		result, err := getCommitRefsHandler(ctx, createCallToolRequest(getCommitRefsTool.Name, map[string]any{"projectId": projectID, "sha": sha}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "not found")
+	})
+}
+
+func TestGetCommitMergeRequestsHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockCommits, ctrl := setupMockClientForCommits(t)
+	defer ctrl.Finish()
+
+	mockGetClientCommits := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	getCommitMRsTool, getCommitMRsHandler := GetCommitMergeRequests(mockGetClientCommits)
+
+	projectID := "group/project"
+	sha := "abc123"
+
+	t.Run("Success - Merge Requests Found", func(t *testing.T) {
+		mockCommits.EXPECT().
+			ListMergeRequestsByCommit(projectID, sha, gomock.Any()).
+			Return([]*gl.BasicMergeRequest{{IID: 42, Title: "Add feature X"}}, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+
+		result, err := getCommitMRsHandler(ctx, createCallToolRequest(getCommitMRsTool.Name, map[string]any{"projectId": projectID, "sha": sha}))
+		require.NoError(t, err)
+		items, _ := getListResult(t, result)
+		var actual []*gl.BasicMergeRequest
+		require.NoError(t, json.Unmarshal([]byte(items), &actual))
+		require.Len(t, actual, 1)
+		assert.Equal(t, 42, actual[0].IID)
+	})
+
+	t.Run("Error - GitLab API Error (500)", func(t *testing.T) {
+		mockCommits.EXPECT().
+			ListMergeRequestsByCommit(projectID, sha, gomock.Any()).
+			Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
+
+		result, err := getCommitMRsHandler(ctx, createCallToolRequest(getCommitMRsTool.Name, map[string]any{"projectId": projectID, "sha": sha}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "failed to list merge requests of commit")
+	})
+}
+
+func TestGetCommitStatusesHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockCommits, ctrl := setupMockClientForCommits(t)
+	defer ctrl.Finish()
+
+	mockGetClientCommits := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	getCommitStatusesTool, getCommitStatusesHandler := GetCommitStatuses(mockGetClientCommits)
+
+	projectID := "group/project"
+	sha := "abc123"
+
+	t.Run("Success - With Filters", func(t *testing.T) {
+		mockCommits.EXPECT().
+			GetCommitStatuses(projectID, sha, gomock.AssignableToTypeOf(&gl.GetCommitStatusesOptions{}), gomock.Any()).
+			DoAndReturn(func(_ interface{}, _ string, opts *gl.GetCommitStatusesOptions, _ ...gl.RequestOptionFunc) ([]*gl.CommitStatus, *gl.Response, error) {
+				assert.Equal(t, "main", *opts.Ref)
+				assert.Equal(t, "test", *opts.Stage)
+				assert.Equal(t, "unit", *opts.Name)
+				assert.True(t, *opts.All)
+				return []*gl.CommitStatus{{ID: 1, Name: "unit", Status: "success", PipelineId: 7}}, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil
+			})
+
+		args := map[string]any{"projectId": projectID, "sha": sha, "ref": "main", "stage": "test", "name": "unit", "includeRetried": true}
+		result, err := getCommitStatusesHandler(ctx, createCallToolRequest(getCommitStatusesTool.Name, args))
+		require.NoError(t, err)
+		items, _ := getListResult(t, result)
+		var actual []*gl.CommitStatus
+		require.NoError(t, json.Unmarshal([]byte(items), &actual))
+		require.Len(t, actual, 1)
+		assert.Equal(t, 7, actual[0].PipelineId)
+	})
+
+	t.Run("Error - Invalid all type", func(t *testing.T) {
+		result, err := getCommitStatusesHandler(ctx, createCallToolRequest(getCommitStatusesTool.Name, map[string]any{"projectId": projectID, "sha": sha, "includeRetried": "sometimes"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "Validation Error")
+	})
+}
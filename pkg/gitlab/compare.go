@@ -0,0 +1,276 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	gl "gitlab.com/gitlab-org/api/client-go" // GitLab client library
+)
+
+// DefaultMaxDiffBytes defines the default maximum size of a single file diff returned by diff tools.
+const DefaultMaxDiffBytes = 20000
+
+// DefaultMaxDiffFiles defines the default maximum number of file diffs returned by diff tools.
+const DefaultMaxDiffFiles = 100
+
+// diffSummary aggregates change statistics across a set of file diffs.
+type diffSummary struct {
+	Commits      int `json:"commits"`
+	FilesChanged int `json:"files_changed"`
+	Additions    int `json:"additions"`
+	Deletions    int `json:"deletions"`
+}
+
+// fileDiff is a single file diff, possibly truncated to the configured size cap.
+type fileDiff struct {
+	OldPath     string `json:"old_path"`
+	NewPath     string `json:"new_path"`
+	NewFile     bool   `json:"new_file"`
+	RenamedFile bool   `json:"renamed_file"`
+	DeletedFile bool   `json:"deleted_file"`
+	Additions   int    `json:"additions"`
+	Deletions   int    `json:"deletions"`
+	Diff        string `json:"diff,omitempty"`
+	Truncated   bool   `json:"truncated,omitempty"`
+}
+
+// compareResult is the payload returned by the compareRefs tool.
+type compareResult struct {
+	From           string       `json:"from"`
+	To             string       `json:"to"`
+	Straight       bool         `json:"straight"`
+	CompareSameRef bool         `json:"compare_same_ref"`
+	CompareTimeout bool         `json:"compare_timeout"`
+	WebURL         string       `json:"web_url,omitempty"`
+	Summary        diffSummary  `json:"summary"`
+	Commits        []*gl.Commit `json:"commits,omitempty"`
+	Diffs          []*fileDiff  `json:"diffs,omitempty"`
+	// DiffsOmitted counts file diffs dropped because maxFiles was reached.
+	DiffsOmitted int `json:"diffs_omitted,omitempty"`
+	// Continuation is set when commits or file diffs were left out to fit the response size budget.
+	Continuation string `json:"continuation,omitempty"`
+}
+
+// countDiffLines counts added and removed lines in a unified diff, ignoring file headers.
+func countDiffLines(diff string) (additions, deletions int) {
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			// File header lines, not content changes
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+	return additions, deletions
+}
+
+// truncateDiff cuts a diff to at most maxBytes, backing off to the last complete line.
+// A non-positive maxBytes disables truncation.
+func truncateDiff(diff string, maxBytes int) (string, bool) {
+	if maxBytes <= 0 || len(diff) <= maxBytes {
+		return diff, false
+	}
+	cut := diff[:maxBytes]
+	if idx := strings.LastIndex(cut, "\n"); idx > 0 {
+		cut = cut[:idx+1]
+	}
+	return cut, true
+}
+
+// buildFileDiffs converts GitLab diffs into capped fileDiffs and accumulates their stats into summary.
+// When includeDiff is false only paths and line counts are kept. Diffs beyond maxFiles are counted but dropped.
+func buildFileDiffs(diffs []*gl.Diff, includeDiff bool, maxBytes, maxFiles int, summary *diffSummary) (files []*fileDiff, omitted int) {
+	files = make([]*fileDiff, 0, len(diffs))
+	for _, d := range diffs {
+		if d == nil {
+			continue
+		}
+		additions, deletions := countDiffLines(d.Diff)
+		summary.FilesChanged++
+		summary.Additions += additions
+		summary.Deletions += deletions
+
+		if maxFiles > 0 && len(files) >= maxFiles {
+			omitted++
+			continue
+		}
+		fd := &fileDiff{
+			OldPath:     d.OldPath,
+			NewPath:     d.NewPath,
+			NewFile:     d.NewFile,
+			RenamedFile: d.RenamedFile,
+			DeletedFile: d.DeletedFile,
+			Additions:   additions,
+			Deletions:   deletions,
+		}
+		if includeDiff {
+			fd.Diff, fd.Truncated = truncateDiff(d.Diff, maxBytes)
+		}
+		files = append(files, fd)
+	}
+	return files, omitted
+}
+
+// fitFileDiffs skips the file diffs already returned according to cont and keeps as many of
+// the rest as fit in budget, returning them with the continuation token for the others.
+// size returns the encoded size of the result holding the given diffs and token.
+func fitFileDiffs(files []*fileDiff, budget int, cont continuation, tool string, size func(files []*fileDiff, next string) int) ([]*fileDiff, string) {
+	files = files[min(cont.Offset, len(files)):]
+	k, next := fitToBudget(len(files), budget, cont, tool, func(k int, next string) int {
+		return size(files[:k], next)
+	})
+	return files[:k], next
+}
+
+// fitComparison is the fitFileDiffs counterpart for results listing commits before file diffs:
+// the continuation offset counts the commits first, then the file diffs, so neither is
+// returned twice. size returns the encoded size of the result holding the given items and token.
+func fitComparison(commits []*gl.Commit, files []*fileDiff, budget int, cont continuation, tool string, size func(commits []*gl.Commit, files []*fileDiff, next string) int) ([]*gl.Commit, []*fileDiff, string) {
+	skip := min(cont.Offset, len(commits)+len(files))
+	files = files[max(skip-len(commits), 0):]
+	commits = commits[min(skip, len(commits)):]
+	split := func(k int) ([]*gl.Commit, []*fileDiff) {
+		c := min(k, len(commits))
+		return commits[:c], files[:k-c]
+	}
+	k, next := fitToBudget(len(commits)+len(files), budget, cont, tool, func(k int, next string) int {
+		c, f := split(k)
+		return size(c, f, next)
+	})
+	c, f := split(k)
+	return c, f, next
+}
+
+// CompareRefs defines the MCP tool for comparing two branches, tags or commits.
+func CompareRefs(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"compareRefs",
+			mcp.WithDescription("Compares two branches, tags or commit SHAs in a project, returning the commits and file diffs between them along with a change summary."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Compare Refs",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			WithResultSchema(compareResult{}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("from",
+				mcp.Required(),
+				mcp.Description("The branch name, tag or commit SHA to compare from (the base)."),
+			),
+			mcp.WithString("to",
+				mcp.Required(),
+				mcp.Description("The branch name, tag or commit SHA to compare to (the head)."),
+			),
+			mcp.WithBoolean("straight",
+				mcp.Description("Compare 'from' and 'to' directly (from..to) instead of from their merge base (from...to). Default is false."),
+			),
+			mcp.WithBoolean("summaryOnly",
+				mcp.Description("Return only the change summary (commits, files changed, additions, deletions) without commits or diffs. Default is false."),
+			),
+			mcp.WithBoolean("includeDiffs",
+				mcp.Description("Include the diff text of each changed file. When false, only paths and line counts are returned. Default is true."),
+			),
+			mcp.WithNumber("maxDiffBytes",
+				mcp.Description(fmt.Sprintf("Maximum size in bytes of each file diff; larger diffs are truncated at a line boundary (default: %d).", DefaultMaxDiffBytes)),
+			),
+			mcp.WithNumber("maxFiles",
+				mcp.Description(fmt.Sprintf("Maximum number of file diffs to return (default: %d).", DefaultMaxDiffFiles)),
+			),
+			WithContinuation(),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			from, err := requiredParam[string](&request, "from")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			to, err := requiredParam[string](&request, "to")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			straight, err := OptionalBoolParam(&request, "straight")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			summaryOnly, err := OptionalBoolParam(&request, "summaryOnly")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			includeDiffs, err := OptionalBoolParam(&request, "includeDiffs")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			maxDiffBytes, err := OptionalIntParamWithDefault(&request, "maxDiffBytes", DefaultMaxDiffBytes)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			maxFiles, err := OptionalIntParamWithDefault(&request, "maxFiles", DefaultMaxDiffFiles)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			cont, err := optionalContinuationParam(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			opts := &gl.CompareOptions{
+				From:     &from,
+				To:       &to,
+				Straight: straight,
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			cmp, resp, err := glClient.Repositories.Compare(projectIDStr, opts, gl.WithContext(ctx))
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to compare %q...%q in project %q", from, to, projectIDStr),
+					fmt.Sprintf("project %q or refs %q/%q not found, or access denied", projectIDStr, from, to),
+				), nil
+			}
+
+			// --- Build result
+			result := &compareResult{
+				From:           from,
+				To:             to,
+				Straight:       straight != nil && *straight,
+				CompareSameRef: cmp.CompareSameRef,
+				CompareTimeout: cmp.CompareTimeout,
+				WebURL:         cmp.WebURL,
+			}
+			result.Summary.Commits = len(cmp.Commits)
+			withDiffText := includeDiffs == nil || *includeDiffs
+			files, omitted := buildFileDiffs(cmp.Diffs, withDiffText, maxDiffBytes, maxFiles, &result.Summary)
+			if summaryOnly == nil || !*summaryOnly {
+				result.DiffsOmitted = omitted
+				result.Commits, result.Diffs, result.Continuation = fitComparison(cmp.Commits, files, responseBudget(ctx), cont, request.Params.Name, func(commits []*gl.Commit, files []*fileDiff, next string) int {
+					trial := *result
+					trial.Commits, trial.Diffs, trial.Continuation = commits, files, next
+					return jsonSize(&trial)
+				})
+			}
+
+			// --- Marshal and return success
+			return newJSONResult(result, "compare data")
+		}
+}
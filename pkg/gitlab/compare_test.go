@@ -0,0 +1,241 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestCompareRefsHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockRepos, ctrl := setupMockClientForRepos(t)
+	defer ctrl.Finish()
+
+	// Mock getClient function for compare tests
+	mockGetClientCompare := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+
+	// --- Define the Tool and Handler ---
+	compareRefsTool, compareRefsHandler := CompareRefs(mockGetClientCompare)
+
+	projectID := "group/project"
+	from := "v1.0.0"
+	to := "main"
+
+	// Helper to create a mock comparison payload
+	createCompare := func() *gl.Compare {
+		return &gl.Compare{
+			Commits: []*gl.Commit{
+				{ID: "sha1", ShortID: "sha1abc", Title: "Add feature X"},
+				{ID: "sha2", ShortID: "sha2def", Title: "Fix bug Y"},
+			},
+			Diffs: []*gl.Diff{
+				{
+					OldPath: "main.go",
+					NewPath: "main.go",
+					Diff:    "--- a/main.go\n+++ b/main.go\n@@ -1,2 +1,3 @@\n package main\n-var a = 1\n+var a = 2\n+var b = 3\n",
+				},
+				{
+					OldPath: "README.md",
+					NewPath: "README.md",
+					NewFile: true,
+					Diff:    "@@ -0,0 +1 @@\n+# Title\n",
+				},
+			},
+			WebURL: "https://gitlab.example.com/group/project/-/compare/v1.0.0...main",
+		}
+	}
+
+	// --- Test Cases ---
+	tests := []struct {
+		name               string
+		inputArgs          map[string]any
+		mockSetup          func()
+		check              func(t *testing.T, result *compareResult)
+		expectHandlerError bool
+		expectResultError  bool
+		errorContains      string
+	}{
+		{
+			name: "Success - Compare With Merge Base",
+			inputArgs: map[string]any{
+				"projectId": projectID,
+				"from":      from,
+				"to":        to,
+			},
+			mockSetup: func() {
+				mockRepos.EXPECT().
+					Compare(projectID, gomock.AssignableToTypeOf(&gl.CompareOptions{}), gomock.Any()).
+					DoAndReturn(func(_ interface{}, opts *gl.CompareOptions, _ ...gl.RequestOptionFunc) (*gl.Compare, *gl.Response, error) {
+						assert.Equal(t, from, *opts.From)
+						assert.Equal(t, to, *opts.To)
+						assert.Nil(t, opts.Straight)
+						return createCompare(), &gl.Response{Response: &http.Response{StatusCode: 200}}, nil
+					})
+			},
+			check: func(t *testing.T, result *compareResult) {
+				assert.False(t, result.Straight)
+				assert.Equal(t, diffSummary{Commits: 2, FilesChanged: 2, Additions: 3, Deletions: 1}, result.Summary)
+				require.Len(t, result.Commits, 2)
+				require.Len(t, result.Diffs, 2)
+				assert.Equal(t, 2, result.Diffs[0].Additions)
+				assert.Equal(t, 1, result.Diffs[0].Deletions)
+				assert.True(t, result.Diffs[1].NewFile)
+				assert.NotEmpty(t, result.Diffs[0].Diff)
+				assert.False(t, result.Diffs[0].Truncated)
+			},
+		},
+		{
+			name: "Success - Straight Summary Only",
+			inputArgs: map[string]any{
+				"projectId":   projectID,
+				"from":        from,
+				"to":          to,
+				"straight":    true,
+				"summaryOnly": true,
+			},
+			mockSetup: func() {
+				mockRepos.EXPECT().
+					Compare(projectID, gomock.AssignableToTypeOf(&gl.CompareOptions{}), gomock.Any()).
+					DoAndReturn(func(_ interface{}, opts *gl.CompareOptions, _ ...gl.RequestOptionFunc) (*gl.Compare, *gl.Response, error) {
+						require.NotNil(t, opts.Straight)
+						assert.True(t, *opts.Straight)
+						return createCompare(), &gl.Response{Response: &http.Response{StatusCode: 200}}, nil
+					})
+			},
+			check: func(t *testing.T, result *compareResult) {
+				assert.True(t, result.Straight)
+				assert.Equal(t, diffSummary{Commits: 2, FilesChanged: 2, Additions: 3, Deletions: 1}, result.Summary)
+				assert.Empty(t, result.Commits)
+				assert.Empty(t, result.Diffs)
+			},
+		},
+		{
+			name: "Success - Diff Caps Applied",
+			inputArgs: map[string]any{
+				"projectId":    projectID,
+				"from":         from,
+				"to":           to,
+				"maxDiffBytes": 40,
+				"maxFiles":     1,
+			},
+			mockSetup: func() {
+				mockRepos.EXPECT().
+					Compare(projectID, gomock.Any(), gomock.Any()).
+					Return(createCompare(), &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+			},
+			check: func(t *testing.T, result *compareResult) {
+				assert.Equal(t, 2, result.Summary.FilesChanged, "summary should cover omitted files")
+				require.Len(t, result.Diffs, 1)
+				assert.Equal(t, 1, result.DiffsOmitted)
+				assert.True(t, result.Diffs[0].Truncated)
+				assert.LessOrEqual(t, len(result.Diffs[0].Diff), 40)
+				assert.True(t, strings.HasSuffix(result.Diffs[0].Diff, "\n"), "diff should be cut at a line boundary")
+			},
+		},
+		{
+			name: "Success - Without Diff Text",
+			inputArgs: map[string]any{
+				"projectId":    projectID,
+				"from":         from,
+				"to":           to,
+				"includeDiffs": false,
+			},
+			mockSetup: func() {
+				mockRepos.EXPECT().
+					Compare(projectID, gomock.Any(), gomock.Any()).
+					Return(createCompare(), &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+			},
+			check: func(t *testing.T, result *compareResult) {
+				require.Len(t, result.Diffs, 2)
+				assert.Empty(t, result.Diffs[0].Diff)
+				assert.Equal(t, 2, result.Diffs[0].Additions)
+			},
+		},
+		{
+			name: "Error - Ref Not Found (404)",
+			inputArgs: map[string]any{
+				"projectId": projectID,
+				"from":      "missing",
+				"to":        to,
+			},
+			mockSetup: func() {
+				mockRepos.EXPECT().
+					Compare(projectID, gomock.Any(), gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Not Found"))
+			},
+			expectResultError: true,
+			errorContains:     "not found, or access denied",
+		},
+		{
+			name: "Error - GitLab API Error (500)",
+			inputArgs: map[string]any{
+				"projectId": projectID,
+				"from":      from,
+				"to":        to,
+			},
+			mockSetup: func() {
+				mockRepos.EXPECT().
+					Compare(projectID, gomock.Any(), gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
+			},
+			expectResultError: true,
+			errorContains:     "failed to compare",
+		},
+		{
+			name:              "Error - Missing to",
+			inputArgs:         map[string]any{"projectId": projectID, "from": from},
+			mockSetup:         func() {},
+			expectResultError: true,
+			errorContains:     "Validation Error: missing required parameter: to",
+		},
+	}
+
+	// --- Run Tests ---
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			// Create the request
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      compareRefsTool.Name,
+					Arguments: tc.inputArgs,
+				},
+			}
+
+			// Execute the handler
+			result, err := compareRefsHandler(ctx, req)
+
+			// Assertions
+			if tc.expectHandlerError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectResultError {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.errorContains, "Error message mismatch")
+				return
+			}
+
+			var actual compareResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &actual), "Failed to unmarshal actual result JSON")
+			tc.check(t, &actual)
+		})
+	}
+}
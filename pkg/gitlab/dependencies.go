@@ -0,0 +1,813 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	gl "gitlab.com/gitlab-org/api/client-go" // GitLab client library
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultMaxGroupProjects caps how many projects listGroupDependencies scans when 'maxProjects' is not set.
+	DefaultMaxGroupProjects = 200
+	// MaxGroupProjectsLimit is the largest 'maxProjects' value accepted by listGroupDependencies.
+	MaxGroupProjectsLimit = 2000
+	// groupDependencyWorkers is the number of projects whose dependencies are fetched concurrently.
+	groupDependencyWorkers = 4
+)
+
+// dependencyPackageManagers are the package managers the dependency list can be filtered by.
+var dependencyPackageManagers = []string{
+	"bundler", "composer", "conan", "go", "gradle", "maven", "npm", "nuget", "pip", "pipenv", "pnpm", "yarn", "sbt", "setuptools",
+}
+
+// dependency is a dependency of a project, with the number of its known vulnerabilities.
+type dependency struct {
+	// Project is the path of the project, set in results spanning several projects.
+	Project              string                        `json:"project,omitempty"`
+	Name                 string                        `json:"name"`
+	Version              string                        `json:"version"`
+	PackageManager       string                        `json:"package_manager"`
+	DependencyFilePath   string                        `json:"dependency_file_path"`
+	VulnerabilitiesCount int                           `json:"vulnerabilities_count"`
+	Vulnerabilities      []*gl.DependencyVulnerability `json:"vulnerabilities"`
+	Licenses             []*gl.DependencyLicense       `json:"licenses"`
+}
+
+// groupDependencies is the result of a dependency query across the projects of a group.
+type groupDependencies struct {
+	Dependencies    []*dependency `json:"dependencies"`
+	ProjectsScanned int           `json:"projects_scanned"`
+	// ProjectsFailed lists the projects whose dependency list could not be read, e.g., because
+	// dependency scanning is not available to them.
+	ProjectsFailed []*projectFailure `json:"projects_failed"`
+	// Truncated is set when the group has more projects than were scanned.
+	Truncated bool `json:"truncated"`
+}
+
+// projectFailure is a project a query across a group could not read, and why.
+type projectFailure struct {
+	Project string `json:"project"`
+	Error   string `json:"error"`
+}
+
+// licenseViolations is the result of checking the dependencies of a project against its
+// license approval policies.
+type licenseViolations struct {
+	Violations []*licenseViolation `json:"violations"`
+	// PoliciesEvaluated is the number of enabled policies with license rules; none means the
+	// project has no license policy to violate.
+	PoliciesEvaluated     int `json:"policies_evaluated"`
+	DependenciesEvaluated int `json:"dependencies_evaluated"`
+}
+
+// licenseViolation is a dependency whose license a license approval policy does not allow.
+type licenseViolation struct {
+	Policy             string `json:"policy"`
+	License            string `json:"license"`
+	Name               string `json:"name"`
+	Version            string `json:"version"`
+	PackageManager     string `json:"package_manager"`
+	DependencyFilePath string `json:"dependency_file_path"`
+}
+
+// ListDependencies defines the MCP tool for listing the dependencies of a project.
+func ListDependencies(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"listDependencies",
+			mcp.WithDescription("Lists the dependencies of a project detected by dependency scanning on its default branch: package, version, package manager, "+
+				"the manifest declaring it, known vulnerabilities and licenses. Requires GitLab Ultimate."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "List Dependencies",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			withDependencyFilterParams(),
+			WithPagination(),
+			WithListOutput(dependencySchema),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectID, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			filter, packageManagers, err := optionalDependencyFilterParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, dependencySchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			// The package and version filters apply to the fetched dependencies: search them all
+			if !filter.empty() && listParams.MaxItems == 0 {
+				listParams.MaxItems = MaxItemsLimit
+				if _, ok, _ := OptionalParamOK[any](&request, "per_page"); !ok {
+					listParams.PerPage = MaxPerPage
+				}
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			dependencies, pagination, resp, err := fetchDependencies(ctx, glClient, projectID, packageManagers, listParams)
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list dependencies of project %q", projectID),
+					fmt.Sprintf("project %q not found or access denied", projectID),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newListResult(filter.apply(dependencies, ""), pagination, output, "dependency list data")
+		}
+}
+
+// ListGroupDependencies defines the MCP tool for querying the dependencies of the projects of a group.
+func ListGroupDependencies(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"listGroupDependencies",
+			mcp.WithDescription("Finds which projects of a group, including its subgroups, depend on a package, optionally within a version range "+
+				"(e.g., package 'log4j-core' with version '<2.17'); 'package' or 'version' is required. Archived projects are skipped, as are projects whose dependency list is not available, which are reported. "+
+				"Requires GitLab Ultimate."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "List Group Dependencies",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("groupId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the group."),
+			),
+			withDependencyFilterParams(),
+			mcp.WithBoolean("includeSubgroups",
+				mcp.Description("Include the projects of subgroups. Default: true."),
+			),
+			mcp.WithNumber("maxProjects",
+				mcp.Description(fmt.Sprintf("The maximum number of projects to scan, most recently active first (default: %d, max: %d).", DefaultMaxGroupProjects, MaxGroupProjectsLimit)),
+			),
+			WithOutput(groupDependenciesSchema),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			groupID, err := requiredParam[string](&request, "groupId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			filter, packageManagers, err := optionalDependencyFilterParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			if filter.empty() {
+				// A package manager alone matches most of the dependencies of every project
+				return mcp.NewToolResultError("Validation Error: at least one of 'package' or 'version' is required"), nil
+			}
+			includeSubgroups, err := OptionalBoolParam(&request, "includeSubgroups")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			maxProjects, err := OptionalIntParamWithDefault(&request, "maxProjects", DefaultMaxGroupProjects)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			if maxProjects < 1 || maxProjects > MaxGroupProjectsLimit {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: 'maxProjects' must be between 1 and %d, got %d", MaxGroupProjectsLimit, maxProjects)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, groupDependenciesSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			if includeSubgroups == nil {
+				includeSubgroups = gl.Ptr(true)
+			}
+			opts := &gl.ListGroupProjectsOptions{
+				ListOptions: gl.ListOptions{
+					PerPage: min(MaxPerPage, maxProjects),
+				},
+				IncludeSubGroups: includeSubgroups,
+				Archived:         gl.Ptr(false),
+				WithShared:       gl.Ptr(false),
+				Simple:           gl.Ptr(true),
+				OrderBy:          gl.Ptr("last_activity_at"),
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			// Whole pages are collected, so walk enough of them to cover maxProjects and drop the excess
+			pages := (maxProjects + opts.PerPage - 1) / opts.PerPage
+			projects, pagination, resp, err := collectPages(ListParams{Page: 1, PerPage: opts.PerPage, MaxItems: pages * opts.PerPage}, func(page int) ([]*gl.Project, *gl.Response, error) {
+				opts.Page = page
+				return glClient.Groups.ListGroupProjects(groupID, opts, gl.WithContext(ctx))
+			})
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list projects of group %q", groupID),
+					fmt.Sprintf("group %q not found or access denied", groupID),
+				), nil
+			}
+
+			truncated := pagination.HasMore || len(projects) > maxProjects
+			projects = projects[:min(len(projects), maxProjects)]
+
+			// --- Query the dependencies of each project
+			result := &groupDependencies{
+				Dependencies:    []*dependency{},
+				ProjectsScanned: len(projects),
+				ProjectsFailed:  []*projectFailure{},
+				Truncated:       truncated,
+			}
+			found := make([][]*dependency, len(projects))
+			failures := make([]*projectFailure, len(projects))
+			all := ListParams{Page: 1, PerPage: MaxPerPage, MaxItems: MaxItemsLimit}
+			forEachConcurrently(len(projects), groupDependencyWorkers, func(i int) {
+				project := projects[i]
+				dependencies, _, resp, err := fetchDependencies(ctx, glClient, project.ID, packageManagers, all)
+				if err != nil {
+					failures[i] = &projectFailure{
+						Project: project.PathWithNamespace,
+						Error:   apiErrorMessage(err, resp, "failed to list dependencies", "dependency list not found or access denied", time.Now()),
+					}
+					return
+				}
+				found[i] = filter.apply(dependencies, project.PathWithNamespace)
+			})
+			for i := range projects {
+				result.Dependencies = append(result.Dependencies, found[i]...)
+				if failures[i] != nil {
+					result.ProjectsFailed = append(result.ProjectsFailed, failures[i])
+				}
+			}
+
+			// --- Marshal and return success
+			return newProjectedResult(result, output, "group dependency data")
+		}
+}
+
+// ListLicenseViolations defines the MCP tool for checking the dependencies of a project against its license policies.
+func ListLicenseViolations(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"listLicenseViolations",
+			mcp.WithDescription("Lists the dependencies of a project whose licenses violate the license rules of the enabled merge request approval policies "+
+				"applying to it, including those inherited from its groups. The default branch is checked, against the rules covering it that check detected licenses; "+
+				"packages a rule excludes from a license are exempt from it. Licenses are matched by name or SPDX identifier. Requires GitLab Ultimate."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "List License Violations",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			WithOutput(licenseViolationsSchema),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectID, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, licenseViolationsSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			action := fmt.Sprintf("failed to check the licenses of project %q", projectID)
+			notFound := fmt.Sprintf("project %q not found or access denied", projectID)
+			fullPath, resp, err := projectFullPath(ctx, glClient, projectID)
+			if err != nil {
+				return apiErrorResult(err, resp, action, notFound), nil
+			}
+			var data struct {
+				Project *struct {
+					Repository *struct {
+						RootRef string `json:"rootRef"`
+					} `json:"repository"`
+					ApprovalPolicies graphQLConnection[*approvalPolicy] `json:"approvalPolicies"`
+				} `json:"project"`
+			}
+			resp, err = doGraphQL(ctx, glClient, approvalPoliciesQuery, map[string]any{"fullPath": fullPath}, &data)
+			if err != nil {
+				return apiErrorResult(err, resp, action, notFound), nil
+			}
+			if data.Project == nil {
+				return mcp.NewToolResultError(notFound), nil
+			}
+			var rules []licenseRule
+			for _, policy := range data.Project.ApprovalPolicies.Nodes {
+				policyRules, err := policy.licenseRules(fullPath)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("%s: %v", action, err)), nil
+				}
+				rules = append(rules, policyRules...)
+			}
+
+			// --- Keep the rules covering the default branch, whose dependencies are listed
+			if data.Project.Repository != nil && data.Project.Repository.RootRef != "" {
+				defaultBranch := data.Project.Repository.RootRef
+				protected := false
+				if slices.ContainsFunc(rules, licenseRule.protectedOnly) {
+					branch, resp, err := glClient.Branches.GetBranch(projectID, defaultBranch, gl.WithContext(ctx))
+					if err != nil {
+						return apiErrorResult(err, resp, action, notFound), nil
+					}
+					protected = branch.Protected
+				}
+				rules = slices.DeleteFunc(rules, func(r licenseRule) bool { return !r.appliesTo(defaultBranch, protected) })
+			}
+			result := &licenseViolations{Violations: []*licenseViolation{}, PoliciesEvaluated: countPolicies(rules)}
+			if len(rules) > 0 {
+				dependencies, _, resp, err := fetchDependencies(ctx, glClient, projectID, nil, ListParams{Page: 1, PerPage: MaxPerPage, MaxItems: MaxItemsLimit})
+				if err != nil {
+					return apiErrorResult(err, resp, action, notFound), nil
+				}
+				result.DependenciesEvaluated = len(dependencies)
+				for _, d := range dependencies {
+					for _, rule := range rules {
+						for _, license := range rule.violations(d) {
+							result.Violations = append(result.Violations, &licenseViolation{
+								Policy:             rule.policy,
+								License:            license,
+								Name:               d.Name,
+								Version:            d.Version,
+								PackageManager:     string(d.PackageManager),
+								DependencyFilePath: d.DependencyFilePath,
+							})
+						}
+					}
+				}
+			}
+
+			// --- Marshal and return success
+			return newProjectedResult(result, output, "license violation data")
+		}
+}
+
+// withDependencyFilterParams adds the parameters filtering the dependencies of a project.
+func withDependencyFilterParams() mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		mcp.WithString("package",
+			mcp.Description("Case-insensitive part of the names of the packages to include (e.g., 'log4j-core')."),
+		)(t)
+		mcp.WithString("version",
+			mcp.Description("Comma-separated version constraints the packages must all satisfy, each an operator among <, <=, >, >=, = and != followed by a version "+
+				"(e.g., '<2.17' or '>=2.0,<2.17.1'). Versions compare by their dotted numbers; pre-release suffixes sort before the release."),
+		)(t)
+		mcp.WithString("packageManager",
+			mcp.Description(fmt.Sprintf("Comma-separated package managers to include (%s).", strings.Join(dependencyPackageManagers, ", "))),
+		)(t)
+	}
+}
+
+// optionalDependencyFilterParams parses the parameters added by withDependencyFilterParams. The package
+// managers, nil when not filtered, are applied by GitLab.
+func optionalDependencyFilterParams(r *mcp.CallToolRequest) (dependencyFilter, []*gl.DependencyPackageManagerValue, error) {
+	pkg, err := OptionalParam[string](r, "package")
+	if err != nil {
+		return dependencyFilter{}, nil, err
+	}
+	version, err := OptionalParam[string](r, "version")
+	if err != nil {
+		return dependencyFilter{}, nil, err
+	}
+	constraints, err := parseVersionConstraints(version)
+	if err != nil {
+		return dependencyFilter{}, nil, fmt.Errorf("invalid 'version' parameter: %w", err)
+	}
+	managers, err := optionalEnumListParam(r, "packageManager", dependencyPackageManagers)
+	if err != nil {
+		return dependencyFilter{}, nil, err
+	}
+	var packageManagers []*gl.DependencyPackageManagerValue
+	for _, m := range managers {
+		packageManagers = append(packageManagers, gl.Ptr(gl.DependencyPackageManagerValue(strings.ToLower(m))))
+	}
+	return dependencyFilter{pkg: strings.ToLower(strings.TrimSpace(pkg)), constraints: constraints}, packageManagers, nil
+}
+
+// fetchDependencies returns the dependencies of the project, of the package managers when not nil.
+func fetchDependencies(ctx context.Context, glClient *gl.Client, projectID any, packageManagers []*gl.DependencyPackageManagerValue, listParams ListParams) ([]*gl.Dependency, *Pagination, *gl.Response, error) {
+	opts := &gl.ListProjectDependenciesOptions{
+		ListOptions: gl.ListOptions{
+			PerPage: listParams.PerPage,
+		},
+		PackageManager: packageManagers,
+	}
+	return collectPages(listParams, func(page int) ([]*gl.Dependency, *gl.Response, error) {
+		opts.Page = page
+		return glClient.Dependencies.ListProjectDependencies(projectID, opts, gl.WithContext(ctx))
+	})
+}
+
+// forEachConcurrently calls fn with each index below n, from at most workers goroutines at a time.
+func forEachConcurrently(n, workers int, fn func(i int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(n, workers) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := range n {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// dependencyFilter selects dependencies by package name and version.
+type dependencyFilter struct {
+	// pkg is a lower-case part of the package name, empty to match any.
+	pkg         string
+	constraints []versionConstraint
+}
+
+func (f dependencyFilter) empty() bool {
+	return f.pkg == "" && len(f.constraints) == 0
+}
+
+// apply returns the dependencies matching the filter, attributed to project when not empty.
+func (f dependencyFilter) apply(dependencies []*gl.Dependency, project string) []*dependency {
+	matched := []*dependency{}
+	for _, d := range dependencies {
+		if f.pkg != "" && !strings.Contains(strings.ToLower(d.Name), f.pkg) {
+			continue
+		}
+		if !slices.ContainsFunc(f.constraints, func(c versionConstraint) bool { return !c.satisfiedBy(d.Version) }) {
+			matched = append(matched, &dependency{
+				Project:              project,
+				Name:                 d.Name,
+				Version:              d.Version,
+				PackageManager:       string(d.PackageManager),
+				DependencyFilePath:   d.DependencyFilePath,
+				VulnerabilitiesCount: len(d.Vulnerabilities),
+				Vulnerabilities:      d.Vulnerabilities,
+				Licenses:             d.Licenses,
+			})
+		}
+	}
+	return matched
+}
+
+// versionConstraint is a comparison a version must satisfy, such as "<2.17".
+type versionConstraint struct {
+	op      string
+	version string
+}
+
+// versionOperators are the operators of version constraints, longest first so "<=" is not read as "<".
+var versionOperators = []string{"<=", ">=", "!=", "==", "<", ">", "="}
+
+// parseVersionConstraints parses comma-separated version constraints; a bare version means "=".
+func parseVersionConstraints(spec string) ([]versionConstraint, error) {
+	var constraints []versionConstraint
+	for _, item := range splitCommaList(spec) {
+		c := versionConstraint{op: "=", version: item}
+		for _, op := range versionOperators {
+			if rest, ok := strings.CutPrefix(item, op); ok {
+				c.op, c.version = op, strings.TrimSpace(rest)
+				break
+			}
+		}
+		if c.op == "==" {
+			c.op = "="
+		}
+		if c.version == "" {
+			return nil, fmt.Errorf("constraint %q has no version", item)
+		}
+		constraints = append(constraints, c)
+	}
+	return constraints, nil
+}
+
+func (c versionConstraint) satisfiedBy(version string) bool {
+	cmp := compareVersions(version, c.version)
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "!=":
+		return cmp != 0
+	default:
+		return cmp == 0
+	}
+}
+
+// compareVersions compares two package versions, returning -1, 0 or 1. Versions are split into
+// numeric and alphabetic parts (e.g., "2.0-beta9" into 2, 0, beta, 9): numbers compare as numbers,
+// missing trailing numbers as zeros, and a part starting with a letter sorts before any number or
+// the end of the version, so pre-releases precede their release.
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(strings.TrimPrefix(a, "v")), versionParts(strings.TrimPrefix(b, "v"))
+	for i := range max(len(pa), len(pb)) {
+		var x, y string
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if cmp := compareVersionParts(x, y); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+// compareVersionParts compares parts of versions, an empty part standing for a missing one.
+func compareVersionParts(x, y string) int {
+	nx, errX := strconv.Atoi(x)
+	ny, errY := strconv.Atoi(y)
+	switch {
+	case (errX == nil || x == "") && (errY == nil || y == ""):
+		return compareInts(nx, ny) // Missing parts are zeros
+	case errX == nil || x == "":
+		return 1 // y is a pre-release suffix
+	case errY == nil || y == "":
+		return -1
+	default:
+		return strings.Compare(strings.ToLower(x), strings.ToLower(y))
+	}
+}
+
+func compareInts(x, y int) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// versionPartPattern matches the numeric and alphabetic parts of a version.
+var versionPartPattern = regexp.MustCompile(`[0-9]+|[^0-9\W_]+`)
+
+// versionParts splits a version into its numeric and alphabetic parts, dropping separators.
+func versionParts(version string) []string {
+	return versionPartPattern.FindAllString(version, -1)
+}
+
+const approvalPoliciesQuery = `
+query($fullPath: ID!) {
+  project(fullPath: $fullPath) {
+    repository { rootRef }
+    approvalPolicies(relationship: INHERITED) {
+      nodes { name enabled yaml }
+    }
+  }
+}`
+
+// approvalPolicy is a merge request approval policy, defined in YAML in a security policy project.
+type approvalPolicy struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	YAML    string `json:"yaml"`
+}
+
+// licenseRule is a license rule of an approval policy: either licenses are denied, or only
+// allowed licenses may be used.
+type licenseRule struct {
+	policy  string
+	denied  []policyLicense
+	allowed []policyLicense
+	// allowList is set when only the allowed licenses may be used, even if none is listed.
+	allowList bool
+	// branches, branchType and branchExceptions scope the rule to target branches, as in the policy.
+	branches         []string
+	branchType       string
+	branchExceptions []string
+}
+
+// policyLicense is a license named by a license rule, with the packages the rule exempts from it.
+type policyLicense struct {
+	name string
+	// excluding are package URLs (e.g., "pkg:npm/lodash" or "pkg:npm/lodash@4.17.21").
+	excluding []string
+}
+
+// branchException is an entry of 'branch_exceptions': a branch name, or a branch of the project full_path.
+type branchException struct {
+	Name     string `yaml:"name"`
+	FullPath string `yaml:"full_path"`
+}
+
+func (e *branchException) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&e.Name)
+	}
+	type plain branchException
+	return node.Decode((*plain)(e))
+}
+
+// licenseRules returns the license rules of the policy that check the licenses already
+// detected in project fullPath, none if it is disabled. Rules covering only newly detected
+// licenses are left out, as they apply to the changes of merge requests.
+func (p *approvalPolicy) licenseRules(fullPath string) ([]licenseRule, error) {
+	if !p.Enabled {
+		return nil, nil
+	}
+	type licenseEntry struct {
+		Name     string `yaml:"name"`
+		Packages *struct {
+			Excluding struct {
+				Purls []string `yaml:"purls"`
+			} `yaml:"excluding"`
+		} `yaml:"packages"`
+	}
+	var def struct {
+		Rules []struct {
+			Type string `yaml:"type"`
+			// Older policies list license_types, denied or, when match_on_inclusion_license
+			// is false, the only ones allowed
+			MatchOnInclusionLicense *bool    `yaml:"match_on_inclusion_license"`
+			LicenseTypes            []string `yaml:"license_types"`
+			Licenses                *struct {
+				Allowed []licenseEntry `yaml:"allowed"`
+				Denied  []licenseEntry `yaml:"denied"`
+			} `yaml:"licenses"`
+			LicenseStates    []string          `yaml:"license_states"`
+			Branches         []string          `yaml:"branches"`
+			BranchType       string            `yaml:"branch_type"`
+			BranchExceptions []branchException `yaml:"branch_exceptions"`
+		} `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal([]byte(p.YAML), &def); err != nil {
+		return nil, fmt.Errorf("invalid definition of policy %q: %w", p.Name, err)
+	}
+	toPolicyLicenses := func(entries []licenseEntry) []policyLicense {
+		licenses := make([]policyLicense, 0, len(entries))
+		for _, e := range entries {
+			l := policyLicense{name: e.Name}
+			if e.Packages != nil {
+				l.excluding = e.Packages.Excluding.Purls
+			}
+			licenses = append(licenses, l)
+		}
+		return licenses
+	}
+	toNames := func(names []string) []policyLicense {
+		licenses := make([]policyLicense, 0, len(names))
+		for _, name := range names {
+			licenses = append(licenses, policyLicense{name: name})
+		}
+		return licenses
+	}
+	var rules []licenseRule
+	for _, r := range def.Rules {
+		if r.Type != "license_finding" {
+			continue
+		}
+		if len(r.LicenseStates) > 0 && !slices.Contains(r.LicenseStates, "detected") {
+			continue
+		}
+		rule := licenseRule{policy: p.Name, branches: r.Branches, branchType: r.BranchType}
+		for _, e := range r.BranchExceptions {
+			if e.FullPath == "" || e.FullPath == fullPath {
+				rule.branchExceptions = append(rule.branchExceptions, e.Name)
+			}
+		}
+		switch {
+		case r.Licenses != nil && r.Licenses.Allowed != nil:
+			rule.allowList, rule.allowed = true, toPolicyLicenses(r.Licenses.Allowed)
+		case r.Licenses != nil:
+			rule.denied = toPolicyLicenses(r.Licenses.Denied)
+		case r.MatchOnInclusionLicense != nil && !*r.MatchOnInclusionLicense:
+			rule.allowList, rule.allowed = true, toNames(r.LicenseTypes)
+		default:
+			rule.denied = toNames(r.LicenseTypes)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// protectedOnly reports whether the rule covers protected branches alone, which is the case
+// when it names neither branches nor a branch type other than protected.
+func (r licenseRule) protectedOnly() bool {
+	return len(r.branches) == 0 && r.branchType != "default" && r.branchType != "all"
+}
+
+// appliesTo reports whether the rule covers the branch, protected telling whether it is protected.
+func (r licenseRule) appliesTo(branch string, protected bool) bool {
+	matches := func(pattern string) bool { return protectedBranchPattern(pattern, branch) }
+	switch {
+	case slices.ContainsFunc(r.branchExceptions, matches):
+		return false
+	case len(r.branches) > 0:
+		return slices.ContainsFunc(r.branches, matches)
+	case r.protectedOnly():
+		return protected
+	}
+	return true
+}
+
+// violations returns the licenses of d the rule does not allow. Dependencies without a
+// license are reported as "unknown" by allow lists not listing it.
+func (r licenseRule) violations(d *gl.Dependency) []string {
+	licenses := d.Licenses
+	if len(licenses) == 0 {
+		licenses = []*gl.DependencyLicense{{Name: "unknown"}}
+	}
+	var violated []string
+	for _, l := range licenses {
+		// A license named for d, unless d is among the packages excluded from it
+		covers := func(pl policyLicense) bool {
+			return licenseMatches(l, pl.name) && !slices.ContainsFunc(pl.excluding, func(purl string) bool { return packageURLMatches(purl, d) })
+		}
+		if r.allowList && !slices.ContainsFunc(r.allowed, covers) || slices.ContainsFunc(r.denied, covers) {
+			violated = append(violated, l.Name)
+		}
+	}
+	return violated
+}
+
+// purlTypes maps the package managers of the dependency list to package URL types.
+var purlTypes = map[string]string{
+	"bundler": "gem", "composer": "composer", "conan": "conan", "go": "golang", "gradle": "maven", "maven": "maven", "sbt": "maven",
+	"npm": "npm", "pnpm": "npm", "yarn": "npm", "nuget": "nuget", "pip": "pypi", "pipenv": "pypi", "setuptools": "pypi",
+}
+
+// packageURLMatches reports whether purl, with or without a version, identifies the package of d.
+func packageURLMatches(purl string, d *gl.Dependency) bool {
+	purlType, ok := purlTypes[string(d.PackageManager)]
+	if !ok {
+		return false
+	}
+	if unescaped, err := url.PathUnescape(purl); err == nil {
+		purl = unescaped
+	}
+	base := "pkg:" + purlType + "/" + d.Name
+	return strings.EqualFold(purl, base) || strings.EqualFold(purl, base+"@"+d.Version)
+}
+
+// licenseMatches reports whether the license of a dependency is the one a policy names, by
+// name or by the SPDX identifier ending its URL (e.g., "https://spdx.org/licenses/MIT.html").
+func licenseMatches(l *gl.DependencyLicense, name string) bool {
+	if strings.EqualFold(l.Name, name) {
+		return true
+	}
+	u, err := url.Parse(l.URL)
+	if err != nil || u.Host != "spdx.org" {
+		return false
+	}
+	return strings.EqualFold(strings.TrimSuffix(path.Base(u.Path), ".html"), name)
+}
+
+// countPolicies returns the number of policies the rules come from.
+func countPolicies(rules []licenseRule) int {
+	policies := map[string]bool{}
+	for _, r := range rules {
+		policies[r.policy] = true
+	}
+	return len(policies)
+}
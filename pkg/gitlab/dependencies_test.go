@@ -0,0 +1,193 @@
+package gitlab
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+const apiDependenciesJSON = `[
+	{"name": "org.apache.logging.log4j/log4j-core", "version": "2.14.1", "package_manager": "gradle", "dependency_file_path": "build.gradle",
+	 "vulnerabilities": [{"name": "CVE-2021-44228", "severity": "critical", "id": 7, "url": "https://gitlab.example.com/platform/api/-/security/vulnerabilities/7"}],
+	 "licenses": [{"name": "Apache-2.0", "url": "https://spdx.org/licenses/Apache-2.0.html"}]},
+	{"name": "org.apache.logging.log4j/log4j-api", "version": "2.17.1", "package_manager": "gradle", "dependency_file_path": "build.gradle",
+	 "vulnerabilities": [], "licenses": [{"name": "Apache-2.0", "url": "https://spdx.org/licenses/Apache-2.0.html"}]},
+	{"name": "readline", "version": "8.1", "package_manager": "gradle", "dependency_file_path": "build.gradle",
+	 "vulnerabilities": [], "licenses": [{"name": "GNU General Public License v3.0 only", "url": "https://spdx.org/licenses/GPL-3.0-only.html"}]},
+	{"name": "internal-utils", "version": "1.0", "package_manager": "gradle", "dependency_file_path": "build.gradle",
+	 "vulnerabilities": [], "licenses": []}
+]`
+
+func TestListDependenciesHandler(t *testing.T) {
+	ctx := context.Background()
+	getClient := newGraphQLServer(t, map[string]string{
+		"/api/v4/projects/platform%2Fapi/dependencies": apiDependenciesJSON,
+	}, nil)
+
+	listDependenciesTool, listDependenciesHandler := ListDependencies(getClient)
+
+	tests := []struct {
+		name              string
+		inputArgs         map[string]any
+		expectedItems     string
+		expectResultError bool
+		errorContains     string
+	}{
+		{
+			name:      "Success - Vulnerable Versions",
+			inputArgs: map[string]any{"projectId": "platform/api", "package": "LOG4J", "version": "<2.17", "fields": "name,version,vulnerabilities_count,licenses.name"},
+			expectedItems: `[{"name": "org.apache.logging.log4j/log4j-core", "version": "2.14.1", "vulnerabilities_count": 1,
+				"licenses": [{"name": "Apache-2.0"}]}]`,
+		},
+		{
+			name:          "Success - Version Range",
+			inputArgs:     map[string]any{"projectId": "platform/api", "version": ">=2.15, <3", "fields": "name"},
+			expectedItems: `[{"name": "org.apache.logging.log4j/log4j-api"}]`,
+		},
+		{
+			name:              "Error - Invalid Package Manager",
+			inputArgs:         map[string]any{"projectId": "platform/api", "packageManager": "cargo"},
+			expectResultError: true,
+			errorContains:     "Validation Error: parameter 'packageManager' values must be among",
+		},
+		{
+			name:              "Error - Project Not Found (404)",
+			inputArgs:         map[string]any{"projectId": "platform/unknown"},
+			expectResultError: true,
+			errorContains:     `project "platform/unknown" not found or access denied (404)`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := listDependenciesHandler(ctx, createCallToolRequest(listDependenciesTool.Name, tc.inputArgs))
+			require.NoError(t, err)
+			if tc.expectResultError {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.errorContains)
+				return
+			}
+			items, _ := getListResult(t, result)
+			assert.JSONEq(t, tc.expectedItems, items)
+		})
+	}
+}
+
+func TestListGroupDependenciesHandler(t *testing.T) {
+	ctx := context.Background()
+	getClient := newGraphQLServer(t, map[string]string{
+		"/api/v4/groups/platform/projects": `[
+			{"id": 11, "path_with_namespace": "platform/api"},
+			{"id": 12, "path_with_namespace": "platform/web"},
+			{"id": 13, "path_with_namespace": "platform/legacy"}
+		]`,
+		"/api/v4/projects/11/dependencies": apiDependenciesJSON,
+		"/api/v4/projects/12/dependencies": `[{"name": "org.apache.logging.log4j/log4j-core", "version": "2.17.0", "package_manager": "maven", "dependency_file_path": "pom.xml"}]`,
+	}, nil)
+
+	listGroupDependenciesTool, listGroupDependenciesHandler := ListGroupDependencies(getClient)
+
+	result, err := listGroupDependenciesHandler(ctx, createCallToolRequest(listGroupDependenciesTool.Name, map[string]any{
+		"groupId": "platform", "package": "log4j-core", "version": "<2.17",
+	}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"dependencies": [{"project": "platform/api", "name": "org.apache.logging.log4j/log4j-core", "version": "2.14.1", "package_manager": "gradle",
+			"dependency_file_path": "build.gradle", "vulnerabilities_count": 1, "licenses": [{"name": "Apache-2.0"}]}],
+		"projects_scanned": 3,
+		"projects_failed": [{"project": "platform/legacy", "error": "dependency list not found or access denied (404): 404 Not Found"}],
+		"truncated": false
+	}`, getTextResult(t, result).Text)
+
+	result, err = listGroupDependenciesHandler(ctx, createCallToolRequest(listGroupDependenciesTool.Name, map[string]any{"groupId": "platform"}))
+	require.NoError(t, err)
+	assert.Contains(t, getTextResult(t, result).Text, "Validation Error", "A filter is required")
+
+	result, err = listGroupDependenciesHandler(ctx, createCallToolRequest(listGroupDependenciesTool.Name, map[string]any{"groupId": "platform", "packageManager": "gradle"}))
+	require.NoError(t, err)
+	assert.Contains(t, getTextResult(t, result).Text, "Validation Error: at least one of 'package' or 'version' is required", "A package manager alone is too broad")
+}
+
+func TestListLicenseViolationsHandler(t *testing.T) {
+	ctx := context.Background()
+	getClient := newGraphQLServer(t, map[string]string{
+		"/api/v4/projects/platform%2Fapi/dependencies":             apiDependenciesJSON,
+		"/api/v4/projects/platform%2Fapi/repository/branches/main": `{"name": "main", "protected": true, "default": true}`,
+	}, func(req graphQLRequest) string {
+		if req.Variables["fullPath"] != "platform/api" {
+			return `{"data": {"project": null}}`
+		}
+		return `{"data": {"project": {"repository": {"rootRef": "main"}, "approvalPolicies": {"nodes": [
+			{"name": "No copyleft", "enabled": true, "yaml": "name: No copyleft\nenabled: true\nrules:\n- type: license_finding\n  branches: []\n  licenses:\n    denied:\n    - name: GPL-3.0-only\n"},
+			{"name": "Permissive only", "enabled": true, "yaml": "name: Permissive only\nenabled: true\nrules:\n- type: scan_finding\n  scanners: [sast]\n- type: license_finding\n  match_on_inclusion_license: false\n  license_types: [Apache-2.0, MIT]\n"},
+			{"name": "Draft", "enabled": false, "yaml": "rules:\n- type: license_finding\n  match_on_inclusion_license: true\n  license_types: [Apache-2.0]\n"},
+			{"name": "Copyleft but readline", "enabled": true, "yaml": "rules:\n- type: license_finding\n  branch_type: default\n  licenses:\n    denied:\n    - name: GPL-3.0-only\n      packages:\n        excluding:\n          purls: [pkg:maven/readline]\n"},
+			{"name": "Apache but log4j-api", "enabled": true, "yaml": "rules:\n- type: license_finding\n  branch_type: protected\n  licenses:\n    allowed:\n    - name: Apache-2.0\n      packages:\n        excluding:\n          purls: ['pkg:maven/org.apache.logging.log4j/log4j-api@2.17.1']\n    - name: GPL-3.0-only\n    - name: unknown\n"},
+			{"name": "New licenses", "enabled": true, "yaml": "rules:\n- type: license_finding\n  license_states: [newly_detected]\n  license_types: [Apache-2.0]\n"},
+			{"name": "Release branches", "enabled": true, "yaml": "rules:\n- type: license_finding\n  branches: [release/*]\n  license_types: [Apache-2.0]\n"},
+			{"name": "Not on main", "enabled": true, "yaml": "rules:\n- type: license_finding\n  branch_type: all\n  branch_exceptions:\n  - name: main\n    full_path: platform/api\n  - other\n  license_types: [Apache-2.0]\n"}
+		]}}}}`
+	})
+
+	listLicenseViolationsTool, listLicenseViolationsHandler := ListLicenseViolations(getClient)
+
+	result, err := listLicenseViolationsHandler(ctx, createCallToolRequest(listLicenseViolationsTool.Name, map[string]any{"projectId": "platform/api", "fields": "violations.policy,violations.license,violations.name,policies_evaluated"}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"violations": [
+			{"policy": "Apache but log4j-api", "license": "Apache-2.0", "name": "org.apache.logging.log4j/log4j-api"},
+			{"policy": "No copyleft", "license": "GNU General Public License v3.0 only", "name": "readline"},
+			{"policy": "Permissive only", "license": "GNU General Public License v3.0 only", "name": "readline"},
+			{"policy": "Permissive only", "license": "unknown", "name": "internal-utils"}
+		],
+		"policies_evaluated": 4
+	}`, getTextResult(t, result).Text)
+
+	result, err = listLicenseViolationsHandler(ctx, createCallToolRequest(listLicenseViolationsTool.Name, map[string]any{"projectId": "platform/unknown"}))
+	require.NoError(t, err)
+	assert.Equal(t, `project "platform/unknown" not found or access denied`, getTextResult(t, result).Text)
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"2.14.1", "2.17", -1},
+		{"2.17.0", "2.17", 0},
+		{"2.17.1", "2.17", 1},
+		{"v1.10.0", "1.9.3", 1},
+		{"2.0-beta9", "2.0", -1},
+		{"2.0-beta9", "2.0-rc1", -1},
+		{"2.0.1", "2.0-rc1", 1},
+		{"1.0.0.RELEASE", "1.0.0", -1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.a+" vs "+tc.b, func(t *testing.T) {
+			assert.Equal(t, tc.expected, compareVersions(tc.a, tc.b))
+			assert.Equal(t, -tc.expected, compareVersions(tc.b, tc.a))
+		})
+	}
+}
+
+func TestParseVersionConstraints(t *testing.T) {
+	constraints, err := parseVersionConstraints(">= 2.0, <2.17,==2.16.0, !=2.15, 1.0")
+	require.NoError(t, err)
+	assert.Equal(t, []versionConstraint{{">=", "2.0"}, {"<", "2.17"}, {"=", "2.16.0"}, {"!=", "2.15"}, {"=", "1.0"}}, constraints)
+
+	_, err = parseVersionConstraints("<=")
+	assert.ErrorContains(t, err, `constraint "<=" has no version`)
+}
+
+func TestLicenseMatches(t *testing.T) {
+	license := &gl.DependencyLicense{Name: "MIT License", URL: "https://spdx.org/licenses/MIT.html"}
+	assert.True(t, licenseMatches(license, "mit license"))
+	assert.True(t, licenseMatches(license, "MIT"))
+	assert.False(t, licenseMatches(license, "MIT-0"))
+	assert.False(t, licenseMatches(&gl.DependencyLicense{Name: "Custom", URL: "https://example.com/MIT.html"}, "MIT"), "Only SPDX URLs carry identifiers")
+}
@@ -0,0 +1,149 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+// apiErrorResult maps a failed GitLab API call to a tool error result, so clients see an
+// actionable message instead of an opaque protocol error. action describes the failed
+// operation and prefixes the message (e.g., `failed to list issues from project "g/p"`);
+// notFound replaces it for 404 responses, which GitLab also returns for resources hidden
+// from the token's user.
+func apiErrorResult(err error, resp *gl.Response, action, notFound string) *mcp.CallToolResult {
+	return mcp.NewToolResultError(apiErrorMessage(err, resp, action, notFound, time.Now()))
+}
+
+// apiErrorMessage builds the message returned by apiErrorResult, including GitLab's own
+// error message when the response carried one.
+func apiErrorMessage(err error, resp *gl.Response, action, notFound string, now time.Time) string {
+	code := statusCode(resp)
+	detail := errorDetail(err)
+
+	var msg string
+	switch {
+	case code == 0:
+		msg = fmt.Sprintf("%s: could not reach GitLab", action)
+	case code < http.StatusMultipleChoices:
+		// GraphQL reports errors, among them unknown or hidden resources, in successful responses
+		msg = action
+	case code == http.StatusNotFound:
+		msg = fmt.Sprintf("%s (%d)", notFound, code)
+	case code == http.StatusUnauthorized:
+		msg = fmt.Sprintf("%s: authentication failed (%d); the token is invalid, expired or revoked", action, code)
+	case code == http.StatusForbidden:
+		if scope := missingScope(err); scope != "" {
+			msg = fmt.Sprintf("%s: token lacks `%s` scope (%d)", action, scope, code)
+		} else {
+			msg = fmt.Sprintf("%s: permission denied (%d); the token's user lacks the required role or the resource is protected", action, code)
+		}
+	case code == http.StatusConflict:
+		msg = fmt.Sprintf("%s: conflict (%d); the resource already exists or was changed concurrently", action, code)
+	case code == http.StatusBadRequest, code == http.StatusUnprocessableEntity:
+		msg = fmt.Sprintf("%s: GitLab rejected the request (%d)", action, code)
+	case code == http.StatusTooManyRequests:
+		if wait := retryAfter(resp, now); wait > 0 {
+			msg = fmt.Sprintf("%s: rate limited (%d), retry after %s", action, code, wait)
+		} else {
+			msg = fmt.Sprintf("%s: rate limited (%d), retry later", action, code)
+		}
+	case code >= http.StatusInternalServerError:
+		msg = fmt.Sprintf("%s: GitLab server error (%d), retry later", action, code)
+	default:
+		msg = fmt.Sprintf("%s: unexpected response (%d)", action, code)
+	}
+	if detail != "" {
+		msg += ": " + detail
+	}
+	return msg
+}
+
+// statusCode returns the HTTP status of resp, 0 when the request got no response.
+func statusCode(resp *gl.Response) int {
+	if resp == nil || resp.Response == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+// errorDetail returns the most specific message GitLab gave for err: the "message",
+// "error_description" or "error" member of the response body, or the error text otherwise.
+func errorDetail(err error) string {
+	if err == nil {
+		return ""
+	}
+	var errResp *gl.ErrorResponse
+	if !errors.As(err, &errResp) {
+		return err.Error()
+	}
+	var body map[string]any
+	if json.Unmarshal(errResp.Body, &body) == nil {
+		for _, key := range []string{"message", "error_description", "error"} {
+			switch v := body[key].(type) {
+			case string:
+				return v
+			case nil:
+				continue
+			default:
+				data, _ := json.Marshal(v) // Decoded from JSON: cannot fail
+				return string(data)
+			}
+		}
+	}
+	return errResp.Message
+}
+
+// missingScope returns the token scope GitLab reported as required in an
+// "insufficient_scope" error, or "" for other errors.
+func missingScope(err error) string {
+	var errResp *gl.ErrorResponse
+	if !errors.As(err, &errResp) {
+		return ""
+	}
+	var body struct {
+		Error string `json:"error"`
+		Scope string `json:"scope"`
+	}
+	if json.Unmarshal(errResp.Body, &body) != nil || body.Error != "insufficient_scope" {
+		return ""
+	}
+	if body.Scope == "" {
+		return "api"
+	}
+	return body.Scope
+}
+
+// retryAfter returns how long GitLab asked clients to wait before retrying resp, 0 when unknown.
+func retryAfter(resp *gl.Response, now time.Time) time.Duration {
+	if resp == nil || resp.Response == nil {
+		return 0
+	}
+	return retryAfterHeader(resp.Header, now)
+}
+
+// retryAfterHeader returns the wait given by the Retry-After header (seconds or HTTP date)
+// or the RateLimit-Reset header (Unix time), 0 when neither is set.
+func retryAfterHeader(header http.Header, now time.Time) time.Duration {
+	if value := strings.TrimSpace(header.Get("Retry-After")); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return max(time.Duration(seconds)*time.Second, 0)
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return max(at.Sub(now).Round(time.Second), 0)
+		}
+	}
+	if value := strings.TrimSpace(header.Get("RateLimit-Reset")); value != "" {
+		if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return max(time.Unix(unix, 0).Sub(now).Round(time.Second), 0)
+		}
+	}
+	return 0
+}
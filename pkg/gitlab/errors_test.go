@@ -0,0 +1,159 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+// gitlabErrorResponse builds the error and response the GitLab client returns for a failed
+// request answered with the given status, body and headers.
+func gitlabErrorResponse(t *testing.T, code int, body string, header http.Header) (error, *gl.Response) {
+	t.Helper()
+	if header == nil {
+		header = http.Header{}
+	}
+	httpResp := &http.Response{
+		StatusCode: code,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Scheme: "https", Host: "gitlab.com", Path: "/api/v4/projects/1"}},
+	}
+	err := gl.CheckResponse(httpResp)
+	require.Error(t, err)
+	return err, &gl.Response{Response: httpResp}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	const action = `failed to get project "g/p"`
+	const notFound = `project "g/p" not found or access denied`
+
+	tests := []struct {
+		name     string
+		code     int
+		body     string
+		header   http.Header
+		expected string
+	}{
+		{
+			name:     "Unauthorized",
+			code:     http.StatusUnauthorized,
+			body:     `{"message":"401 Unauthorized"}`,
+			expected: action + ": authentication failed (401); the token is invalid, expired or revoked: 401 Unauthorized",
+		},
+		{
+			name:     "Insufficient Scope",
+			code:     http.StatusForbidden,
+			body:     `{"error":"insufficient_scope","error_description":"The request requires higher privileges than provided by the access token.","scope":"api"}`,
+			expected: action + ": token lacks `api` scope (403): The request requires higher privileges than provided by the access token.",
+		},
+		{
+			name:     "Forbidden",
+			code:     http.StatusForbidden,
+			body:     `{"message":"403 Forbidden"}`,
+			expected: action + ": permission denied (403); the token's user lacks the required role or the resource is protected: 403 Forbidden",
+		},
+		{
+			name:     "Not Found",
+			code:     http.StatusNotFound,
+			expected: notFound + " (404): 404 Not Found",
+		},
+		{
+			name:     "Conflict",
+			code:     http.StatusConflict,
+			body:     `{"message":"Release already exists"}`,
+			expected: action + ": conflict (409); the resource already exists or was changed concurrently: Release already exists",
+		},
+		{
+			name:     "Unprocessable With Field Errors",
+			code:     http.StatusUnprocessableEntity,
+			body:     `{"message":{"name":["has already been taken"]}}`,
+			expected: action + `: GitLab rejected the request (422): {"name":["has already been taken"]}`,
+		},
+		{
+			name:     "Rate Limited With Retry-After",
+			code:     http.StatusTooManyRequests,
+			body:     `Retry later`,
+			header:   http.Header{"Retry-After": []string{"12"}},
+			expected: action + ": rate limited (429), retry after 12s: failed to parse unknown error format: Retry later",
+		},
+		{
+			name:     "Rate Limited With RateLimit-Reset",
+			code:     http.StatusTooManyRequests,
+			header:   http.Header{"Ratelimit-Reset": []string{"1735732830"}},
+			expected: action + ": rate limited (429), retry after 30s",
+		},
+		{
+			name:     "Server Error",
+			code:     http.StatusBadGateway,
+			body:     `{"message":"502 Bad Gateway"}`,
+			expected: action + ": GitLab server error (502), retry later: 502 Bad Gateway",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err, resp := gitlabErrorResponse(t, tc.code, tc.body, tc.header)
+			assert.Equal(t, tc.expected, apiErrorMessage(err, resp, action, notFound, now))
+		})
+	}
+
+	t.Run("No Response", func(t *testing.T) {
+		err := errors.New("dial tcp: connection refused")
+		assert.Equal(t, action+": could not reach GitLab: dial tcp: connection refused", apiErrorMessage(err, nil, action, notFound, now))
+	})
+
+	t.Run("GraphQL Errors", func(t *testing.T) {
+		err := graphQLErrors{"The resource that you are attempting to access does not exist or you don't have permission to perform this action"}
+		resp := &gl.Response{Response: &http.Response{StatusCode: http.StatusOK}}
+		assert.Equal(t, action+": The resource that you are attempting to access does not exist or you don't have permission to perform this action",
+			apiErrorMessage(err, resp, action, notFound, now))
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	respWith := func(header http.Header) *gl.Response {
+		return &gl.Response{Response: &http.Response{Header: header}}
+	}
+
+	assert.Equal(t, 12*time.Second, retryAfter(respWith(http.Header{"Retry-After": []string{"12"}}), now))
+	assert.Equal(t, 90*time.Second, retryAfter(respWith(http.Header{"Retry-After": []string{now.Add(90 * time.Second).Format(http.TimeFormat)}}), now))
+	assert.Equal(t, 30*time.Second, retryAfter(respWith(http.Header{"Ratelimit-Reset": []string{"1735732830"}}), now))
+	assert.Zero(t, retryAfter(respWith(http.Header{"Ratelimit-Reset": []string{"1735732000"}}), now), "A reset in the past means no wait")
+	assert.Zero(t, retryAfter(respWith(http.Header{}), now))
+	assert.Zero(t, retryAfter(nil, now))
+}
+
+func TestGetProjectHandlerAPIErrors(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockProjects, ctrl := setupMockClient(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	getProjectTool, getProjectHandler := GetProject(mockGetClient)
+
+	err, resp := gitlabErrorResponse(t, http.StatusForbidden, `{"error":"insufficient_scope","scope":"read_api"}`, nil)
+	mockProjects.EXPECT().
+		GetProject("group/project", gomock.Any(), gomock.Any()).
+		Return(nil, resp, err)
+
+	result, handlerErr := getProjectHandler(ctx, createCallToolRequest(getProjectTool.Name, map[string]any{"projectId": "group/project"}))
+	require.NoError(t, handlerErr, "API errors should be reported as tool errors")
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "token lacks `read_api` scope (403)")
+}
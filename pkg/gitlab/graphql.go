@@ -0,0 +1,141 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	gl "gitlab.com/gitlab-org/api/client-go" // GitLab client library
+)
+
+// graphQLRequest is the body of a GraphQL API request. Queries are sent with client-go's
+// REST request machinery, as its GraphQL service cannot pass variables.
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+// graphQLErrors are the error messages GitLab reports in a GraphQL response, or in the
+// 'errors' field of a mutation payload, while answering with a success status.
+type graphQLErrors []string
+
+func (e graphQLErrors) Error() string {
+	return strings.Join(e, "; ")
+}
+
+// graphQLConnection is a page of a GraphQL connection.
+type graphQLConnection[T any] struct {
+	Nodes    []T `json:"nodes"`
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+}
+
+// globalID is the numeric ID of a GraphQL global ID (e.g., 42 for "gid://gitlab/Vulnerability/42"),
+// the ID the REST API knows the resource by.
+type globalID int
+
+func (id *globalID) UnmarshalJSON(data []byte) error {
+	var gid string
+	if err := json.Unmarshal(data, &gid); err != nil {
+		return err
+	}
+	n, err := strconv.Atoi(gid[strings.LastIndex(gid, "/")+1:])
+	if err != nil {
+		return fmt.Errorf("invalid global ID %q", gid)
+	}
+	*id = globalID(n)
+	return nil
+}
+
+// toGlobalID returns the GraphQL global ID of the resource of type model with the numeric id.
+func toGlobalID(model string, id int) string {
+	return fmt.Sprintf("gid://gitlab/%s/%d", model, id)
+}
+
+// WithCursorPagination is the WithPagination counterpart of tools listing GraphQL connections,
+// which are walked with the 'cursor' returned by a previous call rather than page numbers.
+func WithCursorPagination() mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		WithPagination()(t)
+		delete(t.InputSchema.Properties, "page")
+		WithKeysetPagination()(t)
+	}
+}
+
+// doGraphQL sends query with variables to GitLab's GraphQL API and decodes the 'data' member of
+// the response into data. Errors reported in a successful response are returned as graphQLErrors.
+func doGraphQL(ctx context.Context, glClient *gl.Client, query string, variables map[string]any, data any) (*gl.Response, error) {
+	if !strings.HasPrefix(strings.TrimSpace(query), "mutation") {
+		// Queries are POSTed too, but must not drop cached responses as writes do
+		ctx = withReadOnlyRequest(ctx)
+	}
+	req, err := glClient.NewRequest(http.MethodPost, "", graphQLRequest{Query: query, Variables: variables}, []gl.RequestOptionFunc{gl.WithContext(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	// The endpoint sits beside the REST API rather than under it, also on instances served
+	// from a relative URL
+	req.URL.Path = strings.TrimSuffix(glClient.BaseURL().Path, "/api/v4/") + gl.GraphQLAPIEndpoint
+
+	var body struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	resp, err := glClient.Do(req, &body)
+	if err != nil {
+		return resp, err
+	}
+	if len(body.Errors) > 0 {
+		errs := make(graphQLErrors, len(body.Errors))
+		for i, e := range body.Errors {
+			errs[i] = e.Message
+		}
+		return resp, errs
+	}
+	if err := json.Unmarshal(body.Data, data); err != nil {
+		return resp, fmt.Errorf("failed to decode GraphQL response: %w", err)
+	}
+	return resp, nil
+}
+
+// collectConnection walks a GraphQL connection like collectKeysetPages walks keyset pages, the
+// end cursor of each page standing for the link to the next. fetch receives the cursor to
+// continue after, empty for the first page, and the number of nodes to request, and returns
+// a nil connection when its parent resource does not exist.
+func collectConnection[T any](params ListParams, fetch func(after string, first int) (*graphQLConnection[T], *gl.Response, error)) ([]T, *Pagination, *gl.Response, error) {
+	return collectKeysetPages(params, func(link string, perPage int) ([]T, *gl.Response, error) {
+		conn, resp, err := fetch(link, perPage)
+		if err != nil || conn == nil {
+			return nil, resp, err
+		}
+		if conn.PageInfo.HasNextPage {
+			resp.NextLink = conn.PageInfo.EndCursor
+		}
+		return conn.Nodes, resp, nil
+	})
+}
+
+// projectFullPath returns the full path the GraphQL API identifies the project projectID by,
+// looking it up through the REST API when projectID is numeric.
+func projectFullPath(ctx context.Context, glClient *gl.Client, projectID string) (string, *gl.Response, error) {
+	if _, err := strconv.Atoi(projectID); err != nil {
+		if path, err := url.PathUnescape(projectID); err == nil {
+			return path, nil, nil
+		}
+		return projectID, nil, nil
+	}
+	project, resp, err := glClient.Projects.GetProject(projectID, &gl.GetProjectOptions{}, gl.WithContext(ctx))
+	if err != nil {
+		return "", resp, err
+	}
+	return project.PathWithNamespace, resp, nil
+}
@@ -0,0 +1,474 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	gl "gitlab.com/gitlab-org/api/client-go" // GitLab client library
+)
+
+// GetGroup defines the MCP tool for retrieving details of a specific group.
+func GetGroup(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getGroup",
+			mcp.WithDescription("Retrieves details of a specific group (namespace), such as its full path, visibility and parent group. Use listGroupProjects for its projects."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get Group",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("groupId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the group."),
+			),
+			WithOutput(groupDetailSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			groupID, err := requiredParam[string](&request, "groupId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, groupDetailSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API, without the deprecated embedded project list
+			withProjects := false
+			group, resp, err := glClient.Groups.GetGroup(groupID, &gl.GetGroupOptions{WithProjects: &withProjects}, gl.WithContext(ctx))
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get group %q", groupID),
+					fmt.Sprintf("group %q not found or access denied", groupID),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newProjectedResult(group, output, "group data")
+		}
+}
+
+// ListGroups defines the MCP tool for listing the groups visible to the current user.
+func ListGroups(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"listGroups",
+			mcp.WithDescription("Retrieves a list of groups visible to the current user, including subgroups unless 'topLevelOnly' is set."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "List Groups",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("search", mcp.Description("Return groups whose name or path matches the search criteria.")),
+			mcp.WithBoolean("owned", mcp.Description("Limit by groups explicitly owned by the current user.")),
+			mcp.WithBoolean("topLevelOnly", mcp.Description("Limit to top-level groups, excluding all subgroups.")),
+			mcp.WithBoolean("allAvailable", mcp.Description("Include all groups the current user can see, not only those they are a member of. Default for non-admin users: true.")),
+			mcp.WithString("orderBy",
+				mcp.Description("Return groups ordered by field. Default: 'name'."),
+				mcp.Enum("name", "path", "id", "similarity"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Return groups sorted in asc or desc order. Default: 'asc'."),
+				mcp.Enum("asc", "desc"),
+			),
+			// Add standard MCP pagination parameters
+			WithPagination(),
+			WithListOutput(groupSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			search, err := OptionalParam[string](&request, "search")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			owned, err := OptionalBoolParam(&request, "owned")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			topLevelOnly, err := OptionalBoolParam(&request, "topLevelOnly")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			allAvailable, err := OptionalBoolParam(&request, "allAvailable")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			orderBy, err := OptionalParam[string](&request, "orderBy")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			sort, err := OptionalParam[string](&request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, groupSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			opts := &gl.ListGroupsOptions{
+				ListOptions: gl.ListOptions{
+					PerPage: listParams.PerPage,
+				},
+				Owned:        owned,
+				TopLevelOnly: topLevelOnly,
+				AllAvailable: allAvailable,
+			}
+			if search != "" {
+				opts.Search = &search
+			}
+			if orderBy != "" {
+				opts.OrderBy = &orderBy
+			}
+			if sort != "" {
+				opts.Sort = &sort
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			groups, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.Group, *gl.Response, error) {
+				opts.Page = page
+				return glClient.Groups.ListGroups(opts, gl.WithContext(ctx))
+			})
+
+			// --- Handle API errors
+			if err != nil {
+				// No resource to report for 404s, as an empty list is a valid result
+				return apiErrorResult(err, resp, "failed to list groups", "failed to list groups"), nil
+			}
+
+			// --- Marshal and return success
+			return newListResult(groups, pagination, output, "group list data")
+		}
+}
+
+// ListSubgroups defines the MCP tool for listing the subgroups of a group.
+func ListSubgroups(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"listSubgroups",
+			mcp.WithDescription("Retrieves the direct subgroups of a group, or all its descendant groups with 'allDescendants'."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "List Subgroups",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("groupId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the parent group."),
+			),
+			mcp.WithBoolean("allDescendants", mcp.Description("Return the subgroups at every depth below the group instead of only its direct subgroups.")),
+			mcp.WithString("search", mcp.Description("Return subgroups whose name or path matches the search criteria.")),
+			mcp.WithString("orderBy",
+				mcp.Description("Return subgroups ordered by field. Default: 'name'."),
+				mcp.Enum("name", "path", "id", "similarity"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Return subgroups sorted in asc or desc order. Default: 'asc'."),
+				mcp.Enum("asc", "desc"),
+			),
+			// Add standard MCP pagination parameters
+			WithPagination(),
+			WithListOutput(groupSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			groupID, err := requiredParam[string](&request, "groupId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			allDescendants, err := OptionalBoolParam(&request, "allDescendants")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			search, err := OptionalParam[string](&request, "search")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			orderBy, err := OptionalParam[string](&request, "orderBy")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			sort, err := OptionalParam[string](&request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, groupSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options, shared by both endpoints
+			opts := gl.ListGroupsOptions{
+				ListOptions: gl.ListOptions{
+					PerPage: listParams.PerPage,
+				},
+			}
+			if search != "" {
+				opts.Search = &search
+			}
+			if orderBy != "" {
+				opts.OrderBy = &orderBy
+			}
+			if sort != "" {
+				opts.Sort = &sort
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			groups, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.Group, *gl.Response, error) {
+				opts.Page = page
+				if allDescendants != nil && *allDescendants {
+					descendantOpts := gl.ListDescendantGroupsOptions(opts)
+					return glClient.Groups.ListDescendantGroups(groupID, &descendantOpts, gl.WithContext(ctx))
+				}
+				subgroupOpts := gl.ListSubGroupsOptions(opts)
+				return glClient.Groups.ListSubGroups(groupID, &subgroupOpts, gl.WithContext(ctx))
+			})
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list subgroups of group %q", groupID),
+					fmt.Sprintf("group %q not found or access denied", groupID),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newListResult(groups, pagination, output, "subgroup list data")
+		}
+}
+
+// ListGroupProjects defines the MCP tool for listing the projects of a group.
+func ListGroupProjects(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"listGroupProjects",
+			mcp.WithDescription("Retrieves the projects of a group, optionally including those of its subgroups, to answer questions spanning an organization."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "List Group Projects",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("groupId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the group."),
+			),
+			mcp.WithBoolean("includeSubgroups", mcp.Description("Include the projects of all subgroups of the group.")),
+			mcp.WithBoolean("archived", mcp.Description("Limit by archived status: true returns only archived projects, false only active ones. Default: both.")),
+			mcp.WithBoolean("withShared", mcp.Description("Include projects shared with the group. Default: true.")),
+			mcp.WithString("search", mcp.Description("Return projects matching the search criteria.")),
+			mcp.WithString("visibility",
+				mcp.Description("Limit by visibility level."),
+				mcp.Enum("public", "internal", "private"),
+			),
+			mcp.WithString("orderBy",
+				mcp.Description("Return projects ordered by field. Default: 'created_at'."),
+				mcp.Enum("id", "name", "path", "created_at", "updated_at", "last_activity_at", "similarity"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Return projects sorted in asc or desc order. Default: 'desc'."),
+				mcp.Enum("asc", "desc"),
+			),
+			// Add standard MCP pagination parameters
+			WithPagination(),
+			WithListOutput(projectSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			groupID, err := requiredParam[string](&request, "groupId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			includeSubgroups, err := OptionalBoolParam(&request, "includeSubgroups")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			archived, err := OptionalBoolParam(&request, "archived")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			withShared, err := OptionalBoolParam(&request, "withShared")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			search, err := OptionalParam[string](&request, "search")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			visibility, err := OptionalParam[string](&request, "visibility")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			orderBy, err := OptionalParam[string](&request, "orderBy")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			sort, err := OptionalParam[string](&request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, projectSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			opts := &gl.ListGroupProjectsOptions{
+				ListOptions: gl.ListOptions{
+					PerPage: listParams.PerPage,
+				},
+				IncludeSubGroups: includeSubgroups,
+				Archived:         archived,
+				WithShared:       withShared,
+			}
+			if search != "" {
+				opts.Search = &search
+			}
+			if visibility != "" {
+				vis := gl.VisibilityValue(visibility)
+				opts.Visibility = &vis
+			}
+			if orderBy != "" {
+				opts.OrderBy = &orderBy
+			}
+			if sort != "" {
+				opts.Sort = &sort
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			projects, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.Project, *gl.Response, error) {
+				opts.Page = page
+				return glClient.Groups.ListGroupProjects(groupID, opts, gl.WithContext(ctx))
+			})
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list projects of group %q", groupID),
+					fmt.Sprintf("group %q not found or access denied", groupID),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newListResult(projects, pagination, output, "group project list data")
+		}
+}
+
+// ListGroupMembers defines the MCP tool for listing the members of a group.
+func ListGroupMembers(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"listGroupMembers",
+			mcp.WithDescription("Retrieves the members of a group with their access level (10 guest, 20 reporter, 30 developer, 40 maintainer, 50 owner), optionally including members inherited from ancestor groups."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "List Group Members",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("groupId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the group."),
+			),
+			mcp.WithBoolean("includeInherited", mcp.Description("Include the members inherited from ancestor groups and invited through shared groups.")),
+			mcp.WithString("query", mcp.Description("Return members whose name, email or username matches the query.")),
+			// Add standard MCP pagination parameters
+			WithPagination(),
+			WithListOutput(groupMemberSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			groupID, err := requiredParam[string](&request, "groupId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			includeInherited, err := OptionalBoolParam(&request, "includeInherited")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			query, err := OptionalParam[string](&request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, groupMemberSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			opts := &gl.ListGroupMembersOptions{
+				ListOptions: gl.ListOptions{
+					PerPage: listParams.PerPage,
+				},
+			}
+			if query != "" {
+				opts.Query = &query
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API: /members/all includes inherited members
+			members, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.GroupMember, *gl.Response, error) {
+				opts.Page = page
+				if includeInherited != nil && *includeInherited {
+					return glClient.Groups.ListAllGroupMembers(groupID, opts, gl.WithContext(ctx))
+				}
+				return glClient.Groups.ListGroupMembers(groupID, opts, gl.WithContext(ctx))
+			})
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list members of group %q", groupID),
+					fmt.Sprintf("group %q not found or access denied", groupID),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newListResult(members, pagination, output, "group member list data")
+		}
+}
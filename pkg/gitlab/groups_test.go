@@ -0,0 +1,228 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestGetGroupHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockGroups, ctrl := setupMockClientForGroups(t)
+	defer ctrl.Finish()
+
+	getGroupTool, getGroupHandler := GetGroup(func(_ context.Context) (*gl.Client, error) { return mockClient, nil })
+	groupID := "platform/backend"
+	group := &gl.Group{ID: 7, Name: "backend", FullPath: groupID, ParentID: 3, Visibility: gl.PrivateVisibility, RunnersToken: "secret"}
+
+	tests := []struct {
+		name              string
+		mockSetup         func()
+		expectResultError bool
+		errorContains     string
+	}{
+		{
+			name: "Success",
+			mockSetup: func() {
+				mockGroups.EXPECT().
+					GetGroup(groupID, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, opts *gl.GetGroupOptions, _ ...gl.RequestOptionFunc) (*gl.Group, *gl.Response, error) {
+						assert.False(t, *opts.WithProjects, "The deprecated project list is not requested")
+						return group, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil
+					})
+			},
+		},
+		{
+			name: "Error - Group Not Found (404)",
+			mockSetup: func() {
+				mockGroups.EXPECT().
+					GetGroup(groupID, gomock.Any(), gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Not Found"))
+			},
+			expectResultError: true,
+			errorContains:     `group "platform/backend" not found or access denied`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			result, err := getGroupHandler(ctx, createCallToolRequest(getGroupTool.Name, map[string]any{"groupId": groupID}))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectResultError {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.errorContains)
+				return
+			}
+			assert.JSONEq(t, projectedJSON(t, group, groupDetailSchema.fields), textContent.Text)
+			assert.NotContains(t, textContent.Text, "secret", "The runners token is not returned by default")
+		})
+	}
+}
+
+func TestListGroupsHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockGroups, ctrl := setupMockClientForGroups(t)
+	defer ctrl.Finish()
+
+	listGroupsTool, listGroupsHandler := ListGroups(func(_ context.Context) (*gl.Client, error) { return mockClient, nil })
+	groups := []*gl.Group{{ID: 3, Name: "platform", FullPath: "platform"}}
+
+	mockGroups.EXPECT().
+		ListGroups(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(opts *gl.ListGroupsOptions, _ ...gl.RequestOptionFunc) ([]*gl.Group, *gl.Response, error) {
+			assert.Equal(t, "plat", *opts.Search)
+			assert.True(t, *opts.TopLevelOnly)
+			assert.Nil(t, opts.Owned, "Omitted filters are not sent")
+			assert.Equal(t, "path", *opts.OrderBy)
+			return groups, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil
+		})
+
+	result, err := listGroupsHandler(ctx, createCallToolRequest(listGroupsTool.Name, map[string]any{"search": "plat", "topLevelOnly": true, "orderBy": "path"}))
+	require.NoError(t, err)
+	items, _ := getListResult(t, result)
+	assert.JSONEq(t, projectedJSON(t, groups, groupSchema.fields), items)
+}
+
+func TestListSubgroupsHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockGroups, ctrl := setupMockClientForGroups(t)
+	defer ctrl.Finish()
+
+	listSubgroupsTool, listSubgroupsHandler := ListSubgroups(func(_ context.Context) (*gl.Client, error) { return mockClient, nil })
+	groupID := "platform"
+	subgroups := []*gl.Group{{ID: 7, Name: "backend", FullPath: "platform/backend", ParentID: 3}}
+	okResp := &gl.Response{Response: &http.Response{StatusCode: 200}}
+
+	tests := []struct {
+		name              string
+		inputArgs         map[string]any
+		mockSetup         func()
+		expectResultError bool
+		errorContains     string
+	}{
+		{
+			name:      "Success - Direct Subgroups",
+			inputArgs: map[string]any{"groupId": groupID, "search": "back"},
+			mockSetup: func() {
+				mockGroups.EXPECT().
+					ListSubGroups(groupID, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, opts *gl.ListSubGroupsOptions, _ ...gl.RequestOptionFunc) ([]*gl.Group, *gl.Response, error) {
+						assert.Equal(t, "back", *opts.Search)
+						return subgroups, okResp, nil
+					})
+			},
+		},
+		{
+			name:      "Success - All Descendants",
+			inputArgs: map[string]any{"groupId": groupID, "allDescendants": true, "sort": "desc"},
+			mockSetup: func() {
+				mockGroups.EXPECT().
+					ListDescendantGroups(groupID, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, opts *gl.ListDescendantGroupsOptions, _ ...gl.RequestOptionFunc) ([]*gl.Group, *gl.Response, error) {
+						assert.Equal(t, "desc", *opts.Sort)
+						return subgroups, okResp, nil
+					})
+			},
+		},
+		{
+			name:      "Error - Group Not Found (404)",
+			inputArgs: map[string]any{"groupId": groupID},
+			mockSetup: func() {
+				mockGroups.EXPECT().
+					ListSubGroups(groupID, gomock.Any(), gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Not Found"))
+			},
+			expectResultError: true,
+			errorContains:     `group "platform" not found or access denied`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			result, err := listSubgroupsHandler(ctx, createCallToolRequest(listSubgroupsTool.Name, tc.inputArgs))
+			require.NoError(t, err)
+			if tc.expectResultError {
+				assert.Contains(t, getTextResult(t, result).Text, tc.errorContains)
+				return
+			}
+			items, _ := getListResult(t, result)
+			assert.JSONEq(t, projectedJSON(t, subgroups, groupSchema.fields), items)
+		})
+	}
+}
+
+func TestListGroupProjectsHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockGroups, ctrl := setupMockClientForGroups(t)
+	defer ctrl.Finish()
+
+	listGroupProjectsTool, listGroupProjectsHandler := ListGroupProjects(func(_ context.Context) (*gl.Client, error) { return mockClient, nil })
+	groupID := "platform"
+	projects := []*gl.Project{{ID: 11, Name: "api", PathWithNamespace: "platform/backend/api"}}
+
+	mockGroups.EXPECT().
+		ListGroupProjects(groupID, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, opts *gl.ListGroupProjectsOptions, _ ...gl.RequestOptionFunc) ([]*gl.Project, *gl.Response, error) {
+			assert.True(t, *opts.IncludeSubGroups)
+			assert.False(t, *opts.Archived, "archived=false is sent to exclude archived projects")
+			assert.Nil(t, opts.WithShared)
+			assert.Equal(t, gl.PrivateVisibility, *opts.Visibility)
+			assert.Equal(t, 50, opts.PerPage)
+			return projects, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil
+		})
+
+	result, err := listGroupProjectsHandler(ctx, createCallToolRequest(listGroupProjectsTool.Name, map[string]any{
+		"groupId": groupID, "includeSubgroups": true, "archived": false, "visibility": "private", "per_page": 50,
+	}))
+	require.NoError(t, err)
+	items, _ := getListResult(t, result)
+	assert.JSONEq(t, projectedJSON(t, projects, projectSchema.fields), items)
+}
+
+func TestListGroupMembersHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockGroups, ctrl := setupMockClientForGroups(t)
+	defer ctrl.Finish()
+
+	listGroupMembersTool, listGroupMembersHandler := ListGroupMembers(func(_ context.Context) (*gl.Client, error) { return mockClient, nil })
+	groupID := "platform/backend"
+	members := []*gl.GroupMember{{ID: 1, Username: "alice", Name: "Alice", State: "active", AccessLevel: gl.MaintainerPermissions}}
+	okResp := &gl.Response{Response: &http.Response{StatusCode: 200}}
+
+	t.Run("Direct Members", func(t *testing.T) {
+		mockGroups.EXPECT().
+			ListGroupMembers(groupID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, opts *gl.ListGroupMembersOptions, _ ...gl.RequestOptionFunc) ([]*gl.GroupMember, *gl.Response, error) {
+				assert.Equal(t, "ali", *opts.Query)
+				return members, okResp, nil
+			})
+
+		result, err := listGroupMembersHandler(ctx, createCallToolRequest(listGroupMembersTool.Name, map[string]any{"groupId": groupID, "query": "ali"}))
+		require.NoError(t, err)
+		items, _ := getListResult(t, result)
+		assert.JSONEq(t, projectedJSON(t, members, groupMemberSchema.fields), items)
+	})
+
+	t.Run("Including Inherited Members", func(t *testing.T) {
+		mockGroups.EXPECT().
+			ListAllGroupMembers(groupID, gomock.Any(), gomock.Any()).
+			Return(members, okResp, nil)
+
+		result, err := listGroupMembersHandler(ctx, createCallToolRequest(listGroupMembersTool.Name, map[string]any{"groupId": groupID, "includeInherited": true}))
+		require.NoError(t, err)
+		items, _ := getListResult(t, result)
+		assert.JSONEq(t, projectedJSON(t, members, groupMemberSchema.fields), items)
+	})
+}
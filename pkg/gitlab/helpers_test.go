@@ -1,9 +1,12 @@
 package gitlab
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/stretchr/testify/require"
 	gl "gitlab.com/gitlab-org/api/client-go"
 	mock_gitlab "gitlab.com/gitlab-org/api/client-go/testing"
@@ -22,6 +25,28 @@ func getTextResult(t *testing.T, result *mcp.CallToolResult) mcp.TextContent {
 	return textContent
 }
 
+// Helper to unwrap a list tool result, returning the items as raw JSON and the pagination metadata
+func getListResult(t *testing.T, result *mcp.CallToolResult) (string, Pagination) {
+	t.Helper()
+	var envelope struct {
+		Items      json.RawMessage `json:"items"`
+		Pagination *Pagination     `json:"pagination"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &envelope), "Failed to unmarshal list result envelope")
+	require.NotNil(t, envelope.Pagination, "List result is missing pagination metadata")
+	return string(envelope.Items), *envelope.Pagination
+}
+
+// Helper to marshal an expected value reduced to the given fields, as a tool returns it by default
+func projectedJSON(t *testing.T, v any, fields []string) string {
+	t.Helper()
+	projected, err := parseFields(fields).project(v)
+	require.NoError(t, err, "Failed to project expected value")
+	data, err := json.Marshal(projected)
+	require.NoError(t, err, "Failed to marshal projected expected value")
+	return string(data)
+}
+
 // Helper to create a mock GetClientFn for testing handlers
 func setupMockClient(t *testing.T) (*gl.Client, *mock_gitlab.MockProjectsServiceInterface, *gomock.Controller) {
 	ctrl := gomock.NewController(t)
@@ -131,3 +156,140 @@ func setupMockClientForNotes(t *testing.T) (*gl.Client, *mock_gitlab.MockNotesSe
 
 	return client, mockNotes, ctrl
 }
+
+// Helper to build a CallToolRequest for a tool with the given arguments
+func createCallToolRequest(name string, args map[string]any) mcp.CallToolRequest {
+	req := mcp.CallToolRequest{}
+	req.Params.Name = name
+	req.Params.Arguments = args
+	return req
+}
+
+// Helper to create a mock client with both the Branches and ProtectedBranches services,
+// used by branch write tools that consult protection rules before acting
+func setupMockClientForProtectedBranches(t *testing.T) (*gl.Client, *mock_gitlab.MockBranchesServiceInterface, *mock_gitlab.MockProtectedBranchesServiceInterface, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	mockBranches := mock_gitlab.NewMockBranchesServiceInterface(ctrl)
+	mockProtected := mock_gitlab.NewMockProtectedBranchesServiceInterface(ctrl)
+
+	client := &gl.Client{
+		Branches:          mockBranches,
+		ProtectedBranches: mockProtected,
+	}
+
+	return client, mockBranches, mockProtected, ctrl
+}
+
+// Helper to create a mock GetClientFn for testing handlers for the Tags service
+func setupMockClientForTags(t *testing.T) (*gl.Client, *mock_gitlab.MockTagsServiceInterface, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	mockTags := mock_gitlab.NewMockTagsServiceInterface(ctrl) // Mock for Tags
+
+	client := &gl.Client{
+		Tags: mockTags,
+	}
+
+	return client, mockTags, ctrl
+}
+
+// Helper to create a mock GetClientFn for testing handlers for the Groups service
+func setupMockClientForGroups(t *testing.T) (*gl.Client, *mock_gitlab.MockGroupsServiceInterface, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	mockGroups := mock_gitlab.NewMockGroupsServiceInterface(ctrl) // Mock for Groups, including group members
+
+	client := &gl.Client{
+		Groups: mockGroups,
+	}
+
+	return client, mockGroups, ctrl
+}
+
+// Helper to create a mock client with the Users and PersonalAccessTokens services
+func setupMockClientForUsers(t *testing.T) (*gl.Client, *mock_gitlab.MockUsersServiceInterface, *mock_gitlab.MockPersonalAccessTokensServiceInterface, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	mockUsers := mock_gitlab.NewMockUsersServiceInterface(ctrl)
+	mockTokens := mock_gitlab.NewMockPersonalAccessTokensServiceInterface(ctrl)
+
+	client := &gl.Client{
+		Users:                mockUsers,
+		PersonalAccessTokens: mockTokens,
+	}
+
+	return client, mockUsers, mockTokens, ctrl
+}
+
+// Helper to create a mock client with the Releases and ReleaseLinks services
+func setupMockClientForReleases(t *testing.T) (*gl.Client, *mock_gitlab.MockReleasesServiceInterface, *mock_gitlab.MockReleaseLinksServiceInterface, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	mockReleases := mock_gitlab.NewMockReleasesServiceInterface(ctrl)
+	mockLinks := mock_gitlab.NewMockReleaseLinksServiceInterface(ctrl)
+
+	client := &gl.Client{
+		Releases:     mockReleases,
+		ReleaseLinks: mockLinks,
+	}
+
+	return client, mockReleases, mockLinks, ctrl
+}
+
+// Helper to create a mock client with the Repositories and RepositoryFiles services,
+// used by tools that walk the tree and then fetch file contents
+func setupMockClientForTreeAndFiles(t *testing.T) (*gl.Client, *mock_gitlab.MockRepositoriesServiceInterface, *mock_gitlab.MockRepositoryFilesServiceInterface, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	mockRepos := mock_gitlab.NewMockRepositoriesServiceInterface(ctrl)
+	mockFiles := mock_gitlab.NewMockRepositoryFilesServiceInterface(ctrl)
+
+	client := &gl.Client{
+		Repositories:    mockRepos,
+		RepositoryFiles: mockFiles,
+	}
+
+	return client, mockRepos, mockFiles, ctrl
+}
+
+// Helper to call a tool through the MCP server, so that its middlewares apply
+func callTool(t *testing.T, s *server.MCPServer, name string, args map[string]any) mcp.CallToolResult {
+	t.Helper()
+	params, err := json.Marshal(map[string]any{"name": name, "arguments": args})
+	require.NoError(t, err)
+	response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":`+string(params)+`}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "Expected a JSON-RPC response, got %T", response)
+	result, ok := resp.Result.(mcp.CallToolResult)
+	require.True(t, ok, "Expected a tool result, got %T", resp.Result)
+	return result
+}
+
+// readResource reads the resource at uri through s, as a client would with resources/read. It
+// returns the contents of the resource, or the message of the JSON-RPC error of a failed read.
+func readResource(t *testing.T, s *server.MCPServer, uri string) ([]mcp.ResourceContents, string) {
+	t.Helper()
+	params, err := json.Marshal(map[string]any{"uri": uri})
+	require.NoError(t, err)
+	response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/read","params":`+string(params)+`}`))
+	if rpcErr, ok := response.(mcp.JSONRPCError); ok {
+		return nil, rpcErr.Error.Message
+	}
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "Expected a JSON-RPC response, got %T", response)
+	result, ok := resp.Result.(mcp.ReadResourceResult)
+	require.True(t, ok, "Expected a resource result, got %T", resp.Result)
+	return result.Contents, ""
+}
+
+// getPrompt gets the prompt name with args through s, as a client would with prompts/get. It
+// returns the prompt, or the message of the JSON-RPC error of a failed request.
+func getPrompt(t *testing.T, s *server.MCPServer, name string, args map[string]string) (mcp.GetPromptResult, string) {
+	t.Helper()
+	params, err := json.Marshal(map[string]any{"name": name, "arguments": args})
+	require.NoError(t, err)
+	response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"prompts/get","params":`+string(params)+`}`))
+	if rpcErr, ok := response.(mcp.JSONRPCError); ok {
+		return mcp.GetPromptResult{}, rpcErr.Error.Message
+	}
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "Expected a JSON-RPC response, got %T", response)
+	result, ok := resp.Result.(mcp.GetPromptResult)
+	require.True(t, ok, "Expected a prompt result, got %T", resp.Result)
+	return result, ""
+}
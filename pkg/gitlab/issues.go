@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -32,8 +31,9 @@ func GetIssue(getClient GetClientFn) (mcp.Tool, server.ToolHandlerFunc) { // Sim
 			),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        "Get GitLab Issue", // Add title
-				ReadOnlyHint: true,
+				ReadOnlyHint: mcp.ToBoolPtr(true),
 			}),
+			WithOutput(issueDetailSchema),
 		),
 
 		// Handler signature matches projects.go: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
@@ -51,6 +51,10 @@ func GetIssue(getClient GetClientFn) (mcp.Tool, server.ToolHandlerFunc) { // Sim
 				// Return user-facing error directly
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
+			output, err := optionalOutputParams(ctx, &req, issueDetailSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
 
 			// Use WithNumber in tool definition, expect float64 here, then convert
 			issueIidFloat, err := requiredParam[float64](&req, "issueIid")
@@ -68,27 +72,14 @@ func GetIssue(getClient GetClientFn) (mcp.Tool, server.ToolHandlerFunc) { // Sim
 
 			// Handle Errors (pattern from projects.go)
 			if err != nil {
-				code := http.StatusInternalServerError
-				if resp != nil {
-					code = resp.StatusCode
-				}
-				if code == http.StatusNotFound {
-					msg := fmt.Sprintf("issue %d not found in project %q or access denied (%d)", issueIid, projectID, code)
-					// Return user-facing error directly
-					return mcp.NewToolResultError(msg), nil
-				}
-				// Return internal error using fmt.Errorf
-				return nil, fmt.Errorf("failed to get issue %d from project %q: %w (status code: %d)", issueIid, projectID, err, code)
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get issue %d from project %q", issueIid, projectID),
+					fmt.Sprintf("issue %d not found in project %q or access denied", issueIid, projectID),
+				), nil
 			}
 
 			// Format Success Response (pattern from projects.go)
-			jsonData, err := json.Marshal(issue)
-			if err != nil {
-				// Return internal error using fmt.Errorf
-				return nil, fmt.Errorf("failed to marshal issue data: %w", err)
-			}
-			// Use NewToolResultText
-			return mcp.NewToolResultText(string(jsonData)), nil
+			return newProjectedResult(issue, output, "issue data")
 		}
 }
 
@@ -101,7 +92,7 @@ func ListIssues(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandle
 			mcp.WithDescription("Retrieves a list of issues in a GitLab project with pagination and filtering."),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        "List GitLab Issues",
-				ReadOnlyHint: true,
+				ReadOnlyHint: mcp.ToBoolPtr(true),
 			}),
 			// Required parameters
 			mcp.WithString("projectId",
@@ -154,6 +145,7 @@ func ListIssues(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandle
 			),
 			// Add standard MCP pagination parameters
 			WithPagination(),
+			WithListOutput(issueSchema),
 		),
 		// Handler function implementation
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -238,7 +230,11 @@ func ListIssues(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandle
 			}
 
 			// --- Parse pagination parameters
-			page, perPage, err := OptionalPaginationParams(&request)
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, issueSchema)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
@@ -246,8 +242,7 @@ func ListIssues(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandle
 			// --- Construct GitLab API options
 			opts := &gl.ListProjectIssuesOptions{
 				ListOptions: gl.ListOptions{
-					Page:    page,
-					PerPage: perPage,
+					PerPage: listParams.PerPage,
 				},
 			}
 
@@ -302,32 +297,21 @@ func ListIssues(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandle
 			opts.UpdatedBefore = updatedBefore
 
 			// --- Call GitLab API
-			issues, resp, err := client.Issues.ListProjectIssues(projectID, opts, gl.WithContext(ctx))
+			issues, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.Issue, *gl.Response, error) {
+				opts.Page = page
+				return client.Issues.ListProjectIssues(projectID, opts, gl.WithContext(ctx))
+			})
 
 			// --- Handle API errors
 			if err != nil {
-				code := http.StatusInternalServerError
-				if resp != nil {
-					code = resp.StatusCode
-				}
-				if code == http.StatusNotFound {
-					return mcp.NewToolResultError(fmt.Sprintf("project %q not found or access denied (%d)", projectID, code)), nil
-				}
-				// For other errors, return internal error
-				return nil, fmt.Errorf("failed to list issues from project %q: %w (status: %d)", projectID, err, code)
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list issues from project %q", projectID),
+					fmt.Sprintf("project %q not found or access denied", projectID),
+				), nil
 			}
 
-			// --- Handle empty result gracefully
-			if len(issues) == 0 {
-				return mcp.NewToolResultText("[]"), nil // Return empty JSON array
-			}
-
-			// --- Format successful response
-			data, err := json.Marshal(issues)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal issues list: %w", err)
-			}
-			return mcp.NewToolResultText(string(data)), nil
+			// --- Marshal and return success
+			return newListResult(issues, pagination, output, "issues list")
 		}
 }
 
@@ -338,7 +322,7 @@ func GetIssueComments(getClient GetClientFn) (tool mcp.Tool, handler server.Tool
 			mcp.WithDescription("Retrieves comments or notes from a specific issue in a GitLab project."),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        "Get Issue Comments",
-				ReadOnlyHint: true,
+				ReadOnlyHint: mcp.ToBoolPtr(true),
 			}),
 			// Required parameters
 			mcp.WithString("projectId",
@@ -351,6 +335,7 @@ func GetIssueComments(getClient GetClientFn) (tool mcp.Tool, handler server.Tool
 			),
 			// Add standard MCP pagination parameters
 			WithPagination(),
+			WithListOutput(noteSchema),
 		),
 		// Handler function implementation
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -371,7 +356,11 @@ func GetIssueComments(getClient GetClientFn) (tool mcp.Tool, handler server.Tool
 			}
 
 			// Get pagination parameters
-			page, perPage, err := OptionalPaginationParams(&request)
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, noteSchema)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
@@ -385,38 +374,26 @@ func GetIssueComments(getClient GetClientFn) (tool mcp.Tool, handler server.Tool
 			// --- Construct GitLab API options
 			opts := &gl.ListIssueNotesOptions{
 				ListOptions: gl.ListOptions{
-					Page:    page,
-					PerPage: perPage,
+					PerPage: listParams.PerPage,
 				},
 			}
 
 			// --- Call GitLab API
-			notes, resp, err := glClient.Notes.ListIssueNotes(projectID, issueIid, opts, gl.WithContext(ctx))
+			notes, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.Note, *gl.Response, error) {
+				opts.Page = page
+				return glClient.Notes.ListIssueNotes(projectID, issueIid, opts, gl.WithContext(ctx))
+			})
 
 			// --- Handle API errors
 			if err != nil {
-				code := http.StatusInternalServerError
-				if resp != nil {
-					code = resp.StatusCode
-				}
-				if code == http.StatusNotFound {
-					msg := fmt.Sprintf("issue %d not found in project %q or access denied (%d)", issueIid, projectID, code)
-					return mcp.NewToolResultError(msg), nil
-				}
-				return nil, fmt.Errorf("failed to get comments for issue %d from project %q: %w (status: %d)", issueIid, projectID, err, code)
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get comments for issue %d from project %q", issueIid, projectID),
+					fmt.Sprintf("issue %d not found in project %q or access denied", issueIid, projectID),
+				), nil
 			}
 
 			// --- Marshal and return success
-			// Handle empty list gracefully
-			if len(notes) == 0 {
-				return mcp.NewToolResultText("[]"), nil // Return empty JSON array
-			}
-
-			data, err := json.Marshal(notes)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal issue comments data: %w", err)
-			}
-			return mcp.NewToolResultText(string(data)), nil
+			return newListResult(notes, pagination, output, "issue comments data")
 		}
 }
 
@@ -427,7 +404,7 @@ func GetIssueLabels(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHa
 			mcp.WithDescription("Retrieves the labels associated with a specific GitLab issue."),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        "Get Issue Labels",
-				ReadOnlyHint: true,
+				ReadOnlyHint: mcp.ToBoolPtr(true),
 			}),
 			// Required parameters
 			mcp.WithString("projectId",
@@ -469,15 +446,10 @@ func GetIssueLabels(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHa
 
 			// --- Handle API errors
 			if err != nil {
-				code := http.StatusInternalServerError
-				if resp != nil {
-					code = resp.StatusCode
-				}
-				if code == http.StatusNotFound {
-					msg := fmt.Sprintf("issue %d not found in project %q or access denied (%d)", issueIid, projectID, code)
-					return mcp.NewToolResultError(msg), nil
-				}
-				return nil, fmt.Errorf("failed to get labels for issue %d from project %q: %w (status: %d)", issueIid, projectID, err, code)
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get labels for issue %d from project %q", issueIid, projectID),
+					fmt.Sprintf("issue %d not found in project %q or access denied", issueIid, projectID),
+				), nil
 			}
 
 			// --- Extract and return labels
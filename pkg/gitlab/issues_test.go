@@ -119,10 +119,8 @@ func TestGetIssueHandler(t *testing.T) {
 					GetIssue("group/project", 2, gomock.Any(), gomock.Any()).
 					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
 			},
-			expectedResult:      nil,                                                                                       // No result content expected when handler errors
-			expectResultError:   true,                                                                                      // Result is nil due to handler error
-			expectInternalError: true,                                                                                      // Handler returns an actual error
-			errorContains:       "failed to get issue 2 from project \"group/project\": gitlab: 500 Internal Server Error", // Check internal error here
+			expectedResult:    "failed to get issue 2 from project \"group/project\": GitLab server error (500), retry later: gitlab: 500 Internal Server Error",
+			expectResultError: true,
 		},
 	}
 
@@ -135,13 +133,7 @@ func TestGetIssueHandler(t *testing.T) {
 		_, handler := GetIssue(errorGetClientFn)
 
 		request := mcp.CallToolRequest{
-			Params: struct {
-				Name      string                 `json:"name"`
-				Arguments map[string]interface{} `json:"arguments,omitempty"`
-				Meta      *struct {
-					ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-				} `json:"_meta,omitempty"`
-			}{
+			Params: mcp.CallToolParams{
 				Name:      getIssueTool.Name,
 				Arguments: map[string]any{"projectId": "any", "issueIid": 1.0},
 			},
@@ -169,13 +161,7 @@ func TestGetIssueHandler(t *testing.T) {
 
 			// Prepare request using correct structure
 			request := mcp.CallToolRequest{
-				Params: struct {
-					Name      string                 `json:"name"`
-					Arguments map[string]interface{} `json:"arguments,omitempty"`
-					Meta      *struct {
-						ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-					} `json:"_meta,omitempty"`
-				}{
+				Params: mcp.CallToolParams{
 					Name:      getIssueTool.Name, // Use the tool name from the definition
 					Arguments: args,
 				},
@@ -205,9 +191,7 @@ func TestGetIssueHandler(t *testing.T) {
 					// Successful result - compare JSON content
 					expectedIssue, ok := tc.expectedResult.(*gl.Issue)
 					require.True(t, ok, "Expected success result should be *gl.Issue")
-					expectedJSON, err := json.Marshal(expectedIssue)
-					require.NoError(t, err, "Failed to marshal expected success result")
-					assert.JSONEq(t, string(expectedJSON), textContent.Text, "Result JSON mismatch")
+					assert.JSONEq(t, projectedJSON(t, expectedIssue, issueDetailSchema.fields), textContent.Text, "Result JSON mismatch")
 				}
 			}
 		})
@@ -411,10 +395,8 @@ func TestListIssuesHandler(t *testing.T) {
 					ListProjectIssues("group/project", gomock.Any(), gomock.Any()).
 					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
 			},
-			expectedResult:      nil,
-			expectResultError:   false,
-			expectInternalError: true,
-			errorContains:       "failed to list issues from project \"group/project\": gitlab: 500 Internal Server Error",
+			expectedResult:    "failed to list issues from project \"group/project\": GitLab server error (500), retry later: gitlab: 500 Internal Server Error",
+			expectResultError: true,
 		},
 		{
 			name:                "Error - Missing projectId parameter",
@@ -446,13 +428,7 @@ func TestListIssuesHandler(t *testing.T) {
 		_, handler := ListIssues(errorGetClientFn)
 
 		request := mcp.CallToolRequest{
-			Params: struct {
-				Name      string                 `json:"name"`
-				Arguments map[string]interface{} `json:"arguments,omitempty"`
-				Meta      *struct {
-					ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-				} `json:"_meta,omitempty"`
-			}{
+			Params: mcp.CallToolParams{
 				Name:      listIssuesTool.Name,
 				Arguments: map[string]any{"projectId": "any"},
 			},
@@ -472,13 +448,7 @@ func TestListIssuesHandler(t *testing.T) {
 
 			// Prepare request using correct structure
 			request := mcp.CallToolRequest{
-				Params: struct {
-					Name      string                 `json:"name"`
-					Arguments map[string]interface{} `json:"arguments,omitempty"`
-					Meta      *struct {
-						ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-					} `json:"_meta,omitempty"`
-				}{
+				Params: mcp.CallToolParams{
 					Name:      listIssuesTool.Name, // Use the tool name from the definition
 					Arguments: tc.args,
 				},
@@ -505,16 +475,15 @@ func TestListIssuesHandler(t *testing.T) {
 					require.True(t, ok, "Expected user error result to be a string")
 					assert.Contains(t, textContent.Text, expectedErrString, "User error message mismatch")
 				} else {
+					items, _ := getListResult(t, result)
 					if expectedStr, ok := tc.expectedResult.(string); ok {
 						// Special case for empty array
-						assert.Equal(t, expectedStr, textContent.Text, "Result JSON mismatch")
+						assert.Equal(t, expectedStr, items, "Result JSON mismatch")
 					} else {
 						// Successful result - compare JSON content
 						expectedIssues, ok := tc.expectedResult.([]*gl.Issue)
 						require.True(t, ok, "Expected success result should be []*gl.Issue")
-						expectedJSON, err := json.Marshal(expectedIssues)
-						require.NoError(t, err, "Failed to marshal expected success result")
-						assert.JSONEq(t, string(expectedJSON), textContent.Text, "Result JSON mismatch")
+						assert.JSONEq(t, projectedJSON(t, expectedIssues, issueSchema.fields), items, "Result JSON mismatch")
 					}
 				}
 			}
@@ -522,6 +491,46 @@ func TestListIssuesHandler(t *testing.T) {
 	}
 }
 
+// TestListIssuesHandlerMaxItems tests that ListIssues walks pages up to the max_items cap
+func TestListIssuesHandlerMaxItems(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockIssues, ctrl := setupMockClientForIssues(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	listIssuesTool, handler := ListIssues(mockGetClient)
+
+	projectID := "group/project"
+	var requestedPages []int
+	mockIssues.EXPECT().
+		ListProjectIssues(projectID, gomock.AssignableToTypeOf(&gl.ListProjectIssuesOptions{}), gomock.Any()).
+		DoAndReturn(func(_ interface{}, opts *gl.ListProjectIssuesOptions, _ ...gl.RequestOptionFunc) ([]*gl.Issue, *gl.Response, error) {
+			requestedPages = append(requestedPages, opts.Page)
+			assert.Equal(t, 2, opts.PerPage)
+			issues := []*gl.Issue{{IID: opts.Page*2 - 1}, {IID: opts.Page * 2}}
+			resp := &gl.Response{Response: &http.Response{StatusCode: 200}, NextPage: opts.Page + 1, TotalItems: 10, TotalPages: 5}
+			return issues, resp, nil
+		}).Times(2)
+
+	req := createCallToolRequest(listIssuesTool.Name, map[string]any{"projectId": projectID, "max_items": 5, "per_page": 2})
+	result, err := handler(ctx, req)
+	require.NoError(t, err)
+
+	items, pagination := getListResult(t, result)
+	var actual []*gl.Issue
+	require.NoError(t, json.Unmarshal([]byte(items), &actual))
+	assert.Equal(t, []int{1, 2}, requestedPages, "a third page would not fit in the cap")
+	require.Len(t, actual, 4)
+	assert.Equal(t, 4, actual[3].IID)
+	assert.True(t, pagination.HasMore)
+	assert.False(t, pagination.Truncated)
+	assert.Equal(t, 3, pagination.NextPage)
+	require.NotNil(t, pagination.TotalItems)
+	assert.Equal(t, 10, *pagination.TotalItems)
+}
+
 // TestGetIssueCommentsHandler tests the GetIssueComments tool handler
 func TestGetIssueCommentsHandler(t *testing.T) {
 	ctx := context.Background()
@@ -703,10 +712,8 @@ func TestGetIssueCommentsHandler(t *testing.T) {
 					ListIssueNotes(projectID, int(issueIid), gomock.Any(), gomock.Any()).
 					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
 			},
-			expectedResult:      nil,
-			expectResultError:   false,
-			expectInternalError: true,
-			errorContains:       "failed to get comments for issue 1 from project \"group/project\"",
+			expectedResult:    "failed to get comments for issue 1 from project \"group/project\": GitLab server error (500)",
+			expectResultError: true,
 		},
 		{
 			name:                "Error - Missing projectId parameter",
@@ -743,13 +750,7 @@ func TestGetIssueCommentsHandler(t *testing.T) {
 		_, handler := GetIssueComments(errorGetClientFn)
 
 		request := mcp.CallToolRequest{
-			Params: struct {
-				Name      string                 `json:"name"`
-				Arguments map[string]interface{} `json:"arguments,omitempty"`
-				Meta      *struct {
-					ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-				} `json:"_meta,omitempty"`
-			}{
+			Params: mcp.CallToolParams{
 				Name:      getIssueCommentsTool.Name,
 				Arguments: map[string]any{"projectId": "any", "issueIid": 1.0},
 			},
@@ -769,13 +770,7 @@ func TestGetIssueCommentsHandler(t *testing.T) {
 
 			// Prepare request using correct structure
 			request := mcp.CallToolRequest{
-				Params: struct {
-					Name      string                 `json:"name"`
-					Arguments map[string]interface{} `json:"arguments,omitempty"`
-					Meta      *struct {
-						ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-					} `json:"_meta,omitempty"`
-				}{
+				Params: mcp.CallToolParams{
 					Name:      getIssueCommentsTool.Name,
 					Arguments: tc.args,
 				},
@@ -802,9 +797,10 @@ func TestGetIssueCommentsHandler(t *testing.T) {
 					require.True(t, ok, "Expected user error result to be a string")
 					assert.Contains(t, textContent.Text, expectedErrString, "User error message mismatch")
 				} else {
+					items, _ := getListResult(t, result)
 					if expectedStr, ok := tc.expectedResult.(string); ok {
 						// Special case for empty array
-						assert.Equal(t, expectedStr, textContent.Text, "Result JSON mismatch")
+						assert.Equal(t, expectedStr, items, "Result JSON mismatch")
 					} else {
 						// Successful result - compare JSON content
 						expectedNotes, ok := tc.expectedResult.([]*gl.Note)
@@ -816,7 +812,7 @@ func TestGetIssueCommentsHandler(t *testing.T) {
 
 						// Unmarshal the actual result
 						var actualNotes []*gl.Note
-						err := json.Unmarshal([]byte(textContent.Text), &actualNotes)
+						err := json.Unmarshal([]byte(items), &actualNotes)
 						require.NoError(t, err, "Failed to unmarshal actual result JSON")
 
 						// Compare lengths
@@ -970,10 +966,8 @@ func TestGetIssueLabelsHandler(t *testing.T) {
 					GetIssue(projectID, int(issueIid), gomock.Any(), gomock.Any()).
 					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
 			},
-			expectedResult:      nil,
-			expectResultError:   false,
-			expectInternalError: true,
-			errorContains:       "failed to get labels for issue 1 from project \"group/project\"",
+			expectedResult:    "failed to get labels for issue 1 from project \"group/project\": GitLab server error (500)",
+			expectResultError: true,
 		},
 		{
 			name:                "Error - Missing projectId parameter",
@@ -1010,13 +1004,7 @@ func TestGetIssueLabelsHandler(t *testing.T) {
 		_, handler := GetIssueLabels(errorGetClientFn)
 
 		request := mcp.CallToolRequest{
-			Params: struct {
-				Name      string                 `json:"name"`
-				Arguments map[string]interface{} `json:"arguments,omitempty"`
-				Meta      *struct {
-					ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-				} `json:"_meta,omitempty"`
-			}{
+			Params: mcp.CallToolParams{
 				Name:      getIssueLabelssTool.Name,
 				Arguments: map[string]any{"projectId": "any", "issueIid": 1.0},
 			},
@@ -1036,13 +1024,7 @@ func TestGetIssueLabelsHandler(t *testing.T) {
 
 			// Prepare request using correct structure
 			request := mcp.CallToolRequest{
-				Params: struct {
-					Name      string                 `json:"name"`
-					Arguments map[string]interface{} `json:"arguments,omitempty"`
-					Meta      *struct {
-						ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-					} `json:"_meta,omitempty"`
-				}{
+				Params: mcp.CallToolParams{
 					Name:      getIssueLabelssTool.Name,
 					Arguments: tc.args,
 				},
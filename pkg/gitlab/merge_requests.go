@@ -2,9 +2,7 @@ package gitlab
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -21,7 +19,7 @@ func GetMergeRequest(getClient GetClientFn) (tool mcp.Tool, handler server.ToolH
 			mcp.WithDescription("Retrieves details for a specific GitLab merge request."),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        "Get GitLab Merge Request",
-				ReadOnlyHint: true,
+				ReadOnlyHint: mcp.ToBoolPtr(true),
 			}),
 			mcp.WithString("projectId",
 				mcp.Required(),
@@ -31,6 +29,7 @@ func GetMergeRequest(getClient GetClientFn) (tool mcp.Tool, handler server.ToolH
 				mcp.Required(),
 				mcp.Description("The IID (internal ID, integer) of the merge request within the project."),
 			),
+			WithOutput(mergeRequestDetailSchema),
 		),
 		// Handler function implementation
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -39,6 +38,10 @@ func GetMergeRequest(getClient GetClientFn) (tool mcp.Tool, handler server.ToolH
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
+			output, err := optionalOutputParams(ctx, &request, mergeRequestDetailSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
 
 			mrIidFloat, err := requiredParam[float64](&request, "mergeRequestIid")
 			if err != nil {
@@ -61,23 +64,14 @@ func GetMergeRequest(getClient GetClientFn) (tool mcp.Tool, handler server.ToolH
 
 			// --- Handle API errors
 			if err != nil {
-				code := http.StatusInternalServerError
-				if resp != nil {
-					code = resp.StatusCode
-				}
-				if code == http.StatusNotFound {
-					msg := fmt.Sprintf("merge request %d not found in project %q or access denied (%d)", mrIid, projectID, code)
-					return mcp.NewToolResultError(msg), nil
-				}
-				return nil, fmt.Errorf("failed to get merge request %d from project %q: %w (status: %d)", mrIid, projectID, err, code)
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get merge request %d from project %q", mrIid, projectID),
+					fmt.Sprintf("merge request %d not found in project %q or access denied", mrIid, projectID),
+				), nil
 			}
 
 			// --- Marshal and return success
-			data, err := json.Marshal(mr)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal merge request data: %w", err)
-			}
-			return mcp.NewToolResultText(string(data)), nil
+			return newProjectedResult(mr, output, "merge request data")
 		}
 }
 
@@ -88,7 +82,7 @@ func GetMergeRequestComments(getClient GetClientFn) (tool mcp.Tool, handler serv
 			mcp.WithDescription("Retrieves comments or notes from a specific merge request in a GitLab project."),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        "Get Merge Request Comments",
-				ReadOnlyHint: true,
+				ReadOnlyHint: mcp.ToBoolPtr(true),
 			}),
 			// Required parameters
 			mcp.WithString("projectId",
@@ -101,6 +95,7 @@ func GetMergeRequestComments(getClient GetClientFn) (tool mcp.Tool, handler serv
 			),
 			// Add standard MCP pagination parameters
 			WithPagination(),
+			WithListOutput(noteSchema),
 		),
 		// Handler function implementation
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -121,7 +116,11 @@ func GetMergeRequestComments(getClient GetClientFn) (tool mcp.Tool, handler serv
 			}
 
 			// Get pagination parameters
-			page, perPage, err := OptionalPaginationParams(&request)
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, noteSchema)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
@@ -135,38 +134,26 @@ func GetMergeRequestComments(getClient GetClientFn) (tool mcp.Tool, handler serv
 			// --- Construct GitLab API options
 			opts := &gl.ListMergeRequestNotesOptions{
 				ListOptions: gl.ListOptions{
-					Page:    page,
-					PerPage: perPage,
+					PerPage: listParams.PerPage,
 				},
 			}
 
 			// --- Call GitLab API
-			notes, resp, err := glClient.Notes.ListMergeRequestNotes(projectID, mrIid, opts, gl.WithContext(ctx))
+			notes, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.Note, *gl.Response, error) {
+				opts.Page = page
+				return glClient.Notes.ListMergeRequestNotes(projectID, mrIid, opts, gl.WithContext(ctx))
+			})
 
 			// --- Handle API errors
 			if err != nil {
-				code := http.StatusInternalServerError
-				if resp != nil {
-					code = resp.StatusCode
-				}
-				if code == http.StatusNotFound {
-					msg := fmt.Sprintf("merge request %d not found in project %q or access denied (%d)", mrIid, projectID, code)
-					return mcp.NewToolResultError(msg), nil
-				}
-				return nil, fmt.Errorf("failed to get comments for merge request %d from project %q: %w (status: %d)", mrIid, projectID, err, code)
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get comments for merge request %d from project %q", mrIid, projectID),
+					fmt.Sprintf("merge request %d not found in project %q or access denied", mrIid, projectID),
+				), nil
 			}
 
 			// --- Marshal and return success
-			// Handle empty list gracefully
-			if len(notes) == 0 {
-				return mcp.NewToolResultText("[]"), nil // Return empty JSON array
-			}
-
-			data, err := json.Marshal(notes)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal merge request comments data: %w", err)
-			}
-			return mcp.NewToolResultText(string(data)), nil
+			return newListResult(notes, pagination, output, "merge request comments data")
 		}
 }
 
@@ -177,7 +164,7 @@ func ListMergeRequests(getClient GetClientFn) (tool mcp.Tool, handler server.Too
 			mcp.WithDescription("Lists merge requests for a GitLab project with filtering and pagination options."),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        "List GitLab Merge Requests",
-				ReadOnlyHint: true,
+				ReadOnlyHint: mcp.ToBoolPtr(true),
 			}),
 			// Required parameters
 			mcp.WithString("projectId",
@@ -230,6 +217,7 @@ func ListMergeRequests(getClient GetClientFn) (tool mcp.Tool, handler server.Too
 			),
 			// Add standard MCP pagination parameters
 			WithPagination(),
+			WithListOutput(mergeRequestSchema),
 		),
 		// Handler function implementation
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -249,12 +237,15 @@ func ListMergeRequests(getClient GetClientFn) (tool mcp.Tool, handler server.Too
 			opts := &gl.ListProjectMergeRequestsOptions{}
 
 			// Get pagination parameters
-			page, perPage, err := OptionalPaginationParams(&request)
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, mergeRequestSchema)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
-			opts.Page = page
-			opts.PerPage = perPage
+			opts.PerPage = listParams.PerPage
 
 			// String parameters
 			if state, err := OptionalParam[string](&request, "state"); err == nil && state != "" {
@@ -340,31 +331,20 @@ func ListMergeRequests(getClient GetClientFn) (tool mcp.Tool, handler server.Too
 			}
 
 			// --- Call GitLab API
-			mrs, resp, err := glClient.MergeRequests.ListProjectMergeRequests(projectID, opts, gl.WithContext(ctx))
+			mrs, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.BasicMergeRequest, *gl.Response, error) {
+				opts.Page = page
+				return glClient.MergeRequests.ListProjectMergeRequests(projectID, opts, gl.WithContext(ctx))
+			})
 
 			// --- Handle API errors
 			if err != nil {
-				code := http.StatusInternalServerError
-				if resp != nil {
-					code = resp.StatusCode
-				}
-				if code == http.StatusNotFound {
-					msg := fmt.Sprintf("project %q not found or access denied (%d)", projectID, code)
-					return mcp.NewToolResultError(msg), nil
-				}
-				return nil, fmt.Errorf("failed to list merge requests for project %q: %w (status: %d)", projectID, err, code)
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list merge requests for project %q", projectID),
+					fmt.Sprintf("project %q not found or access denied", projectID),
+				), nil
 			}
 
 			// --- Marshal and return success
-			// Handle empty list gracefully
-			if len(mrs) == 0 {
-				return mcp.NewToolResultText("[]"), nil // Return empty JSON array
-			}
-
-			data, err := json.Marshal(mrs)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal merge requests data: %w", err)
-			}
-			return mcp.NewToolResultText(string(data)), nil
+			return newListResult(mrs, pagination, output, "merge requests data")
 		}
 }
@@ -99,8 +99,8 @@ func TestGetMergeRequestHandler(t *testing.T) {
 					GetMergeRequest(projectID, int(mrIID), nil, gomock.Any()).
 					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
 			},
-			expectHandlerError: true,
-			errorContains:      "failed to get merge request",
+			expectResultError: true,
+			errorContains:     "failed to get merge request",
 		},
 		{
 			name: "Error - Missing projectId parameter",
@@ -139,13 +139,7 @@ func TestGetMergeRequestHandler(t *testing.T) {
 
 			// Create the request
 			req := mcp.CallToolRequest{
-				Params: struct {
-					Name      string                 `json:"name"`
-					Arguments map[string]interface{} `json:"arguments,omitempty"`
-					Meta      *struct {
-						ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-					} `json:"_meta,omitempty"`
-				}{
+				Params: mcp.CallToolParams{
 					Name:      getMergeRequestTool.Name,
 					Arguments: tc.inputArgs,
 				},
@@ -190,13 +184,7 @@ func TestGetMergeRequestHandler(t *testing.T) {
 		_, handler := GetMergeRequest(errorGetClientFn)
 
 		request := mcp.CallToolRequest{
-			Params: struct {
-				Name      string                 `json:"name"`
-				Arguments map[string]interface{} `json:"arguments,omitempty"`
-				Meta      *struct {
-					ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-				} `json:"_meta,omitempty"`
-			}{
+			Params: mcp.CallToolParams{
 				Name: getMergeRequestTool.Name,
 				Arguments: map[string]any{
 					"projectId":       projectID,
@@ -386,8 +374,8 @@ func TestGetMergeRequestCommentsHandler(t *testing.T) {
 					ListMergeRequestNotes(projectID, int(mrIid), gomock.Any(), gomock.Any()).
 					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
 			},
-			expectHandlerError: true,
-			errorContains:      "failed to get comments for merge request",
+			expectResultError: true,
+			errorContains:     "failed to get comments for merge request",
 		},
 		{
 			name: "Error - Missing projectId parameter",
@@ -427,13 +415,7 @@ func TestGetMergeRequestCommentsHandler(t *testing.T) {
 
 			// Prepare request using correct structure
 			request := mcp.CallToolRequest{
-				Params: struct {
-					Name      string                 `json:"name"`
-					Arguments map[string]interface{} `json:"arguments,omitempty"`
-					Meta      *struct {
-						ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-					} `json:"_meta,omitempty"`
-				}{
+				Params: mcp.CallToolParams{
 					Name:      getMRCommentsTool.Name,
 					Arguments: tc.inputArgs,
 				},
@@ -455,23 +437,22 @@ func TestGetMergeRequestCommentsHandler(t *testing.T) {
 				if tc.expectResultError {
 					assert.Contains(t, textContent.Text, tc.errorContains, "Error message mismatch")
 				} else {
+					items, _ := getListResult(t, result)
 					// Handle special case for empty array
 					if notes, ok := tc.expectedResult.([]*gl.Note); ok && len(notes) == 0 {
-						assert.Equal(t, "[]", textContent.Text, "Empty array mismatch")
+						assert.Equal(t, "[]", items, "Empty array mismatch")
 					} else {
 						// Unmarshal expected and actual results
 						var actualNotes []*gl.Note
-						err = json.Unmarshal([]byte(textContent.Text), &actualNotes)
+						err = json.Unmarshal([]byte(items), &actualNotes)
 						require.NoError(t, err, "Failed to unmarshal actual result JSON")
 
 						// Compare lengths first
 						expectedNotes, _ := tc.expectedResult.([]*gl.Note)
 						require.Equal(t, len(expectedNotes), len(actualNotes), "Number of notes mismatch")
 
-						// Compare content using JSONEq
-						expectedJSON, _ := json.Marshal(tc.expectedResult)
-						actualJSON, _ := json.Marshal(actualNotes)
-						assert.JSONEq(t, string(expectedJSON), string(actualJSON), "Notes content mismatch")
+						// Compare content using JSONEq against the compact note schema
+						assert.JSONEq(t, projectedJSON(t, tc.expectedResult, noteSchema.fields), items, "Notes content mismatch")
 					}
 				}
 			}
@@ -487,13 +468,7 @@ func TestGetMergeRequestCommentsHandler(t *testing.T) {
 		_, handler := GetMergeRequestComments(errorGetClientFn)
 
 		request := mcp.CallToolRequest{
-			Params: struct {
-				Name      string                 `json:"name"`
-				Arguments map[string]interface{} `json:"arguments,omitempty"`
-				Meta      *struct {
-					ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-				} `json:"_meta,omitempty"`
-			}{
+			Params: mcp.CallToolParams{
 				Name: getMRCommentsTool.Name,
 				Arguments: map[string]any{
 					"projectId":       projectID,
@@ -669,8 +644,8 @@ func TestListMergeRequestsHandler(t *testing.T) {
 					ListProjectMergeRequests(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
 			},
-			expectHandlerError: true,
-			errorContains:      "failed to list merge requests",
+			expectResultError: true,
+			errorContains:     "failed to list merge requests",
 		},
 		{
 			name:              "Error - Missing projectId parameter",
@@ -698,13 +673,7 @@ func TestListMergeRequestsHandler(t *testing.T) {
 
 			// Create the request
 			req := mcp.CallToolRequest{
-				Params: struct {
-					Name      string                 `json:"name"`
-					Arguments map[string]interface{} `json:"arguments,omitempty"`
-					Meta      *struct {
-						ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-					} `json:"_meta,omitempty"`
-				}{
+				Params: mcp.CallToolParams{
 					Name:      listMergeRequestsTool.Name,
 					Arguments: tc.inputArgs,
 				},
@@ -727,20 +696,19 @@ func TestListMergeRequestsHandler(t *testing.T) {
 				if tc.expectResultError {
 					assert.Contains(t, textContent.Text, tc.errorContains, "Error message mismatch")
 				} else {
+					items, _ := getListResult(t, result)
 					// For empty lists, check for empty JSON array
 					mrList, ok := tc.expectedResult.([]*gl.MergeRequest)
 					if ok && len(mrList) == 0 {
-						assert.Equal(t, "[]", textContent.Text, "Empty array mismatch")
+						assert.Equal(t, "[]", items, "Empty array mismatch")
 					} else {
 						// For successful responses with data, verify JSON equality
 						var actualMRs []*gl.MergeRequest
-						err = json.Unmarshal([]byte(textContent.Text), &actualMRs)
+						err = json.Unmarshal([]byte(items), &actualMRs)
 						require.NoError(t, err, "Failed to unmarshal actual result JSON")
 
-						// Marshal both expected and actual for JSONEq comparison
-						expectedJSON, _ := json.Marshal(tc.expectedResult)
-						actualJSON, _ := json.Marshal(actualMRs)
-						assert.JSONEq(t, string(expectedJSON), string(actualJSON), "Merge request list content mismatch")
+						// Compare against the compact merge request schema
+						assert.JSONEq(t, projectedJSON(t, tc.expectedResult, mergeRequestSchema.fields), items, "Merge request list content mismatch")
 					}
 				}
 			}
@@ -755,13 +723,7 @@ func TestListMergeRequestsHandler(t *testing.T) {
 		_, handler := ListMergeRequests(errorGetClientFn)
 
 		request := mcp.CallToolRequest{
-			Params: struct {
-				Name      string                 `json:"name"`
-				Arguments map[string]interface{} `json:"arguments,omitempty"`
-				Meta      *struct {
-					ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-				} `json:"_meta,omitempty"`
-			}{
+			Params: mcp.CallToolParams{
 				Name: listMergeRequestsTool.Name,
 				Arguments: map[string]any{
 					"projectId": projectID,
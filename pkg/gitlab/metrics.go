@@ -0,0 +1,294 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsNamespace prefixes the names of all metrics exported by the server.
+const metricsNamespace = "gitlab_mcp"
+
+// Error classes of failed tool calls, in addition to those derived from the status of the
+// last failed GitLab API request (see statusErrorClass).
+const (
+	// errorClassTool covers error results not caused by GitLab, such as invalid arguments.
+	errorClassTool = "tool"
+	// errorClassInternal covers calls whose handler returned an error instead of a result.
+	errorClassInternal = "internal"
+)
+
+// routeIDs maps the API collections addressed by identifier to the placeholder replacing
+// that identifier in route templates, as in GitLab's API documentation.
+var routeIDs = map[string]string{
+	"projects":        ":id",
+	"groups":          ":id",
+	"users":           ":id",
+	"issues":          ":issue_iid",
+	"merge_requests":  ":merge_request_iid",
+	"commits":         ":sha",
+	"blobs":           ":sha",
+	"branches":        ":branch",
+	"tags":            ":tag_name",
+	"releases":        ":tag_name",
+	"files":           ":file_path",
+	"pipelines":       ":pipeline_id",
+	"jobs":            ":job_id",
+	"notes":           ":note_id",
+	"discussions":     ":discussion_id",
+	"milestones":      ":milestone_id",
+	"links":           ":link_id",
+	"members":         ":user_id",
+	"vulnerabilities": ":vulnerability_id",
+}
+
+// routeLiterals lists the endpoints that follow a collection of routeIDs in place of an
+// identifier, such as "members/all", so they are not mistaken for one.
+var routeLiterals = map[string]bool{
+	"members/all":      true,
+	"pipelines/latest": true,
+}
+
+// routeWordPattern matches path segments that name an endpoint rather than identify a resource.
+var routeWordPattern = regexp.MustCompile(`^[a-z_]+$`)
+
+// Metrics collects Prometheus metrics of tool calls and of the GitLab API requests they make.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	toolCalls    *prometheus.CounterVec
+	toolDuration *prometheus.HistogramVec
+	toolErrors   *prometheus.CounterVec
+	apiRequests  *prometheus.CounterVec
+	apiDuration  *prometheus.HistogramVec
+	rateLimit    *prometheus.GaugeVec
+}
+
+// NewMetrics returns metrics registered in a dedicated registry, together with the Go
+// runtime and process collectors.
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		toolCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "tool_calls_total",
+			Help:      "Tool calls by tool and outcome (success or error).",
+		}, []string{"tool", "outcome"}),
+		toolDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "tool_call_duration_seconds",
+			Help:      "Duration of tool calls by tool.",
+			Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"tool"}),
+		toolErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "tool_errors_total",
+			Help:      "Failed tool calls by tool and error class (e.g., not_found, rate_limited, tool).",
+		}, []string{"tool", "class"}),
+		apiRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "api_requests_total",
+			Help:      "GitLab API requests sent, retries included, by method, route template and status (0 when GitLab could not be reached).",
+		}, []string{"method", "endpoint", "status"}),
+		apiDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "api_request_duration_seconds",
+			Help:      "Duration of GitLab API requests by method and route template.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "endpoint"}),
+		rateLimit: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "rate_limit",
+			Help:      "GitLab rate limit reported by the last API response: limit, remaining requests and reset time (Unix seconds).",
+		}, []string{"field"}),
+	}
+	m.registry.MustRegister(
+		m.toolCalls, m.toolDuration, m.toolErrors, m.apiRequests, m.apiDuration, m.rateLimit,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// Handler returns the HTTP handler serving the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// RegisterCache exports the statistics of the response cache, including its hit ratio.
+func (m *Metrics) RegisterCache(cache *CachingTransport) {
+	stat := func(value func(CacheStats) float64) func() float64 {
+		return func() float64 { return value(cache.Stats()) }
+	}
+	m.registry.MustRegister(
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: metricsNamespace, Name: "cache_hits_total",
+			Help: "GitLab API responses served from the cache without a request.",
+		}, stat(func(s CacheStats) float64 { return float64(s.Hits) })),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: metricsNamespace, Name: "cache_revalidations_total",
+			Help: "Cached GitLab API responses confirmed unchanged by a 304 Not Modified.",
+		}, stat(func(s CacheStats) float64 { return float64(s.Revalidated) })),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: metricsNamespace, Name: "cache_misses_total",
+			Help: "GitLab API responses downloaded in full.",
+		}, stat(func(s CacheStats) float64 { return float64(s.Misses) })),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: metricsNamespace, Name: "cache_evictions_total",
+			Help: "Cached GitLab API responses evicted to stay within the entry limit.",
+		}, stat(func(s CacheStats) float64 { return float64(s.Evictions) })),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace, Name: "cache_entries",
+			Help: "GitLab API responses cached in memory.",
+		}, stat(func(s CacheStats) float64 { return float64(s.Entries) })),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace, Name: "cache_hit_ratio",
+			Help: "Share of cacheable GitLab API requests answered from the cache, revalidations included.",
+		}, stat(CacheStats.HitRatio)),
+	)
+}
+
+// WithMetrics returns a server option recording the count, duration and errors of every
+// tool call in m.
+func WithMetrics(m *Metrics) server.ServerOption {
+	return server.WithToolHandlerMiddleware(m.middleware)
+}
+
+// apiStatusKey is the context key under which the metrics middleware stores the status of
+// the last failed GitLab API request of a tool call.
+type apiStatusKey struct{}
+
+// middleware wraps a tool handler to record its calls.
+func (m *Metrics) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		tool := request.Params.Name
+		failed := &atomic.Int64{}
+		failed.Store(-1)
+		start := time.Now()
+		result, err := next(context.WithValue(ctx, apiStatusKey{}, failed), request)
+		m.toolDuration.WithLabelValues(tool).Observe(time.Since(start).Seconds())
+
+		var class string
+		switch {
+		case err != nil:
+			class = errorClassInternal
+		case result != nil && result.IsError:
+			class = errorClassTool
+			if status := failed.Load(); status >= 0 {
+				class = statusErrorClass(int(status))
+			}
+		}
+		if class == "" {
+			m.toolCalls.WithLabelValues(tool, AuditOutcomeSuccess).Inc()
+		} else {
+			m.toolCalls.WithLabelValues(tool, AuditOutcomeError).Inc()
+			m.toolErrors.WithLabelValues(tool, class).Inc()
+		}
+		return result, err
+	}
+}
+
+// Transport returns an HTTP transport recording the GitLab API requests sent through next and
+// the rate limit left after them. Placed beneath the response cache, it only sees requests
+// that reach GitLab.
+func (m *Metrics) Transport(next http.RoundTripper) http.RoundTripper {
+	return metricsTransport{metrics: m, next: next}
+}
+
+// metricsTransport is the transport returned by Metrics.Transport.
+type metricsTransport struct {
+	metrics *Metrics
+	next    http.RoundTripper
+}
+
+func (t metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := routeTemplate(req.URL)
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	t.metrics.apiDuration.WithLabelValues(req.Method, endpoint).Observe(time.Since(start).Seconds())
+
+	status := 0
+	if err == nil {
+		status = resp.StatusCode
+		t.metrics.observeRateLimit(resp.Header)
+	}
+	t.metrics.apiRequests.WithLabelValues(req.Method, endpoint, strconv.Itoa(status)).Inc()
+
+	// A retry that succeeds clears the failure of the previous attempt
+	if failed, ok := req.Context().Value(apiStatusKey{}).(*atomic.Int64); ok {
+		if status == 0 || status >= http.StatusBadRequest {
+			failed.Store(int64(status))
+		} else {
+			failed.Store(-1)
+		}
+	}
+	return resp, err
+}
+
+// observeRateLimit records the rate limit headers GitLab sends with every response.
+func (m *Metrics) observeRateLimit(header http.Header) {
+	for field, name := range map[string]string{"limit": "RateLimit-Limit", "remaining": "RateLimit-Remaining", "reset": "RateLimit-Reset"} {
+		if value, err := strconv.ParseFloat(header.Get(name), 64); err == nil {
+			m.rateLimit.WithLabelValues(field).Set(value)
+		}
+	}
+}
+
+// statusErrorClass returns the error class of a failed GitLab API request, following the
+// cases of apiErrorMessage.
+func statusErrorClass(code int) string {
+	switch {
+	case code == 0:
+		return "unreachable"
+	case code == http.StatusNotFound:
+		return "not_found"
+	case code == http.StatusUnauthorized:
+		return "unauthorized"
+	case code == http.StatusForbidden:
+		return "forbidden"
+	case code == http.StatusConflict:
+		return "conflict"
+	case code == http.StatusBadRequest, code == http.StatusUnprocessableEntity:
+		return "rejected"
+	case code == http.StatusTooManyRequests:
+		return "rate_limited"
+	case code >= http.StatusInternalServerError:
+		return "server_error"
+	}
+	return "unexpected"
+}
+
+// routeTemplate returns the API route of a request to u with its identifiers replaced by
+// placeholders (e.g., "/projects/:id/issues/:issue_iid/notes"), keeping metric labels and
+// span names to a bounded set.
+func routeTemplate(u *url.URL) string {
+	segments := apiPath(u)
+	for i, segment := range segments {
+		if i > 0 {
+			// The previous segment was already rewritten, so a placeholder never follows one
+			collection := segments[i-1]
+			if i > 1 && routeLiterals[segments[i-2]+"/"+collection] {
+				collection = segments[i-2] // e.g., "members/all/:user_id"
+			}
+			if placeholder, ok := routeIDs[collection]; ok && !routeLiterals[collection+"/"+segment] {
+				segments[i] = placeholder
+				continue
+			}
+		}
+		if !routeWordPattern.MatchString(segment) {
+			segments[i] = ":id"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,115 @@
+package gitlab
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestRouteTemplate(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/api/v4/projects", "/projects"},
+		{"/api/v4/projects/group%2Fproject", "/projects/:id"},
+		{"/api/v4/projects/42/issues/7/notes", "/projects/:id/issues/:issue_iid/notes"},
+		{"/api/v4/projects/42/merge_requests/3/changes", "/projects/:id/merge_requests/:merge_request_iid/changes"},
+		{"/api/v4/projects/42/repository/files/src%2Fmain.go/raw?ref=main", "/projects/:id/repository/files/:file_path/raw"},
+		{"/api/v4/projects/42/repository/commits/" + testSHA + "/diff", "/projects/:id/repository/commits/:sha/diff"},
+		{"/api/v4/projects/42/repository/branches/feature%2Fx", "/projects/:id/repository/branches/:branch"},
+		{"/api/v4/projects/42/releases/v1.0/assets/links/5", "/projects/:id/releases/:tag_name/assets/links/:link_id"},
+		{"/api/v4/groups/platform/members/all", "/groups/:id/members/all"},
+		{"/api/v4/groups/platform/members/all/12", "/groups/:id/members/all/:user_id"},
+		{"/api/v4/projects/42/pipelines/latest", "/projects/:id/pipelines/latest"},
+		{"/api/v4/user", "/user"},
+		{"/api/v4/users/12/events", "/users/:id/events"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			u, err := url.Parse("https://gitlab.com" + tc.path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, routeTemplate(u))
+		})
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("RateLimit-Limit", "600")
+		w.Header().Set("RateLimit-Remaining", "42")
+		if r.URL.EscapedPath() != "/api/v4/projects/group%2Fproject" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"message": "404 Project Not Found"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id": 1, "path_with_namespace": "group/project"}`)
+	}))
+	defer srv.Close()
+
+	m := NewMetrics()
+	cache, err := NewCachingTransport(m.Transport(http.DefaultTransport), CacheConfig{})
+	require.NoError(t, err)
+	m.RegisterCache(cache)
+	client := newCachedClient(t, srv.URL, "token", cache)
+	getClient := func(_ context.Context) (*gl.Client, error) { return client, nil }
+
+	s := NewServer("test", "0.0.0", WithMetrics(m))
+	s.AddTool(GetProject(getClient))
+	for _, projectID := range []string{"group/project", "group/project", "missing"} {
+		callTool(t, s, "getProject", map[string]any{"projectId": projectID})
+	}
+	result := callTool(t, s, "getProject", map[string]any{})
+	assert.True(t, result.IsError)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.toolCalls.WithLabelValues("getProject", AuditOutcomeSuccess)))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.toolCalls.WithLabelValues("getProject", AuditOutcomeError)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.toolErrors.WithLabelValues("getProject", "not_found")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.toolErrors.WithLabelValues("getProject", errorClassTool)), "Invalid arguments fail without an API request")
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.apiRequests.WithLabelValues(http.MethodGet, "/projects/:id", "200")), "Cache hits are not API requests")
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.apiRequests.WithLabelValues(http.MethodGet, "/projects/:id", "404")))
+	assert.Equal(t, 42.0, testutil.ToFloat64(m.rateLimit.WithLabelValues("remaining")))
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "gitlab_mcp_cache_hit_ratio 0.3333333333333333\n", "One hit among the two downloads")
+	assert.Contains(t, rec.Body.String(), `gitlab_mcp_tool_call_duration_seconds_count{tool="getProject"} 4`)
+}
+
+func TestMetricsErrorClassAfterRetry(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	m := NewMetrics()
+	logger, _ := test.NewNullLogger()
+	opts := append(ClientOptions(ClientConfig{MaxRetries: 1}, logger),
+		gl.WithBaseURL(srv.URL), gl.WithHTTPClient(&http.Client{Transport: m.Transport(http.DefaultTransport)}))
+	client, err := gl.NewClient("token", opts...)
+	require.NoError(t, err)
+	s := NewServer("test", "0.0.0", WithMetrics(m))
+	s.AddTool(GetProject(func(_ context.Context) (*gl.Client, error) { return client, nil }))
+
+	callTool(t, s, "getProject", map[string]any{"projectId": "group/project"})
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.toolErrors.WithLabelValues("getProject", "forbidden")), "The class is that of the last attempt")
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.apiRequests.WithLabelValues(http.MethodGet, "/projects/:id", "429")))
+}
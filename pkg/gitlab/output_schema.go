@@ -0,0 +1,155 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	gl "gitlab.com/gitlab-org/api/client-go" // GitLab client library
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	isoTimeType   = reflect.TypeOf(gl.ISOTime{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// WithResultSchema returns a ToolOption declaring the output schema of a tool whose
+// result is a model value encoded as JSON, such as a created branch or a snapshot.
+func WithResultSchema(model any) mcp.ToolOption {
+	return mcp.WithRawOutputSchema(mustMarshalSchema(objectSchema(model, nil)))
+}
+
+// objectSchema returns the JSON Schema of model, which must encode as a JSON object,
+// reduced to fields (nil keeps every field).
+func objectSchema(model any, fields fieldSet) map[string]any {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return typeSchema(t, fields, map[reflect.Type]bool{})
+}
+
+// listSchema returns the JSON Schema of a list tool result: the items of type model,
+// reduced to fields, and the pagination metadata.
+func listSchema(model any, fields fieldSet) map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"items":      map[string]any{"type": "array", "items": objectSchema(model, fields)},
+			"pagination": objectSchema(Pagination{}, nil),
+		},
+		"required": []string{"items", "pagination"},
+	}
+}
+
+// typeSchema derives the JSON Schema of t as encoded by encoding/json. Only the fields
+// selected by fields are described; seen guards against recursive types.
+func typeSchema(t reflect.Type, fields fieldSet, seen map[reflect.Type]bool) map[string]any {
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case isoTimeType:
+		return map[string]any{"type": "string", "format": "date"}
+	}
+	if t.Kind() != reflect.Pointer && (t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType)) {
+		return map[string]any{} // Custom encoding: any value
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(typeSchema(t.Elem(), fields, seen))
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string"} // Encoded as base64
+		}
+		// Projections traverse arrays, so the same fields apply to every element
+		items := typeSchema(t.Elem(), fields, seen)
+		if t.Kind() == reflect.Array {
+			return map[string]any{"type": "array", "items": items}
+		}
+		return nullable(map[string]any{"type": "array", "items": items})
+	case reflect.Map:
+		return nullable(map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem(), nil, seen)})
+	case reflect.Struct:
+		if seen[t] {
+			return map[string]any{"type": "object"}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+		properties := map[string]any{}
+		addStructProperties(t, fields, seen, properties)
+		return map[string]any{"type": "object", "properties": properties}
+	default:
+		return map[string]any{} // Interfaces and other kinds: any value
+	}
+}
+
+// addStructProperties adds the JSON properties of struct type t to properties, flattening
+// embedded structs the way encoding/json does.
+func addStructProperties(t reflect.Type, fields fieldSet, seen map[reflect.Type]bool, properties map[string]any) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addStructProperties(embedded, fields, seen, properties)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		var sub fieldSet
+		if fields != nil {
+			var ok bool
+			if sub, ok = fields[name]; !ok {
+				continue
+			}
+			if len(sub) == 0 {
+				sub = nil // The whole value is selected
+			}
+		}
+		properties[name] = typeSchema(f.Type, sub, seen)
+	}
+}
+
+// nullable widens schema to also accept null, as encoding/json writes nil pointers,
+// slices and maps.
+func nullable(schema map[string]any) map[string]any {
+	if typ, ok := schema["type"].(string); ok {
+		schema["type"] = []string{typ, "null"}
+	}
+	return schema
+}
+
+// mustMarshalSchema encodes a schema built by this package, which always succeeds.
+func mustMarshalSchema(schema map[string]any) json.RawMessage {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
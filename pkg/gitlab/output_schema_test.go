@@ -0,0 +1,149 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+type schemaTestAuthor struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+type schemaTestBase struct {
+	ID int `json:"id"`
+}
+
+type schemaTestItem struct {
+	schemaTestBase
+	Title     string            `json:"title"`
+	Author    *schemaTestAuthor `json:"author"`
+	Labels    []string          `json:"labels,omitempty"`
+	CreatedAt *time.Time        `json:"created_at"`
+	DueDate   *gl.ISOTime       `json:"due_date"`
+	Score     float64           `json:"score"`
+	Internal  string            `json:"-"`
+	Extra     map[string]int    `json:"extra"`
+}
+
+func TestObjectSchema(t *testing.T) {
+	t.Run("All Fields", func(t *testing.T) {
+		expected := map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"id":    map[string]any{"type": "integer"},
+				"title": map[string]any{"type": "string"},
+				"author": map[string]any{
+					"type": []string{"object", "null"},
+					"properties": map[string]any{
+						"username": map[string]any{"type": "string"},
+						"name":     map[string]any{"type": "string"},
+					},
+				},
+				"labels":     map[string]any{"type": []string{"array", "null"}, "items": map[string]any{"type": "string"}},
+				"created_at": map[string]any{"type": []string{"string", "null"}, "format": "date-time"},
+				"due_date":   map[string]any{"type": []string{"string", "null"}, "format": "date"},
+				"score":      map[string]any{"type": "number"},
+				"extra":      map[string]any{"type": []string{"object", "null"}, "additionalProperties": map[string]any{"type": "integer"}},
+			},
+		}
+		assert.Equal(t, expected, objectSchema(&schemaTestItem{}, nil))
+	})
+
+	t.Run("Projected Fields", func(t *testing.T) {
+		expected := map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"id": map[string]any{"type": "integer"},
+				"author": map[string]any{
+					"type":       []string{"object", "null"},
+					"properties": map[string]any{"username": map[string]any{"type": "string"}},
+				},
+			},
+		}
+		assert.Equal(t, expected, objectSchema(schemaTestItem{}, parseFields([]string{"id", "author.username", "missing"})))
+	})
+
+	t.Run("List Envelope", func(t *testing.T) {
+		schema := listSchema(schemaTestItem{}, parseFields([]string{"title"}))
+		assert.Equal(t, "object", schema["type"])
+		assert.Equal(t, []string{"items", "pagination"}, schema["required"])
+		properties := schema["properties"].(map[string]any)
+		assert.Equal(t, map[string]any{
+			"type":  "array",
+			"items": map[string]any{"type": "object", "properties": map[string]any{"title": map[string]any{"type": "string"}}},
+		}, properties["items"])
+		assert.Contains(t, properties["pagination"].(map[string]any)["properties"], "next_cursor")
+	})
+}
+
+func TestToolOutputSchemas(t *testing.T) {
+	listTool, _ := ListIssues(nil)
+	var listSchema map[string]any
+	require.NoError(t, json.Unmarshal(listTool.RawOutputSchema, &listSchema))
+	items := listSchema["properties"].(map[string]any)["items"].(map[string]any)["items"].(map[string]any)
+	assert.ElementsMatch(t, []string{"id", "iid", "project_id", "title", "state", "labels", "author", "assignees",
+		"milestone", "due_date", "user_notes_count", "created_at", "updated_at", "closed_at", "web_url"},
+		slices.Collect(maps.Keys(items["properties"].(map[string]any))))
+
+	// The schema is advertised in the tools/list payload
+	data, err := json.Marshal(listTool)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"outputSchema":{`)
+
+	branchTool, _ := CreateBranch(nil, false)
+	var branchSchema map[string]any
+	require.NoError(t, json.Unmarshal(branchTool.RawOutputSchema, &branchSchema))
+	assert.Contains(t, branchSchema["properties"], "commit")
+}
+
+func TestGetProjectHandlerStructuredContent(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockProjects, ctrl := setupMockClient(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	getProjectTool, getProjectHandler := GetProject(mockGetClient)
+
+	project := &gl.Project{ID: 1, Name: "project", PathWithNamespace: "group/project", Visibility: gl.PrivateVisibility}
+	mockProjects.EXPECT().
+		GetProject("group/project", gomock.Any(), gomock.Any()).
+		Return(project, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil).
+		Times(2)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(getProjectTool.RawOutputSchema, &schema))
+	schemaProperties := schema["properties"].(map[string]any)
+
+	for _, format := range []string{FormatJSON, FormatMarkdown} {
+		t.Run(format, func(t *testing.T) {
+			args := map[string]any{"projectId": "group/project", "format": format}
+			result, err := getProjectHandler(ctx, createCallToolRequest(getProjectTool.Name, args))
+			require.NoError(t, err)
+			structured, ok := result.StructuredContent.(map[string]any)
+			require.True(t, ok, "Expected structured content to be an object, got %T", result.StructuredContent)
+			assert.Equal(t, "group/project", structured["path_with_namespace"])
+			for key := range structured {
+				assert.Contains(t, schemaProperties, key, "Structured content has a field missing from the output schema")
+			}
+			if format == FormatJSON {
+				data, err := json.Marshal(structured)
+				require.NoError(t, err)
+				assert.JSONEq(t, string(data), getTextResult(t, result).Text, "Text content should mirror the structured content")
+			}
+		})
+	}
+}
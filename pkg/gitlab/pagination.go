@@ -0,0 +1,249 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/mark3labs/mcp-go/mcp"
+	gl "gitlab.com/gitlab-org/api/client-go" // GitLab client library
+)
+
+// DefaultMaxItems caps how many items a list tool collects across pages when 'all' is set.
+const DefaultMaxItems = 1000
+
+// MaxItemsLimit is the largest 'max_items' value accepted by list tools.
+const MaxItemsLimit = 10000
+
+// ListParams holds the parsed pagination parameters of a list tool call.
+type ListParams struct {
+	Page    int
+	PerPage int
+	// MaxItems, when positive, makes the tool walk successive pages until it has
+	// collected MaxItems items or reached the last page.
+	MaxItems int
+	// Cursor is an opaque keyset position returned as 'next_cursor' by a previous call.
+	Cursor string
+}
+
+// Pagination describes where a list result sits within the full GitLab result set.
+type Pagination struct {
+	// Page is the first page included in the result (offset pagination only).
+	Page    int `json:"page,omitempty"`
+	PerPage int `json:"per_page"`
+	// PagesFetched is the number of GitLab pages walked to build the result.
+	PagesFetched int `json:"pages_fetched"`
+	// NextPage is the page to request to continue (offset pagination only).
+	NextPage int `json:"next_page,omitempty"`
+	// NextCursor is the value to pass as 'cursor' to continue (keyset pagination only).
+	NextCursor string `json:"next_cursor,omitempty"`
+	// TotalItems and TotalPages are omitted when GitLab does not report them
+	// (e.g., for result sets above 10,000 items).
+	TotalItems *int `json:"total_items,omitempty"`
+	TotalPages *int `json:"total_pages,omitempty"`
+	HasMore    bool `json:"has_more"`
+	// Truncated is set when items were dropped because the response size budget was reached.
+	Truncated bool `json:"truncated,omitempty"`
+	// Continuation is the token to pass as 'continuation', with the same other parameters,
+	// to get the items dropped to fit the response size budget.
+	Continuation string `json:"continuation,omitempty"`
+}
+
+// listResult is the envelope returned by list tools.
+type listResult[T any] struct {
+	Items      T           `json:"items"`
+	Pagination *Pagination `json:"pagination"`
+}
+
+// WithKeysetPagination returns a ToolOption adding the 'cursor' parameter used by
+// list tools that walk pages with GitLab keyset pagination.
+func WithKeysetPagination() mcp.ToolOption {
+	return mcp.WithString("cursor",
+		mcp.Description("Opaque position returned as 'next_cursor' by a previous call; continues the listing from there. Overrides 'page'."),
+	)
+}
+
+// OptionalListParams extracts page, per_page, all, max_items and cursor parameters from the request.
+// Setting 'all' without 'max_items' caps the walk at DefaultMaxItems. When pages are walked and
+// per_page was not given, the largest useful page size is used to keep the number of requests low.
+func OptionalListParams(req *mcp.CallToolRequest) (ListParams, error) {
+	page, perPage, err := OptionalPaginationParams(req)
+	if err != nil {
+		return ListParams{}, err
+	}
+	all, err := OptionalBoolParam(req, "all")
+	if err != nil {
+		return ListParams{}, fmt.Errorf("invalid 'all' parameter: %w", err)
+	}
+	maxItems, err := OptionalIntParam(req, "max_items")
+	if err != nil {
+		return ListParams{}, fmt.Errorf("invalid 'max_items' parameter: %w", err)
+	}
+	if maxItems < 0 {
+		return ListParams{}, fmt.Errorf("invalid 'max_items' parameter: must be positive, got %d", maxItems)
+	}
+	cursor, err := OptionalParam[string](req, "cursor")
+	if err != nil {
+		return ListParams{}, err
+	}
+
+	if maxItems == 0 && all != nil && *all {
+		maxItems = DefaultMaxItems
+	}
+	if maxItems > MaxItemsLimit {
+		maxItems = MaxItemsLimit
+	}
+	if maxItems > 0 {
+		if _, ok, _ := OptionalParamOK[any](req, "per_page"); !ok {
+			perPage = min(MaxPerPage, maxItems)
+		}
+		// Pages are never cut, so the first one must fit; shrinking it would move later pages
+		if perPage > maxItems {
+			if page > 1 && cursor == "" {
+				return ListParams{}, fmt.Errorf("invalid 'max_items' parameter: must be at least 'per_page' (%d) when 'page' is set", perPage)
+			}
+			perPage = maxItems
+		}
+	}
+
+	return ListParams{Page: page, PerPage: perPage, MaxItems: maxItems, Cursor: cursor}, nil
+}
+
+// collectPages fetches params.Page, or successive pages from it when params.MaxItems is set,
+// using offset pagination. Only whole pages are collected: the walk stops before a page that
+// would not fit in params.MaxItems, so NextPage always resumes right after the last item.
+// On error the failing response is returned so callers can map its status.
+func collectPages[T any](params ListParams, fetch func(page int) ([]T, *gl.Response, error)) ([]T, *Pagination, *gl.Response, error) {
+	p := &Pagination{Page: params.Page, PerPage: params.PerPage}
+	var items []T
+	page := params.Page
+	for {
+		pageItems, resp, err := fetch(page)
+		if err != nil {
+			return nil, nil, resp, err
+		}
+		p.PagesFetched++
+		items = append(items, pageItems...)
+		setTotals(p, resp)
+
+		nextPage := 0
+		if resp != nil {
+			nextPage = resp.NextPage
+		}
+		if nextPage == 0 || params.MaxItems-len(items) < params.PerPage {
+			p.NextPage, p.HasMore = nextPage, nextPage != 0
+			return items, p, resp, nil
+		}
+		page = nextPage
+	}
+}
+
+// collectKeysetPages is the keyset pagination counterpart of collectPages. fetch receives the
+// link of the page to request, empty for the first page, and the number of items to request,
+// which is lowered on the last page so it ends exactly at params.MaxItems.
+func collectKeysetPages[T any](params ListParams, fetch func(link string, perPage int) ([]T, *gl.Response, error)) ([]T, *Pagination, *gl.Response, error) {
+	p := &Pagination{PerPage: params.PerPage}
+	var items []T
+	link := params.Cursor
+	for {
+		perPage := params.PerPage
+		if params.MaxItems > 0 {
+			perPage = min(perPage, params.MaxItems-len(items))
+		}
+		pageItems, resp, err := fetch(link, perPage)
+		if err != nil {
+			return nil, nil, resp, err
+		}
+		p.PagesFetched++
+		items = append(items, pageItems...)
+		setTotals(p, resp)
+
+		nextLink := ""
+		if resp != nil {
+			nextLink = resp.NextLink
+		}
+		if nextLink == "" || params.MaxItems-len(items) <= 0 {
+			p.NextCursor, p.HasMore = nextLink, nextLink != ""
+			return items, p, resp, nil
+		}
+		link = nextLink
+	}
+}
+
+// withPerPage returns a request option setting the page size, overriding the one carried by
+// a keyset pagination link.
+func withPerPage(perPage int) gl.RequestOptionFunc {
+	return func(req *retryablehttp.Request) error {
+		q := req.URL.Query()
+		q.Set("per_page", strconv.Itoa(perPage))
+		req.URL.RawQuery = q.Encode()
+		return nil
+	}
+}
+
+// setTotals copies the X-Total and X-Total-Pages values of resp into p when GitLab sent them.
+func setTotals(p *Pagination, resp *gl.Response) {
+	if resp == nil || resp.Response == nil {
+		return
+	}
+	if resp.Header.Get("X-Total") != "" || resp.TotalItems > 0 {
+		p.TotalItems = gl.Ptr(resp.TotalItems)
+	}
+	if resp.Header.Get("X-Total-Pages") != "" || resp.TotalPages > 0 {
+		p.TotalPages = gl.Ptr(resp.TotalPages)
+	}
+}
+
+// newListResult converts items, reduced to the selected fields, and their pagination into a
+// tool result in the requested format. Items already returned according to the continuation
+// are skipped, and trailing items are dropped, with a new continuation, when the result
+// exceeds the response budget. what names the listed resource in the error message.
+func newListResult[T any](items []T, pagination *Pagination, output outputOptions, what string) (*mcp.CallToolResult, error) {
+	if items == nil {
+		items = []T{} // Always return a JSON array, even when empty
+	}
+	items = items[min(output.continuation.Offset, len(items)):]
+
+	build := func(part []T, p *Pagination) (any, string, error) {
+		projected, err := output.fields.project(part)
+		if err != nil {
+			return nil, "", err
+		}
+		structured := listResult[any]{Items: projected, Pagination: p}
+		if output.rendered() {
+			text, err := renderList(projected, p, output)
+			return structured, text, err
+		}
+		data, err := json.Marshal(structured)
+		return structured, string(data), err
+	}
+
+	var buildErr error
+	k, next := fitToBudget(len(items), output.budget, output.continuation, output.tool, func(k int, next string) int {
+		_, text, err := build(items[:k], withContinuation(pagination, next))
+		if err != nil && buildErr == nil {
+			buildErr = err
+		}
+		return len(text)
+	})
+	if buildErr != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", what, buildErr)
+	}
+	structured, text, err := build(items[:k], withContinuation(pagination, next))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", what, err)
+	}
+	return mcp.NewToolResultStructured(structured, text), nil
+}
+
+// withContinuation returns a copy of p marked as truncated with the given continuation
+// token, or p itself when the token is empty.
+func withContinuation(p *Pagination, token string) *Pagination {
+	if token == "" || p == nil {
+		return p
+	}
+	truncated := *p
+	truncated.Continuation, truncated.Truncated = token, true
+	return &truncated
+}
@@ -0,0 +1,240 @@
+package gitlab
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestOptionalListParams(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		expected    ListParams
+		errContains string
+	}{
+		{
+			name:     "Defaults - Single Page",
+			args:     map[string]any{},
+			expected: ListParams{Page: 1, PerPage: DefaultPerPage},
+		},
+		{
+			name:     "All - Default Cap And Largest Page Size",
+			args:     map[string]any{"all": true, "page": 3},
+			expected: ListParams{Page: 3, PerPage: MaxPerPage, MaxItems: DefaultMaxItems},
+		},
+		{
+			name:     "Max Items - Page Size Follows Small Cap",
+			args:     map[string]any{"max_items": 30},
+			expected: ListParams{Page: 1, PerPage: 30, MaxItems: 30},
+		},
+		{
+			name:     "Max Items - Explicit Page Size Kept",
+			args:     map[string]any{"max_items": 250, "per_page": 50},
+			expected: ListParams{Page: 1, PerPage: 50, MaxItems: 250},
+		},
+		{
+			name:     "Max Items - Explicit Page Size Lowered To Cap On First Page",
+			args:     map[string]any{"max_items": 30, "per_page": 100},
+			expected: ListParams{Page: 1, PerPage: 30, MaxItems: 30},
+		},
+		{
+			name:     "Max Items - Capped At Limit",
+			args:     map[string]any{"max_items": MaxItemsLimit * 2},
+			expected: ListParams{Page: 1, PerPage: MaxPerPage, MaxItems: MaxItemsLimit},
+		},
+		{
+			name:     "Cursor",
+			args:     map[string]any{"cursor": "https://gitlab.example.com/api/v4/projects?id_after=42"},
+			expected: ListParams{Page: 1, PerPage: DefaultPerPage, Cursor: "https://gitlab.example.com/api/v4/projects?id_after=42"},
+		},
+		{
+			name:        "Error - Negative Max Items",
+			args:        map[string]any{"max_items": -1},
+			errContains: "must be positive",
+		},
+		{
+			name:        "Error - Max Items Below Page Size On Later Page",
+			args:        map[string]any{"max_items": 30, "per_page": 100, "page": 3},
+			errContains: "must be at least 'per_page' (100)",
+		},
+		{
+			name:        "Error - All Not Boolean",
+			args:        map[string]any{"all": 5},
+			errContains: "invalid 'all' parameter",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := createCallToolRequest("listSomething", tc.args)
+			params, err := OptionalListParams(&req)
+			if tc.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, params)
+		})
+	}
+}
+
+// fakePages serves numbered items in pages of perPage, up to total items
+func fakePages(total, perPage int, requested *[]int) func(page int) ([]int, *gl.Response, error) {
+	return func(page int) ([]int, *gl.Response, error) {
+		*requested = append(*requested, page)
+		resp := &gl.Response{Response: &http.Response{StatusCode: 200}}
+		var items []int
+		for i := (page-1)*perPage + 1; i <= total && i <= page*perPage; i++ {
+			items = append(items, i)
+		}
+		if page*perPage < total {
+			resp.NextPage = page + 1
+		}
+		return items, resp, nil
+	}
+}
+
+func TestCollectPages(t *testing.T) {
+	t.Run("Single Page", func(t *testing.T) {
+		var requested []int
+		items, p, _, err := collectPages(ListParams{Page: 2, PerPage: 10}, fakePages(35, 10, &requested))
+		require.NoError(t, err)
+		assert.Equal(t, []int{2}, requested)
+		assert.Len(t, items, 10)
+		assert.Equal(t, 11, items[0])
+		assert.Equal(t, Pagination{Page: 2, PerPage: 10, PagesFetched: 1, NextPage: 3, HasMore: true}, *p)
+	})
+
+	t.Run("Walk To Last Page", func(t *testing.T) {
+		var requested []int
+		items, p, _, err := collectPages(ListParams{Page: 1, PerPage: 10, MaxItems: 100}, fakePages(35, 10, &requested))
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4}, requested)
+		assert.Len(t, items, 35)
+		assert.False(t, p.HasMore)
+		assert.Zero(t, p.NextPage)
+		assert.Equal(t, 4, p.PagesFetched)
+	})
+
+	t.Run("Walk Stops At Cap On Page Boundary", func(t *testing.T) {
+		var requested []int
+		items, p, _, err := collectPages(ListParams{Page: 1, PerPage: 10, MaxItems: 20}, fakePages(35, 10, &requested))
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, requested)
+		assert.Len(t, items, 20)
+		assert.True(t, p.HasMore)
+		assert.False(t, p.Truncated)
+		assert.Equal(t, 3, p.NextPage)
+	})
+
+	t.Run("Walk Stops Before Page Exceeding Cap", func(t *testing.T) {
+		var requested []int
+		items, p, _, err := collectPages(ListParams{Page: 1, PerPage: 10, MaxItems: 15}, fakePages(35, 10, &requested))
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, requested, "page 2 would not fit and should not be fetched")
+		assert.Len(t, items, 10)
+		assert.True(t, p.HasMore)
+		assert.False(t, p.Truncated)
+		assert.Equal(t, 2, p.NextPage)
+
+		// Resuming from next_page continues right after the last returned item
+		requested = nil
+		items, _, _, err = collectPages(ListParams{Page: p.NextPage, PerPage: 10, MaxItems: 15}, fakePages(35, 10, &requested))
+		require.NoError(t, err)
+		assert.Equal(t, 11, items[0])
+	})
+
+	t.Run("Totals From Headers", func(t *testing.T) {
+		_, p, _, err := collectPages(ListParams{Page: 1, PerPage: 10}, func(_ int) ([]int, *gl.Response, error) {
+			return []int{1}, &gl.Response{Response: &http.Response{StatusCode: 200, Header: http.Header{"X-Total": {"0"}}}}, nil
+		})
+		require.NoError(t, err)
+		require.NotNil(t, p.TotalItems)
+		assert.Equal(t, 0, *p.TotalItems)
+		assert.Nil(t, p.TotalPages, "absent header should be omitted")
+	})
+
+	t.Run("Error Returns Failing Response", func(t *testing.T) {
+		calls := 0
+		_, p, resp, err := collectPages(ListParams{Page: 1, PerPage: 10, MaxItems: 50}, func(page int) ([]int, *gl.Response, error) {
+			calls++
+			if page == 2 {
+				return nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("boom")
+			}
+			return []int{1}, &gl.Response{Response: &http.Response{StatusCode: 200}, NextPage: 2}, nil
+		})
+		require.Error(t, err)
+		assert.Nil(t, p)
+		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestCollectKeysetPages(t *testing.T) {
+	// fetch serves items 1 to 5, the link of a page being the last item before it
+	fetch := func(requested *[]string) func(link string, perPage int) ([]int, *gl.Response, error) {
+		return func(link string, perPage int) ([]int, *gl.Response, error) {
+			*requested = append(*requested, fmt.Sprintf("%s/%d", link, perPage))
+			after, _ := strconv.Atoi(link)
+			var items []int
+			for i := after + 1; i <= 5 && len(items) < perPage; i++ {
+				items = append(items, i)
+			}
+			resp := &gl.Response{Response: &http.Response{StatusCode: 200}}
+			if last := after + len(items); last < 5 {
+				resp.NextLink = strconv.Itoa(last)
+			}
+			return items, resp, nil
+		}
+	}
+
+	t.Run("Single Page Exposes Cursor", func(t *testing.T) {
+		var requested []string
+		items, p, _, err := collectKeysetPages(ListParams{PerPage: 2}, fetch(&requested))
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, items)
+		assert.True(t, p.HasMore)
+		assert.Equal(t, "2", p.NextCursor)
+		assert.Zero(t, p.Page)
+	})
+
+	t.Run("Walk Follows Next Links", func(t *testing.T) {
+		var requested []string
+		items, p, _, err := collectKeysetPages(ListParams{PerPage: 2, MaxItems: 10}, fetch(&requested))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/2", "2/2", "4/2"}, requested)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+		assert.False(t, p.HasMore)
+		assert.Empty(t, p.NextCursor)
+	})
+
+	t.Run("Last Page Shrunk To Cap", func(t *testing.T) {
+		var requested []string
+		items, p, _, err := collectKeysetPages(ListParams{PerPage: 2, MaxItems: 3}, fetch(&requested))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/2", "2/1"}, requested)
+		assert.Equal(t, []int{1, 2, 3}, items)
+		assert.True(t, p.HasMore)
+		assert.Equal(t, "3", p.NextCursor)
+	})
+
+	t.Run("Cursor Resumes After First Page Cut", func(t *testing.T) {
+		var requested []string
+		items, p, _, err := collectKeysetPages(ListParams{PerPage: 1, MaxItems: 1}, fetch(&requested))
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, items)
+		require.Equal(t, "1", p.NextCursor)
+
+		items, _, _, err = collectKeysetPages(ListParams{PerPage: 2, Cursor: p.NextCursor}, fetch(&requested))
+		require.NoError(t, err)
+		assert.Equal(t, []int{2, 3}, items, "resuming should neither skip nor repeat items")
+	})
+}
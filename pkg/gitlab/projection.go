@@ -0,0 +1,420 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	gl "gitlab.com/gitlab-org/api/client-go" // GitLab client library
+)
+
+// resourceSchema describes how a GitLab resource type is presented: the compact set of
+// fields returned when the caller does not pass 'fields', and the heading of its card
+// in markdown output. List schemas leave out long free-text fields.
+type resourceSchema struct {
+	// model is a value of the GitLab client type the resource is decoded into; tool
+	// output schemas are derived from it.
+	model any
+	// fields is nil for resources returned whole by default.
+	fields []string
+	// title is a heading template in which each {path} is replaced by the value at
+	// that dotted path, e.g. "#{iid} {title}".
+	title string
+}
+
+var (
+	projectSchema = resourceSchema{
+		model: gl.Project{},
+		fields: []string{
+			"id", "name", "path_with_namespace", "description", "visibility", "default_branch",
+			"archived", "star_count", "forks_count", "open_issues_count", "last_activity_at", "web_url",
+		},
+		title: "{path_with_namespace}",
+	}
+	groupSchema = resourceSchema{
+		model: gl.Group{},
+		fields: []string{
+			"id", "name", "full_path", "description", "visibility", "parent_id", "created_at", "web_url",
+		},
+		title: "{full_path}",
+	}
+	groupDetailSchema = resourceSchema{
+		model: gl.Group{},
+		fields: slices.Concat(groupSchema.fields, []string{
+			"full_name", "default_branch", "request_access_enabled", "require_two_factor_authentication",
+			"project_creation_level", "subgroup_creation_level", "shared_with_groups", "marked_for_deletion_on",
+		}),
+		title: groupSchema.title,
+	}
+	groupMemberSchema = resourceSchema{
+		model: gl.GroupMember{},
+		fields: []string{
+			"id", "username", "name", "state", "access_level", "expires_at", "web_url",
+		},
+		title: "{username}",
+	}
+	userSchema = resourceSchema{
+		model:  gl.User{},
+		fields: []string{"id", "username", "name", "state", "bot", "avatar_url", "web_url"},
+		title:  "{username}",
+	}
+	userDetailSchema = resourceSchema{
+		model: gl.User{},
+		fields: slices.Concat(userSchema.fields, []string{
+			"created_at", "bio", "location", "public_email", "organization", "job_title",
+			"last_activity_on", "is_admin", "external",
+		}),
+		title: userSchema.title,
+	}
+	currentUserSchema = resourceSchema{
+		model: currentUser{},
+		fields: []string{
+			"user.id", "user.username", "user.name", "user.email", "user.state", "user.is_admin", "user.bot",
+			"user.can_create_group", "user.can_create_project", "user.web_url",
+			"token.name", "token.scopes", "token.active", "token.expires_at",
+		},
+		title: "{user.username}",
+	}
+	userStatusSchema        = resourceSchema{model: gl.UserStatus{}, title: "{availability} {emoji} {message}"}
+	contributionEventSchema = resourceSchema{
+		model: gl.ContributionEvent{},
+		fields: []string{
+			"id", "action_name", "target_type", "target_iid", "target_title", "project_id", "created_at",
+			"push_data.ref", "push_data.commit_count", "push_data.commit_title", "note.body", "note.noteable_type", "note.noteable_iid",
+		},
+		title: "{created_at} {action_name} {target_type} {target_title}",
+	}
+	issueSchema = resourceSchema{
+		model: gl.Issue{},
+		fields: []string{
+			"id", "iid", "project_id", "title", "state", "labels", "author.username", "assignees.username",
+			"milestone.title", "due_date", "user_notes_count", "created_at", "updated_at", "closed_at", "web_url",
+		},
+		title: "#{iid} {title}",
+	}
+	issueDetailSchema = resourceSchema{
+		model: gl.Issue{},
+		fields: slices.Concat(issueSchema.fields, []string{
+			"description", "confidential", "weight", "closed_by.username", "merge_requests_count", "time_stats",
+		}),
+		title: issueSchema.title,
+	}
+	mergeRequestSchema = resourceSchema{
+		model: gl.BasicMergeRequest{},
+		fields: []string{
+			"id", "iid", "project_id", "title", "state", "draft", "author.username", "assignees.username",
+			"reviewers.username", "source_branch", "target_branch", "labels", "detailed_merge_status",
+			"created_at", "updated_at", "merged_at", "web_url",
+		},
+		title: "!{iid} {title}",
+	}
+	mergeRequestDetailSchema = resourceSchema{
+		model: gl.MergeRequest{},
+		fields: slices.Concat(mergeRequestSchema.fields, []string{
+			"description", "sha", "merge_commit_sha", "squash_commit_sha", "has_conflicts", "changes_count",
+			"merged_by.username", "head_pipeline.id", "head_pipeline.status", "diff_refs",
+		}),
+		title: mergeRequestSchema.title,
+	}
+	noteSchema = resourceSchema{
+		model: gl.Note{},
+		fields: []string{
+			"id", "type", "body", "author.username", "author.name", "system", "resolvable", "resolved",
+			"position.new_path", "position.new_line", "position.old_path", "position.old_line", "created_at", "updated_at",
+		},
+		title: "{author.username} at {created_at}",
+	}
+	milestoneSchema = resourceSchema{
+		model: gl.Milestone{},
+		fields: []string{
+			"id", "iid", "project_id", "group_id", "title", "state", "start_date", "due_date", "expired", "web_url",
+		},
+		title: "%{title}",
+	}
+	searchBlobSchema = resourceSchema{model: searchBlob{}, title: "{path}:{startline}"}
+	searchNoteSchema = resourceSchema{
+		model: gl.Note{},
+		fields: []string{
+			"id", "body", "author.username", "project_id", "noteable_type", "noteable_iid", "created_at",
+		},
+		title: noteSchema.title,
+	}
+	vulnerabilitySchema = resourceSchema{
+		model: vulnerability{},
+		fields: []string{
+			"id", "title", "severity", "state", "report_type", "scanner.external_id", "primary_identifier.name",
+			"location", "detected_at", "web_url",
+		},
+		title: "{severity} {title}",
+	}
+	vulnerabilityDetailSchema = resourceSchema{
+		model: vulnerability{},
+		fields: []string{
+			"id", "title", "description", "severity", "state", "report_type", "scanner", "primary_identifier",
+			"identifiers", "location", "solution", "dismissal_reason", "detected_at", "confirmed_at",
+			"resolved_at", "dismissed_at", "project.full_path", "web_url",
+		},
+		title: vulnerabilitySchema.title,
+	}
+	securityReportSummarySchema = resourceSchema{model: securityReportSummary{}}
+	dependencySchema            = resourceSchema{
+		model: dependency{},
+		fields: []string{
+			"name", "version", "package_manager", "dependency_file_path", "vulnerabilities_count", "licenses.name",
+		},
+		title: "{name} {version}",
+	}
+	groupDependenciesSchema = resourceSchema{
+		model: groupDependencies{},
+		fields: []string{
+			"dependencies.project", "dependencies.name", "dependencies.version", "dependencies.package_manager",
+			"dependencies.dependency_file_path", "dependencies.vulnerabilities_count", "dependencies.licenses.name",
+			"projects_scanned", "projects_failed", "truncated",
+		},
+	}
+	licenseViolationsSchema = resourceSchema{model: licenseViolations{}}
+	branchSchema            = resourceSchema{
+		model: gl.Branch{},
+		fields: []string{
+			"name", "commit.id", "commit.title", "commit.author_name", "commit.committed_date",
+			"protected", "default", "merged", "developers_can_push", "developers_can_merge", "web_url",
+		},
+		title: "{name}",
+	}
+	protectedBranchSchema = resourceSchema{model: gl.ProtectedBranch{}, title: "{name}"}
+	commitSchema          = resourceSchema{
+		model: gl.Commit{},
+		fields: []string{
+			"id", "short_id", "title", "author_name", "author_email", "authored_date", "committed_date",
+			"parent_ids", "stats", "web_url",
+		},
+		title: "{short_id} {title}",
+	}
+	commitRefSchema    = resourceSchema{model: gl.CommitRef{}, title: "{type} {name}"}
+	commitStatusSchema = resourceSchema{model: gl.CommitStatus{}, title: "{name}: {status}"}
+	treeNodeSchema     = resourceSchema{model: gl.TreeNode{}, title: "{path}"}
+	tagSchema          = resourceSchema{
+		model: gl.Tag{},
+		fields: []string{
+			"name", "message", "target", "protected", "created_at",
+			"commit.id", "commit.title", "commit.committed_date", "release.tag_name",
+		},
+		title: "{name}",
+	}
+	releaseSchema = resourceSchema{
+		model: gl.Release{},
+		fields: []string{
+			"tag_name", "name", "created_at", "released_at", "upcoming_release", "author.username",
+			"commit.id", "assets.links.name", "assets.links.url", "_links.self",
+		},
+		title: "{tag_name} {name}",
+	}
+	releaseDetailSchema = resourceSchema{
+		model:  gl.Release{},
+		fields: slices.Concat(releaseSchema.fields, []string{"description", "milestones.title"}),
+		title:  releaseSchema.title,
+	}
+)
+
+// fieldSet is a parsed field projection: a tree of JSON keys where an empty
+// (non-nil) subtree selects the whole value. A nil fieldSet selects everything.
+type fieldSet map[string]fieldSet
+
+// parseFields builds a fieldSet from dotted paths such as "author.username".
+func parseFields(paths []string) fieldSet {
+	fs := fieldSet{}
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		node := fs
+		segments := strings.Split(path, ".")
+		for i, seg := range segments {
+			child, ok := node[seg]
+			if ok && len(child) == 0 {
+				break // A shorter path already selects the whole value
+			}
+			if !ok {
+				child = fieldSet{}
+				node[seg] = child
+			}
+			if i == len(segments)-1 {
+				clear(child) // The full value overrides narrower selections made earlier
+			}
+			node = child
+		}
+	}
+	return fs
+}
+
+// apply keeps only the selected keys of v. Arrays are traversed transparently, so
+// "assignees.username" keeps the username of every assignee.
+func (fs fieldSet) apply(v any) any {
+	if len(fs) == 0 {
+		return v
+	}
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(fs))
+		for key, sub := range fs {
+			if child, ok := val[key]; ok {
+				out[key] = sub.apply(child)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = fs.apply(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// project converts v to its generic JSON form and applies the projection.
+func (fs fieldSet) project(v any) (any, error) {
+	if fs == nil {
+		return v, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return fs.apply(generic), nil
+}
+
+// outputOptions holds the parsed 'fields', 'format' and 'continuation' parameters of a
+// tool call, along with its response budget.
+type outputOptions struct {
+	fields fieldSet
+	// paths lists the selected fields in the order given, nil when all fields are returned.
+	paths  []string
+	format string
+	title  string
+	// tool, budget and continuation drive the truncation of oversized list results.
+	tool         string
+	budget       int
+	continuation continuation
+}
+
+// WithOutput returns a ToolOption adding the 'fields' projection and 'format' parameters
+// to a tool returning a single resource described by schema, and declaring its output schema.
+func WithOutput(schema resourceSchema) mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		WithFields(schema.fields)(t)
+		WithOutputFormat()(t)
+		mcp.WithRawOutputSchema(mustMarshalSchema(objectSchema(schema.model, parseSchemaFields(schema))))(t)
+	}
+}
+
+// WithListOutput is the list tool counterpart of WithOutput: the declared output schema is
+// the items and pagination envelope returned by newListResult.
+func WithListOutput(schema resourceSchema) mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		WithFields(schema.fields)(t)
+		WithOutputFormat()(t)
+		WithContinuation()(t)
+		mcp.WithRawOutputSchema(mustMarshalSchema(listSchema(schema.model, parseSchemaFields(schema))))(t)
+	}
+}
+
+// parseSchemaFields returns the fieldSet of the schema's default fields, nil when the
+// resource is returned whole.
+func parseSchemaFields(schema resourceSchema) fieldSet {
+	if schema.fields == nil {
+		return nil
+	}
+	return parseFields(schema.fields)
+}
+
+// WithFields returns a ToolOption adding the 'fields' projection parameter. defaults
+// lists the fields returned when the parameter is omitted; nil means all fields.
+func WithFields(defaults []string) mcp.ToolOption {
+	defaultDesc := "all fields"
+	if defaults != nil {
+		defaultDesc = strings.Join(defaults, ",")
+	}
+	return mcp.WithString("fields",
+		mcp.Description(fmt.Sprintf("Comma-separated dotted paths of the fields to return (e.g., 'iid,title,author.username'); "+
+			"paths through arrays apply to every element. Use 'all' for the complete GitLab object. Default: %s.", defaultDesc)),
+	)
+}
+
+// optionalOutputParams parses the 'fields', 'format' and 'continuation' parameters, falling
+// back to the schema's default fields when 'fields' is absent.
+func optionalOutputParams(ctx context.Context, r *mcp.CallToolRequest, schema resourceSchema) (outputOptions, error) {
+	spec, err := OptionalParam[string](r, "fields")
+	if err != nil {
+		return outputOptions{}, err
+	}
+	format, err := optionalFormatParam(r)
+	if err != nil {
+		return outputOptions{}, err
+	}
+	cont, err := optionalContinuationParam(r)
+	if err != nil {
+		return outputOptions{}, err
+	}
+	out := outputOptions{
+		format:       format,
+		title:        schema.title,
+		tool:         r.Params.Name,
+		budget:       responseBudget(ctx),
+		continuation: cont,
+	}
+
+	var paths []string
+	switch spec = strings.TrimSpace(spec); spec {
+	case "":
+		paths = schema.fields
+	case "all", "*":
+		// Leave paths nil so the whole object is returned
+	default:
+		for _, path := range strings.Split(spec, ",") {
+			if path = strings.TrimSpace(path); path != "" {
+				paths = append(paths, path)
+			}
+		}
+	}
+	if paths != nil {
+		out.fields, out.paths = parseFields(paths), paths
+	}
+	return out, nil
+}
+
+// newProjectedResult converts v, reduced to the selected fields, into a tool result in the
+// requested format. what names the resource in the error message.
+func newProjectedResult(v any, output outputOptions, what string) (*mcp.CallToolResult, error) {
+	projected, err := output.fields.project(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", what, err)
+	}
+	if output.rendered() {
+		text, err := renderObject(projected, output)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", what, err)
+		}
+		return mcp.NewToolResultStructured(projected, text), nil
+	}
+	return newJSONResult(projected, what)
+}
+
+// newJSONResult returns v as structured content together with its JSON encoding as text,
+// for clients that do not read structured content. what names the resource in the error message.
+func newJSONResult(v any, what string) (*mcp.CallToolResult, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", what, err)
+	}
+	return mcp.NewToolResultStructured(v, string(data)), nil
+}
@@ -0,0 +1,155 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestFieldSetProject(t *testing.T) {
+	source := map[string]any{
+		"iid":   7,
+		"title": "Fix login",
+		"author": map[string]any{
+			"username":   "alice",
+			"avatar_url": "https://example.com/a.png",
+		},
+		"assignees": []any{
+			map[string]any{"username": "bob", "id": 2},
+			map[string]any{"username": "carol", "id": 3},
+		},
+		"_links": map[string]any{"self": "https://example.com"},
+	}
+
+	tests := []struct {
+		name     string
+		paths    []string
+		expected string
+	}{
+		{
+			name:     "Top Level And Nested Paths",
+			paths:    []string{"iid", "author.username"},
+			expected: `{"iid":7,"author":{"username":"alice"}}`,
+		},
+		{
+			name:     "Paths Through Arrays",
+			paths:    []string{"assignees.username"},
+			expected: `{"assignees":[{"username":"bob"},{"username":"carol"}]}`,
+		},
+		{
+			name:     "Whole Value Wins Over Narrower Path",
+			paths:    []string{"author.username", "author", "author.avatar_url"},
+			expected: `{"author":{"username":"alice","avatar_url":"https://example.com/a.png"}}`,
+		},
+		{
+			name:     "Missing Fields Are Omitted",
+			paths:    []string{"title", "milestone.title", " "},
+			expected: `{"title":"Fix login"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			projected, err := parseFields(tc.paths).project(source)
+			require.NoError(t, err)
+			data, err := json.Marshal(projected)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(data))
+		})
+	}
+
+	t.Run("Nil Field Set Keeps Everything", func(t *testing.T) {
+		var fs fieldSet
+		projected, err := fs.project(source)
+		require.NoError(t, err)
+		assert.Equal(t, source, projected)
+	})
+}
+
+func TestOptionalOutputParams(t *testing.T) {
+	schema := resourceSchema{fields: []string{"iid", "title"}, title: "#{iid} {title}"}
+
+	req := createCallToolRequest("listSomething", map[string]any{})
+	out, err := optionalOutputParams(context.Background(), &req, schema)
+	require.NoError(t, err)
+	assert.Equal(t, parseFields(schema.fields), out.fields)
+	assert.Equal(t, schema.fields, out.paths)
+	assert.Equal(t, FormatJSON, out.format)
+	assert.Equal(t, schema.title, out.title)
+
+	req = createCallToolRequest("listSomething", map[string]any{"fields": "all", "format": "table"})
+	out, err = optionalOutputParams(context.Background(), &req, schema)
+	require.NoError(t, err)
+	assert.Nil(t, out.fields)
+	assert.Nil(t, out.paths)
+	assert.Equal(t, FormatTable, out.format)
+
+	req = createCallToolRequest("listSomething", map[string]any{"fields": "iid, author.username"})
+	out, err = optionalOutputParams(context.Background(), &req, schema)
+	require.NoError(t, err)
+	assert.Equal(t, fieldSet{"iid": {}, "author": {"username": {}}}, out.fields)
+	assert.Equal(t, []string{"iid", "author.username"}, out.paths)
+
+	req = createCallToolRequest("listSomething", map[string]any{"fields": 3})
+	_, err = optionalOutputParams(context.Background(), &req, schema)
+	assert.Error(t, err)
+
+	req = createCallToolRequest("listSomething", map[string]any{"format": "yaml"})
+	_, err = optionalOutputParams(context.Background(), &req, schema)
+	assert.ErrorContains(t, err, "invalid 'format' parameter")
+}
+
+func TestGetProjectHandlerFields(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockProjects, ctrl := setupMockClient(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	getProjectTool, getProjectHandler := GetProject(mockGetClient)
+
+	project := &gl.Project{
+		ID:                1,
+		Name:              "project",
+		PathWithNamespace: "group/project",
+		AvatarURL:         "https://example.com/avatar.png",
+		Namespace:         &gl.ProjectNamespace{ID: 9, FullPath: "group"},
+	}
+	mockProjects.EXPECT().
+		GetProject("group/project", gomock.Any(), gomock.Any()).
+		Return(project, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil).
+		Times(3)
+
+	t.Run("Default Compact Schema", func(t *testing.T) {
+		result, err := getProjectHandler(ctx, createCallToolRequest(getProjectTool.Name, map[string]any{"projectId": "group/project"}))
+		require.NoError(t, err)
+		var actual map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &actual))
+		assert.Equal(t, "group/project", actual["path_with_namespace"])
+		assert.NotContains(t, actual, "avatar_url")
+		assert.NotContains(t, actual, "namespace")
+	})
+
+	t.Run("Explicit Fields", func(t *testing.T) {
+		args := map[string]any{"projectId": "group/project", "fields": "id,namespace.full_path"}
+		result, err := getProjectHandler(ctx, createCallToolRequest(getProjectTool.Name, args))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id":1,"namespace":{"full_path":"group"}}`, getTextResult(t, result).Text)
+	})
+
+	t.Run("All Fields", func(t *testing.T) {
+		args := map[string]any{"projectId": "group/project", "fields": "all"}
+		result, err := getProjectHandler(ctx, createCallToolRequest(getProjectTool.Name, args))
+		require.NoError(t, err)
+		expected, _ := json.Marshal(project)
+		assert.JSONEq(t, string(expected), getTextResult(t, result).Text)
+	})
+}
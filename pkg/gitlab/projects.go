@@ -2,9 +2,7 @@ package gitlab
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -20,12 +18,13 @@ func GetProject(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandle
 			mcp.WithDescription("Retrieves details for a specific GitLab project."),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        "Get Project Details",
-				ReadOnlyHint: true,
+				ReadOnlyHint: mcp.ToBoolPtr(true),
 			}),
 			mcp.WithString("projectId",
 				mcp.Required(),
 				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
 			),
+			WithOutput(projectSchema),
 		),
 
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -34,6 +33,10 @@ func GetProject(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandle
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
+			output, err := optionalOutputParams(ctx, &request, projectSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
 			// --- Obtain GitLab client
 			glClient, err := getClient(ctx)
 			if err != nil {
@@ -46,23 +49,14 @@ func GetProject(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandle
 
 			// --- Handle API errors
 			if err != nil {
-				code := http.StatusInternalServerError
-				if resp != nil {
-					code = resp.StatusCode
-				}
-				if code == http.StatusNotFound {
-					msg := fmt.Sprintf("project %q not found or access denied (%d)", projectIDStr, code)
-					return mcp.NewToolResultError(msg), nil
-				}
-				return nil, fmt.Errorf("failed to get project %q: %w (status: %d)", projectIDStr, err, code)
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get project %q", projectIDStr),
+					fmt.Sprintf("project %q not found or access denied", projectIDStr),
+				), nil
 			}
 
 			// --- Marshal and return success
-			data, err := json.Marshal(project)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal project data: %w", err)
-			}
-			return mcp.NewToolResultText(string(data)), nil
+			return newProjectedResult(project, output, "project data")
 		} // End handler func assignment
 }
 
@@ -73,7 +67,7 @@ func ListProjects(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHand
 			mcp.WithDescription("Retrieves a list of projects based on specified criteria."),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        "List Projects",
-				ReadOnlyHint: true,
+				ReadOnlyHint: mcp.ToBoolPtr(true),
 			}),
 			// GitLab API ListProjectsOptions parameters
 			mcp.WithString("search", mcp.Description("Return list of projects matching the search criteria.")),
@@ -85,7 +79,7 @@ func ListProjects(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHand
 				mcp.Enum("public", "internal", "private"),
 			),
 			mcp.WithString("orderBy",
-				mcp.Description("Return projects ordered by field."),
+				mcp.Description("Return projects ordered by field. When walking pages with 'all' or 'max_items', defaults to 'id' so keyset pagination is used."),
 				mcp.Enum("id", "name", "path", "created_at", "updated_at", "last_activity_at"), // Add more if needed
 			),
 			mcp.WithString("sort",
@@ -94,6 +88,8 @@ func ListProjects(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHand
 			),
 			// Add standard MCP pagination parameters
 			WithPagination(),
+			WithKeysetPagination(),
+			WithListOutput(projectSchema),
 		),
 		// Handler function implementation
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -126,16 +122,24 @@ func ListProjects(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHand
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
-			page, perPage, err := OptionalPaginationParams(&request)
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, projectSchema)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
+			// GitLab only supports keyset pagination for projects ordered by id
+			keyset := (listParams.MaxItems > 0 || listParams.Cursor != "") && (orderByVal == "" || orderByVal == "id")
+			if listParams.Cursor != "" && !keyset {
+				return mcp.NewToolResultError("Validation Error: 'cursor' can only be used when ordering by 'id'"), nil
+			}
 
 			// --- Construct GitLab API options
 			opts := &gl.ListProjectsOptions{
 				ListOptions: gl.ListOptions{
-					Page:    page,
-					PerPage: perPage,
+					PerPage: listParams.PerPage,
 				},
 				// Assign pointers only if the value was actually provided
 				Membership: membershipVal,
@@ -150,6 +154,13 @@ func ListProjects(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHand
 				vis := gl.VisibilityValue(visibilityValStr)
 				opts.Visibility = &vis
 			}
+			if keyset {
+				opts.Pagination = "keyset"
+				orderByVal = "id"
+				if sortVal == "" {
+					sortVal = "asc"
+				}
+			}
 			if orderByVal != "" {
 				opts.OrderBy = &orderByVal
 			}
@@ -164,28 +175,32 @@ func ListProjects(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHand
 			}
 
 			// --- Call GitLab API
-			projects, resp, err := glClient.Projects.ListProjects(opts, gl.WithContext(ctx))
+			var projects []*gl.Project
+			var pagination *Pagination
+			var resp *gl.Response
+			if keyset {
+				projects, pagination, resp, err = collectKeysetPages(listParams, func(link string, perPage int) ([]*gl.Project, *gl.Response, error) {
+					opts.PerPage = perPage
+					reqOpts := []gl.RequestOptionFunc{gl.WithContext(ctx)}
+					if link != "" {
+						reqOpts = append(reqOpts, gl.WithKeysetPaginationParameters(link), withPerPage(perPage))
+					}
+					return glClient.Projects.ListProjects(opts, reqOpts...)
+				})
+			} else {
+				projects, pagination, resp, err = collectPages(listParams, func(page int) ([]*gl.Project, *gl.Response, error) {
+					opts.Page = page
+					return glClient.Projects.ListProjects(opts, gl.WithContext(ctx))
+				})
+			}
 
 			// --- Handle API errors
 			if err != nil {
-				code := http.StatusInternalServerError
-				if resp != nil {
-					code = resp.StatusCode
-				}
-				// Don't return specific 404-like errors here, as an empty list is a valid result
-				return nil, fmt.Errorf("failed to list projects: %w (status: %d)", err, code)
+				// No resource to report for 404s, as an empty list is a valid result
+				return apiErrorResult(err, resp, "failed to list projects", "failed to list projects"), nil
 			}
 
 			// --- Marshal and return success
-			// Handle empty list gracefully
-			if len(projects) == 0 {
-				return mcp.NewToolResultText("[]"), nil // Return empty JSON array
-			}
-
-			data, err := json.Marshal(projects)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal project list data: %w", err)
-			}
-			return mcp.NewToolResultText(string(data)), nil
+			return newListResult(projects, pagination, output, "project list data")
 		}
 }
@@ -113,10 +113,9 @@ func TestGetProjectHandler(t *testing.T) {
 					GetProject(projectIDStr, gomock.Any(), gomock.Any()). // Expect string ID
 					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
 			},
-			expectedResult:     nil,  // No result content expected when handler errors
-			expectHandlerError: true, // Handler returns an actual error
-			expectResultError:  true, // Result is nil due to handler error
-			errorContains:      "failed to get project \"123\": gitlab: 500 Internal Server Error",
+			expectedResult:     "failed to get project \"123\": GitLab server error (500), retry later: gitlab: 500 Internal Server Error",
+			expectHandlerError: false, // Handler returns error within the result
+			expectResultError:  true,  // The result itself represents an error
 		},
 		{
 			name:               "Error - Missing projectId parameter",
@@ -151,13 +150,7 @@ func TestGetProjectHandler(t *testing.T) {
 
 			// Create the request using the correct structure
 			req := mcp.CallToolRequest{
-				Params: struct {
-					Name      string                 `json:"name"`
-					Arguments map[string]interface{} `json:"arguments,omitempty"`
-					Meta      *struct {
-						ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-					} `json:"_meta,omitempty"`
-				}{
+				Params: mcp.CallToolParams{
 					Name:      getProjectTool.Name,
 					Arguments: tc.inputArgs,
 				},
@@ -336,9 +329,9 @@ func TestListProjectsHandler(t *testing.T) {
 					ListProjects(gomock.Any(), gomock.Any()). // Match any options
 					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
 			},
-			expectedResult:     nil,
-			expectHandlerError: true,
-			errorContains:      "failed to list projects: gitlab: 500 Internal Server Error",
+			expectedResult:    nil,
+			expectResultError: true,
+			errorContains:     "failed to list projects: GitLab server error (500), retry later: gitlab: 500 Internal Server Error",
 		},
 		{
 			name:               "Error - Invalid Page Type",
@@ -360,13 +353,7 @@ func TestListProjectsHandler(t *testing.T) {
 
 			// --- Create Request ---
 			req := mcp.CallToolRequest{
-				Params: struct {
-					Name      string                 `json:"name"`
-					Arguments map[string]interface{} `json:"arguments,omitempty"`
-					Meta      *struct {
-						ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-					} `json:"_meta,omitempty"`
-				}{
+				Params: mcp.CallToolParams{
 					Name:      listProjectsTool.Name,
 					Arguments: tc.inputArgs,
 				},
@@ -389,8 +376,9 @@ func TestListProjectsHandler(t *testing.T) {
 					assert.Contains(t, textContent.Text, tc.errorContains, "Error message mismatch")
 				} else {
 					// Unmarshal expected and actual results
+					items, _ := getListResult(t, result)
 					var actualProjects []*gl.Project
-					err = json.Unmarshal([]byte(textContent.Text), &actualProjects)
+					err = json.Unmarshal([]byte(items), &actualProjects)
 					require.NoError(t, err, "Failed to unmarshal actual result JSON")
 
 					// Compare lengths first
@@ -405,3 +393,62 @@ func TestListProjectsHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestListProjectsHandlerKeyset(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockProjects, ctrl := setupMockClient(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	listProjectsTool, listProjectsHandler := ListProjects(mockGetClient)
+
+	nextLink := "https://gitlab.example.com/api/v4/projects?id_after=2&order_by=id&pagination=keyset&per_page=2&sort=asc"
+
+	t.Run("Success - Walks Pages With Keyset Pagination", func(t *testing.T) {
+		gomock.InOrder(
+			mockProjects.EXPECT().
+				ListProjects(gomock.AssignableToTypeOf(&gl.ListProjectsOptions{}), gomock.Any()).
+				DoAndReturn(func(opts *gl.ListProjectsOptions, reqOpts ...gl.RequestOptionFunc) ([]*gl.Project, *gl.Response, error) {
+					assert.Equal(t, "keyset", opts.Pagination)
+					assert.Equal(t, "id", *opts.OrderBy)
+					assert.Equal(t, "asc", *opts.Sort)
+					assert.Equal(t, 2, opts.PerPage)
+					assert.Len(t, reqOpts, 1, "first page should not carry a keyset link")
+					return []*gl.Project{{ID: 1}, {ID: 2}}, &gl.Response{Response: &http.Response{StatusCode: 200}, NextLink: nextLink}, nil
+				}),
+			mockProjects.EXPECT().
+				ListProjects(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return([]*gl.Project{{ID: 3}}, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil),
+		)
+
+		result, err := listProjectsHandler(ctx, createCallToolRequest(listProjectsTool.Name, map[string]any{"all": true, "per_page": 2}))
+		require.NoError(t, err)
+		items, pagination := getListResult(t, result)
+		var actual []*gl.Project
+		require.NoError(t, json.Unmarshal([]byte(items), &actual))
+		assert.Len(t, actual, 3)
+		assert.Equal(t, 2, pagination.PagesFetched)
+		assert.False(t, pagination.HasMore)
+	})
+
+	t.Run("Success - Single Page Returns Cursor", func(t *testing.T) {
+		mockProjects.EXPECT().
+			ListProjects(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*gl.Project{{ID: 3}}, &gl.Response{Response: &http.Response{StatusCode: 200}, NextLink: nextLink}, nil)
+
+		result, err := listProjectsHandler(ctx, createCallToolRequest(listProjectsTool.Name, map[string]any{"cursor": nextLink}))
+		require.NoError(t, err)
+		_, pagination := getListResult(t, result)
+		assert.True(t, pagination.HasMore)
+		assert.Equal(t, nextLink, pagination.NextCursor)
+	})
+
+	t.Run("Error - Cursor With Non-ID Ordering", func(t *testing.T) {
+		result, err := listProjectsHandler(ctx, createCallToolRequest(listProjectsTool.Name, map[string]any{"cursor": nextLink, "orderBy": "name"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "'cursor' can only be used when ordering by 'id'")
+	})
+}
@@ -0,0 +1,398 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	gl "gitlab.com/gitlab-org/api/client-go" // GitLab client library
+)
+
+// Limits of the data assembled into prompt messages, which clients send to a model as is.
+const (
+	// maxPromptDataBytes caps each block of data, such as a tool result or a file.
+	maxPromptDataBytes = 50000
+	// maxPromptFailedJobs caps the failed jobs whose log is included in a pipeline summary.
+	maxPromptFailedJobs = 5
+	// maxPromptJobLogBytes caps the tail of each failed job log.
+	maxPromptJobLogBytes = 8000
+	// promptListSize is the number of issues or commits listed in a prompt.
+	promptListSize = 30
+)
+
+// jobLogControlPattern matches the ANSI escape sequences and section markers of job logs.
+var jobLogControlPattern = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]|section_(?:start|end):\d+:[^\r\n]*\r|\r`)
+
+// ReviewMergeRequestPrompt defines the prompt to review a merge request with its diffs and discussion.
+func ReviewMergeRequestPrompt(getClient GetClientFn) (prompt mcp.Prompt, handler server.PromptHandlerFunc) {
+	return mcp.NewPrompt("review_merge_request",
+			mcp.WithPromptDescription("Review a merge request: its description, the diffs of its files and the discussion so far."),
+			mcp.WithArgument("projectId", mcp.RequiredArgument(), mcp.ArgumentDescription("The ID or path of the project (e.g., 'group/project').")),
+			mcp.WithArgument("mergeRequestIid", mcp.RequiredArgument(), mcp.ArgumentDescription("The IID of the merge request within the project.")),
+		),
+		func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			projectID, err := promptArgument(request, "projectId", true)
+			if err != nil {
+				return nil, err
+			}
+			iid, err := promptIDArgument(request, "mergeRequestIid")
+			if err != nil {
+				return nil, err
+			}
+
+			_, readMergeRequest := MergeRequestResource(getClient)
+			mr, err := readPromptResource(ctx, readMergeRequest,
+				fmt.Sprintf("gitlab://%s/-/merge_requests/%d", projectID, iid),
+				map[string]any{"project": []string{projectID}, "iid": []string{strconv.Itoa(iid)}})
+			if err != nil {
+				return nil, err
+			}
+			comments, err := callPromptTool(ctx, GetMergeRequestComments, getClient, map[string]any{
+				"projectId": projectID, "mergeRequestIid": float64(iid), "per_page": float64(MaxPerPage),
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return mcp.NewGetPromptResult(
+				fmt.Sprintf("Review of merge request !%d in %s", iid, projectID),
+				[]mcp.PromptMessage{
+					userMessage(fmt.Sprintf("Review merge request !%d of project %s. The merge request with the diffs of its files, "+
+						"then the discussion so far, follow.\n\n"+
+						"Point out bugs, security issues, risky or breaking changes, missing tests and unclear code, citing the file "+
+						"path and line of each finding. Mention which comments of the discussion are still unaddressed. Finish with a "+
+						"verdict: approve, approve with minor suggestions, or request changes.", iid, projectID)),
+					mcp.NewPromptMessage(mcp.RoleUser, mcp.NewEmbeddedResource(mr)),
+					userMessage("Discussion of the merge request:\n\n" + comments),
+				},
+			), nil
+		}
+}
+
+// TriageIssuesPrompt defines the prompt to triage the latest open issues of a project.
+func TriageIssuesPrompt(getClient GetClientFn) (prompt mcp.Prompt, handler server.PromptHandlerFunc) {
+	return mcp.NewPrompt("triage_issues",
+			mcp.WithPromptDescription(fmt.Sprintf("Triage the newest open issues of a project (at most %d): labels, priority, duplicates and missing information.", promptListSize)),
+			mcp.WithArgument("projectId", mcp.RequiredArgument(), mcp.ArgumentDescription("The ID or path of the project (e.g., 'group/project').")),
+			mcp.WithArgument("createdAfter", mcp.ArgumentDescription("Only triage issues created after this date (ISO 8601, e.g., '2025-05-01T00:00:00Z').")),
+		),
+		func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			projectID, err := promptArgument(request, "projectId", true)
+			if err != nil {
+				return nil, err
+			}
+			createdAfter, err := promptArgument(request, "createdAfter", false)
+			if err != nil {
+				return nil, err
+			}
+
+			args := map[string]any{
+				"projectId": projectID, "state": "opened", "orderBy": "created_at", "sort": "desc",
+				"per_page": float64(promptListSize),
+			}
+			if createdAfter != "" {
+				args["createdAfter"] = createdAfter
+			}
+			issues, err := callPromptTool(ctx, ListIssues, getClient, args)
+			if err != nil {
+				return nil, err
+			}
+
+			return mcp.NewGetPromptResult(
+				fmt.Sprintf("Triage of the open issues of %s", projectID),
+				[]mcp.PromptMessage{
+					userMessage(fmt.Sprintf("Triage the newest open issues of project %s, listed below.\n\n"+
+						"For each issue, give a one-line summary, suggest labels (type, area) and a priority (critical, high, "+
+						"medium or low) with a short justification, and flag likely duplicates among them and issues lacking the "+
+						"information needed to act on them, saying what to ask the author. Finish with a table of the issues ordered "+
+						"by priority.", projectID)),
+					userMessage("Open issues:\n\n" + issues),
+				},
+			), nil
+		}
+}
+
+// SummarizePipelineFailurePrompt defines the prompt to explain why a pipeline failed, from
+// the logs of its failed jobs.
+func SummarizePipelineFailurePrompt(getClient GetClientFn) (prompt mcp.Prompt, handler server.PromptHandlerFunc) {
+	return mcp.NewPrompt("summarize_pipeline_failure",
+			mcp.WithPromptDescription(fmt.Sprintf("Summarize why a pipeline failed, from the end of the logs of its failed jobs (at most %d).", maxPromptFailedJobs)),
+			mcp.WithArgument("projectId", mcp.RequiredArgument(), mcp.ArgumentDescription("The ID or path of the project (e.g., 'group/project').")),
+			mcp.WithArgument("pipelineId", mcp.RequiredArgument(), mcp.ArgumentDescription("The ID of the pipeline.")),
+		),
+		func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			projectID, err := promptArgument(request, "projectId", true)
+			if err != nil {
+				return nil, err
+			}
+			pipelineID, err := promptIDArgument(request, "pipelineId")
+			if err != nil {
+				return nil, err
+			}
+
+			_, readPipeline := PipelineResource(getClient)
+			pipeline, err := readPromptResource(ctx, readPipeline,
+				fmt.Sprintf("gitlab://%s/-/pipelines/%d", projectID, pipelineID),
+				map[string]any{"project": []string{projectID}, "id": []string{strconv.Itoa(pipelineID)}})
+			if err != nil {
+				return nil, err
+			}
+			jobLogs, err := failedJobLogs(ctx, getClient, projectID, pipelineID)
+			if err != nil {
+				return nil, err
+			}
+
+			messages := []mcp.PromptMessage{
+				userMessage(fmt.Sprintf("Pipeline %d of project %s failed. The pipeline, then the end of the log of each failed job, "+
+					"follow.\n\n"+
+					"Summarize the failure for a developer: which jobs failed and at which stage, the root cause of each (e.g., a "+
+					"failing test, a compilation error, a flaky or infrastructure problem) quoting the relevant log lines, and "+
+					"the likely fix. Say whether retrying the pipeline is likely to help.", pipelineID, projectID)),
+				mcp.NewPromptMessage(mcp.RoleUser, mcp.NewEmbeddedResource(pipeline)),
+			}
+			if len(jobLogs) == 0 {
+				messages = append(messages, userMessage("The pipeline has no failed jobs."))
+			}
+			for _, jobLog := range jobLogs {
+				messages = append(messages, userMessage(jobLog))
+			}
+			return mcp.NewGetPromptResult(fmt.Sprintf("Failure of pipeline %d in %s", pipelineID, projectID), messages), nil
+		}
+}
+
+// ReleaseNotesPrompt defines the prompt to write release notes from the commits between two tags.
+func ReleaseNotesPrompt(getClient GetClientFn) (prompt mcp.Prompt, handler server.PromptHandlerFunc) {
+	return mcp.NewPrompt("write_release_notes",
+			mcp.WithPromptDescription("Write release notes from the commits and changed files between two tags (or any refs)."),
+			mcp.WithArgument("projectId", mcp.RequiredArgument(), mcp.ArgumentDescription("The ID or path of the project (e.g., 'group/project').")),
+			mcp.WithArgument("from", mcp.RequiredArgument(), mcp.ArgumentDescription("The tag of the previous release (e.g., 'v1.1.0').")),
+			mcp.WithArgument("to", mcp.RequiredArgument(), mcp.ArgumentDescription("The tag of the new release (e.g., 'v1.2.0').")),
+		),
+		func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			projectID, err := promptArgument(request, "projectId", true)
+			if err != nil {
+				return nil, err
+			}
+			from, err := promptArgument(request, "from", true)
+			if err != nil {
+				return nil, err
+			}
+			to, err := promptArgument(request, "to", true)
+			if err != nil {
+				return nil, err
+			}
+
+			changes, err := callPromptTool(ctx, CompareRefs, getClient, map[string]any{
+				"projectId": projectID, "from": from, "to": to, "includeDiffs": false,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return mcp.NewGetPromptResult(
+				fmt.Sprintf("Release notes of %s %s", projectID, to),
+				[]mcp.PromptMessage{
+					userMessage(fmt.Sprintf("Write the release notes of version %s of project %s, from the commits and changed files "+
+						"since %s listed below.\n\n"+
+						"Write Markdown for users of the project: a short overview, then sections for breaking changes, new "+
+						"features, fixes and other changes, each a list of concise entries in plain language. Group related commits "+
+						"into one entry, leave out merge commits and purely internal changes (e.g., CI or refactoring), and keep the "+
+						"references to merge requests and issues found in commit messages.", to, projectID, from)),
+					userMessage(fmt.Sprintf("Changes from %s to %s:\n\n%s", from, to, changes)),
+				},
+			), nil
+		}
+}
+
+// ExplainFileHistoryPrompt defines the prompt to explain how a file evolved, from its content
+// and the commits that changed it.
+func ExplainFileHistoryPrompt(getClient GetClientFn) (prompt mcp.Prompt, handler server.PromptHandlerFunc) {
+	return mcp.NewPrompt("explain_file_history",
+			mcp.WithPromptDescription(fmt.Sprintf("Explain how a file evolved, from its content and the latest commits that changed it (at most %d).", promptListSize)),
+			mcp.WithArgument("projectId", mcp.RequiredArgument(), mcp.ArgumentDescription("The ID or path of the project (e.g., 'group/project').")),
+			mcp.WithArgument("filePath", mcp.RequiredArgument(), mcp.ArgumentDescription("The path of the file in the repository (e.g., 'src/main.go').")),
+			mcp.WithArgument("ref", mcp.ArgumentDescription("The branch, tag or commit SHA (defaults to the repository's default branch).")),
+		),
+		func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			projectID, err := promptArgument(request, "projectId", true)
+			if err != nil {
+				return nil, err
+			}
+			filePath, err := promptArgument(request, "filePath", true)
+			if err != nil {
+				return nil, err
+			}
+			ref, err := promptArgument(request, "ref", false)
+			if err != nil {
+				return nil, err
+			}
+
+			fileArgs := map[string]any{"projectId": projectID, "filePath": filePath}
+			commitArgs := map[string]any{"projectId": projectID, "path": filePath, "per_page": float64(promptListSize)}
+			if ref != "" {
+				fileArgs["ref"], commitArgs["ref"] = ref, ref
+			}
+			content, err := callPromptTool(ctx, GetProjectFile, getClient, fileArgs)
+			if err != nil {
+				return nil, err
+			}
+			commits, err := callPromptTool(ctx, GetProjectCommits, getClient, commitArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			return mcp.NewGetPromptResult(
+				fmt.Sprintf("History of %s in %s", filePath, projectID),
+				[]mcp.PromptMessage{
+					userMessage(fmt.Sprintf("Explain the history of file %s of project %s. Its current content, then the latest "+
+						"commits that changed it, follow.\n\n"+
+						"Describe what the file does today, then how it got there: the main phases of its evolution, the notable "+
+						"changes and why they were made as far as the commit messages tell, and who worked on it. Point out parts "+
+						"that changed often, which may be fragile.", filePath, projectID)),
+					userMessage(fmt.Sprintf("Content of %s:\n\n%s", filePath, content)),
+					userMessage("Commits that changed the file, newest first:\n\n" + commits),
+				},
+			), nil
+		}
+}
+
+// promptArgument returns the named argument of a prompt request, or an error if a required
+// argument is missing.
+func promptArgument(request mcp.GetPromptRequest, name string, required bool) (string, error) {
+	value := strings.TrimSpace(request.Params.Arguments[name])
+	if value == "" && required {
+		return "", fmt.Errorf("missing required argument: %s", name)
+	}
+	return value, nil
+}
+
+// promptIDArgument returns the named required argument of a prompt request as a positive integer.
+func promptIDArgument(request mcp.GetPromptRequest, name string) (int, error) {
+	value, err := promptArgument(request, name, true)
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("argument %s must be a positive integer, got %q", name, value)
+	}
+	return id, nil
+}
+
+// callPromptTool calls the handler of the tool defined by newTool with args and returns the
+// text of its result, capped to maxPromptDataBytes. A failed call returns its error message.
+func callPromptTool(ctx context.Context, newTool func(GetClientFn) (mcp.Tool, server.ToolHandlerFunc), getClient GetClientFn, args map[string]any) (string, error) {
+	tool, handler := newTool(getClient)
+	request := mcp.CallToolRequest{}
+	request.Params.Name = tool.Name
+	request.Params.Arguments = args
+	result, err := handler(ctx, request)
+	if err != nil {
+		return "", err
+	}
+	if result.IsError {
+		return "", errors.New(resultText(result))
+	}
+	return capPromptData(resultText(result)), nil
+}
+
+// readPromptResource reads the resource at uri, whose template variables are args, with the
+// handler of its template. Text contents are capped to maxPromptDataBytes.
+func readPromptResource(ctx context.Context, handler server.ResourceTemplateHandlerFunc, uri string, args map[string]any) (mcp.ResourceContents, error) {
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = uri
+	request.Params.Arguments = args
+	contents, err := handler(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if len(contents) == 0 {
+		return nil, fmt.Errorf("resource %q has no content", uri)
+	}
+	if text, ok := contents[0].(mcp.TextResourceContents); ok {
+		text.Text = capPromptData(text.Text)
+		return text, nil
+	}
+	return contents[0], nil
+}
+
+// failedJobLogs returns, for each failed job of the pipeline, a description of the job
+// followed by the tail of its log.
+func failedJobLogs(ctx context.Context, getClient GetClientFn, projectID string, pipelineID int) ([]string, error) {
+	glClient, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+	}
+	opts := &gl.ListJobsOptions{
+		ListOptions: gl.ListOptions{PerPage: maxPromptFailedJobs},
+		Scope:       &[]gl.BuildStateValue{gl.Failed},
+	}
+	jobs, resp, err := glClient.Jobs.ListPipelineJobs(projectID, pipelineID, opts, gl.WithContext(ctx))
+	if err != nil {
+		return nil, apiError(err, resp,
+			fmt.Sprintf("failed to list failed jobs of pipeline %d in project %q", pipelineID, projectID),
+			fmt.Sprintf("pipeline %d not found in project %q, or access denied", pipelineID, projectID),
+		)
+	}
+
+	logs := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		header := fmt.Sprintf("Job %q (ID %d, stage %q) failed", job.Name, job.ID, job.Stage)
+		if job.FailureReason != "" {
+			header += fmt.Sprintf(" (reason: %s)", job.FailureReason)
+		}
+		if job.AllowFailure {
+			header += ", but is allowed to fail"
+		}
+		trace, resp, err := glClient.Jobs.GetTraceFile(projectID, job.ID, gl.WithContext(ctx))
+		if err != nil {
+			logs = append(logs, fmt.Sprintf("%s. Its log could not be read: %s", header, apiErrorMessage(err, resp, "failed to get the log", "log not found, or access denied", time.Now())))
+			continue
+		}
+		data, err := io.ReadAll(trace)
+		if err != nil {
+			logs = append(logs, fmt.Sprintf("%s. Its log could not be read: %v", header, err))
+			continue
+		}
+		text := jobLogControlPattern.ReplaceAllString(string(data), "")
+		logs = append(logs, fmt.Sprintf("%s. End of its log:\n\n```\n%s\n```", header, tailText(text, maxPromptJobLogBytes)))
+	}
+	return logs, nil
+}
+
+// tailText returns the end of text, at most maxBytes long and starting at a line boundary when
+// there is one in it.
+func tailText(text string, maxBytes int) string {
+	if len(text) <= maxBytes {
+		return text
+	}
+	tail := text[len(text)-maxBytes:]
+	if idx := strings.Index(tail, "\n"); idx >= 0 && idx < len(tail)-1 {
+		return tail[idx+1:]
+	}
+	for len(tail) > 0 && !utf8.RuneStart(tail[0]) {
+		tail = tail[1:]
+	}
+	return tail
+}
+
+// capPromptData cuts text to maxPromptDataBytes, noting the cut.
+func capPromptData(text string) string {
+	if capped, truncated := truncateText(text, maxPromptDataBytes); truncated {
+		return capped + fmt.Sprintf("\n[truncated at %d bytes]", maxPromptDataBytes)
+	}
+	return text
+}
+
+// userMessage returns a prompt message of the user with text.
+func userMessage(text string) mcp.PromptMessage {
+	return mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text))
+}
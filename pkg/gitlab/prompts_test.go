@@ -0,0 +1,137 @@
+package gitlab
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+// newPromptServer returns an MCP server with all prompts, backed by a fake GitLab API
+// answering the requests of routes (by escaped path) with their JSON body.
+func newPromptServer(t *testing.T, routes map[string]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"message": "404 Not Found"}`)
+			return
+		}
+		_, _ = io.WriteString(w, body)
+	}))
+}
+
+func promptText(t *testing.T, message mcp.PromptMessage) string {
+	t.Helper()
+	text, ok := mcp.AsTextContent(message.Content)
+	require.True(t, ok, "Expected text content, got %T", message.Content)
+	return text.Text
+}
+
+func TestPrompts(t *testing.T) {
+	jobLog := "\x1b[0KRunning with gitlab-runner\r\nsection_start:1714989600:step_script\r\x1b[0K" +
+		strings.Repeat("ok  \tpkg/other\n", 1000) +
+		"\x1b[31;1m--- FAIL: TestParse (0.00s)\x1b[0m\nsection_end:1714989660:step_script\r\x1b[0KERROR: Job failed: exit code 1\n"
+	srv := newPromptServer(t, map[string]string{
+		"/api/v4/projects/group%2Fproject/merge_requests/3":            `{"id": 300, "iid": 3, "title": "Add parser"}`,
+		"/api/v4/projects/group%2Fproject/merge_requests/3/diffs":      `[{"old_path": "parse.go", "new_path": "parse.go", "diff": "@@ -1 +1 @@\n-old\n+new\n"}]`,
+		"/api/v4/projects/group%2Fproject/merge_requests/3/notes":      `[{"id": 1, "body": "Please add a test", "author": {"username": "bob"}}]`,
+		"/api/v4/projects/group%2Fproject/issues":                      `[{"id": 70, "iid": 7, "title": "Crash on empty input"}]`,
+		"/api/v4/projects/group%2Fproject/pipelines/5":                 `{"id": 5, "status": "failed", "ref": "main"}`,
+		"/api/v4/projects/group%2Fproject/pipelines/5/jobs":            `[{"id": 51, "name": "test", "stage": "test", "status": "failed", "failure_reason": "script_failure"}]`,
+		"/api/v4/projects/group%2Fproject/jobs/51/trace":               jobLog,
+		"/api/v4/projects/group%2Fproject/repository/compare":          `{"commits": [{"id": "abc", "title": "Add parser (!3)"}], "diffs": [{"new_path": "parse.go", "diff": "@@ -1 +1 @@\n-old\n+new\n"}]}`,
+		"/api/v4/projects/group%2Fproject/repository/files/parse%2Ego": `{"file_name": "parse.go", "content": "cGFja2FnZSBwYXJzZQo=", "encoding": "base64"}`,
+		"/api/v4/projects/group%2Fproject/repository/commits":          `[{"id": "abc", "title": "Add parser (!3)", "author_name": "Alice"}]`,
+	})
+	defer srv.Close()
+	client, err := gl.NewClient("token", gl.WithBaseURL(srv.URL), gl.WithoutRetries())
+	require.NoError(t, err)
+	getClient := func(_ context.Context) (*gl.Client, error) { return client, nil }
+
+	s := NewServer("test", "0.0.0")
+	for _, newPrompt := range []func(GetClientFn) (mcp.Prompt, server.PromptHandlerFunc){
+		ReviewMergeRequestPrompt, TriageIssuesPrompt, SummarizePipelineFailurePrompt, ReleaseNotesPrompt, ExplainFileHistoryPrompt,
+	} {
+		s.AddPrompt(newPrompt(getClient))
+	}
+
+	t.Run("Review Merge Request", func(t *testing.T) {
+		result, errMsg := getPrompt(t, s, "review_merge_request", map[string]string{"projectId": "group/project", "mergeRequestIid": "3"})
+		require.Empty(t, errMsg)
+		require.Len(t, result.Messages, 3)
+		assert.Contains(t, promptText(t, result.Messages[0]), "Review merge request !3 of project group/project")
+		embedded, ok := result.Messages[1].Content.(mcp.EmbeddedResource)
+		require.True(t, ok, "Expected the merge request as an embedded resource, got %T", result.Messages[1].Content)
+		mr, ok := embedded.Resource.(mcp.TextResourceContents)
+		require.True(t, ok)
+		assert.Equal(t, "gitlab://group/project/-/merge_requests/3", mr.URI)
+		assert.Contains(t, mr.Text, `"title":"Add parser"`)
+		assert.Contains(t, mr.Text, `+new`)
+		assert.Contains(t, promptText(t, result.Messages[2]), "Please add a test")
+	})
+
+	t.Run("Triage Issues", func(t *testing.T) {
+		result, errMsg := getPrompt(t, s, "triage_issues", map[string]string{"projectId": "group/project"})
+		require.Empty(t, errMsg)
+		require.Len(t, result.Messages, 2)
+		assert.Contains(t, promptText(t, result.Messages[1]), "Crash on empty input")
+	})
+
+	t.Run("Summarize Pipeline Failure", func(t *testing.T) {
+		result, errMsg := getPrompt(t, s, "summarize_pipeline_failure", map[string]string{"projectId": "group/project", "pipelineId": "5"})
+		require.Empty(t, errMsg)
+		require.Len(t, result.Messages, 3)
+		_, ok := result.Messages[1].Content.(mcp.EmbeddedResource)
+		assert.True(t, ok, "Expected the pipeline as an embedded resource")
+		jobMessage := promptText(t, result.Messages[2])
+		assert.Contains(t, jobMessage, `Job "test" (ID 51, stage "test") failed (reason: script_failure)`)
+		assert.Contains(t, jobMessage, "--- FAIL: TestParse (0.00s)\nERROR: Job failed: exit code 1")
+		assert.NotContains(t, jobMessage, "\x1b", "ANSI escape sequences are removed")
+		assert.NotContains(t, jobMessage, "section_end")
+		assert.NotContains(t, jobMessage, "Running with gitlab-runner", "Only the end of the log is kept")
+		assert.Less(t, len(jobMessage), maxPromptJobLogBytes+200)
+	})
+
+	t.Run("Write Release Notes", func(t *testing.T) {
+		result, errMsg := getPrompt(t, s, "write_release_notes", map[string]string{"projectId": "group/project", "from": "v1.0.0", "to": "v1.1.0"})
+		require.Empty(t, errMsg)
+		require.Len(t, result.Messages, 2)
+		assert.Contains(t, promptText(t, result.Messages[0]), "version v1.1.0 of project group/project")
+		changes := promptText(t, result.Messages[1])
+		assert.Contains(t, changes, "Add parser (!3)")
+		assert.NotContains(t, changes, "+new", "Diffs are left out of release notes")
+	})
+
+	t.Run("Explain File History", func(t *testing.T) {
+		result, errMsg := getPrompt(t, s, "explain_file_history", map[string]string{"projectId": "group/project", "filePath": "parse.go"})
+		require.Empty(t, errMsg)
+		require.Len(t, result.Messages, 3)
+		assert.Contains(t, promptText(t, result.Messages[1]), "package parse")
+		assert.Contains(t, promptText(t, result.Messages[2]), "Alice")
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, errMsg := getPrompt(t, s, "review_merge_request", map[string]string{"projectId": "group/project"})
+		assert.Equal(t, "missing required argument: mergeRequestIid", errMsg)
+		_, errMsg = getPrompt(t, s, "summarize_pipeline_failure", map[string]string{"projectId": "group/project", "pipelineId": "x"})
+		assert.Contains(t, errMsg, "argument pipelineId must be a positive integer")
+		_, errMsg = getPrompt(t, s, "review_merge_request", map[string]string{"projectId": "group/project", "mergeRequestIid": "9"})
+		assert.Contains(t, errMsg, "not found")
+	})
+}
+
+func TestTailText(t *testing.T) {
+	assert.Equal(t, "short", tailText("short", 10))
+	assert.Equal(t, "line3\n", tailText("line1\nline2\nline3\n", 8), "The tail starts at a line boundary")
+	assert.Equal(t, "é", tailText("ééé", 3), "The tail starts at a character boundary")
+}
@@ -0,0 +1,500 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	gl "gitlab.com/gitlab-org/api/client-go" // GitLab client library
+)
+
+// withAssetLinks returns a ToolOption adding the 'assetLinks' array parameter used by release write tools.
+func withAssetLinks() mcp.ToolOption {
+	return mcp.WithArray("assetLinks",
+		mcp.Description("Asset links to attach to the release. Each item needs a 'name' and 'url', and may set 'linkType' ('other', 'runbook', 'image', 'package') and 'filePath' for a permanent direct asset path."),
+		mcp.Items(map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name":     map[string]any{"type": "string"},
+				"url":      map[string]any{"type": "string"},
+				"linkType": map[string]any{"type": "string", "enum": []string{"other", "runbook", "image", "package"}},
+				"filePath": map[string]any{"type": "string"},
+			},
+			"required": []string{"name", "url"},
+		}),
+	)
+}
+
+// assetLinkParam is a single entry of the 'assetLinks' parameter.
+type assetLinkParam struct {
+	Name     string
+	URL      string
+	LinkType string
+	FilePath string
+}
+
+// optionalAssetLinksParam parses the optional 'assetLinks' array parameter.
+func optionalAssetLinksParam(r *mcp.CallToolRequest, p string) ([]assetLinkParam, error) {
+	raw, ok := r.GetArguments()[p]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	items, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("parameter '%s' must be an array of objects, got %T", p, raw)
+	}
+
+	links := make([]assetLinkParam, 0, len(items))
+	for i, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("parameter '%s[%d]' must be an object, got %T", p, i, item)
+		}
+		var link assetLinkParam
+		for key, dst := range map[string]*string{"name": &link.Name, "url": &link.URL, "linkType": &link.LinkType, "filePath": &link.FilePath} {
+			if v, exists := obj[key]; exists && v != nil {
+				s, ok := v.(string)
+				if !ok {
+					return nil, fmt.Errorf("parameter '%s[%d].%s' must be a string, got %T", p, i, key, v)
+				}
+				*dst = s
+			}
+		}
+		if link.Name == "" || link.URL == "" {
+			return nil, fmt.Errorf("parameter '%s[%d]' requires both 'name' and 'url'", p, i)
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+// splitCommaList splits a comma-separated parameter value, dropping empty entries.
+func splitCommaList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// ListReleases defines the MCP tool for listing releases in a project.
+func ListReleases(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"listReleases",
+			mcp.WithDescription("Retrieves a list of releases in a project, sorted by release date in descending order by default."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "List Releases",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("orderBy",
+				mcp.Description("Return releases ordered by field. Default: 'released_at'."),
+				mcp.Enum("released_at", "created_at"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Return releases sorted in asc or desc order. Default: 'desc'."),
+				mcp.Enum("asc", "desc"),
+			),
+			// Add standard MCP pagination parameters
+			WithPagination(),
+			WithListOutput(releaseSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			orderBy, err := OptionalParam[string](&request, "orderBy")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			sort, err := OptionalParam[string](&request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, releaseSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			opts := &gl.ListReleasesOptions{
+				ListOptions: gl.ListOptions{
+					PerPage: listParams.PerPage,
+				},
+			}
+			if orderBy != "" {
+				opts.OrderBy = &orderBy
+			}
+			if sort != "" {
+				opts.Sort = &sort
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			releases, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.Release, *gl.Response, error) {
+				opts.Page = page
+				return glClient.Releases.ListReleases(projectIDStr, opts, gl.WithContext(ctx))
+			})
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list releases for project %q", projectIDStr),
+					fmt.Sprintf("project %q not found or access denied", projectIDStr),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newListResult(releases, pagination, output, "release list data")
+		}
+}
+
+// GetRelease defines the MCP tool for retrieving a single release by its tag name.
+func GetRelease(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getRelease",
+			mcp.WithDescription("Retrieves a single release by tag name, including release notes, asset links and milestones."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get Release",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("tagName",
+				mcp.Required(),
+				mcp.Description("The tag name the release is associated with."),
+			),
+			WithOutput(releaseDetailSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, releaseDetailSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			tagName, err := requiredParam[string](&request, "tagName")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			release, resp, err := glClient.Releases.GetRelease(projectIDStr, tagName, gl.WithContext(ctx))
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get release %q from project %q", tagName, projectIDStr),
+					fmt.Sprintf("release %q not found in project %q or access denied", tagName, projectIDStr),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newProjectedResult(release, output, "release data")
+		}
+}
+
+// CreateRelease defines the MCP tool for publishing a release, creating its tag from a ref if needed.
+func CreateRelease(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"createRelease",
+			mcp.WithDescription("Creates a release for a tag with release notes, asset links and milestones. If the tag does not exist, it is created from 'ref'."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Create Release",
+				ReadOnlyHint: mcp.ToBoolPtr(false),
+			}),
+			WithResultSchema(gl.Release{}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("tagName",
+				mcp.Required(),
+				mcp.Description("The tag the release is created for."),
+			),
+			mcp.WithString("name",
+				mcp.Description("The release name. Defaults to the tag name."),
+			),
+			mcp.WithString("description",
+				mcp.Description("The release notes, in Markdown."),
+			),
+			mcp.WithString("ref",
+				mcp.Description("The branch name or commit SHA to create the tag from, required only if the tag does not exist."),
+			),
+			mcp.WithString("tagMessage",
+				mcp.Description("The message for the annotated tag created from 'ref'."),
+			),
+			mcp.WithString("milestones",
+				mcp.Description("Comma-separated list of milestone titles to associate with the release."),
+			),
+			mcp.WithString("releasedAt",
+				mcp.Description("The date the release is/was ready (ISO 8601 format). Defaults to now; a future date creates an upcoming release."),
+			),
+			withAssetLinks(),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			tagName, err := requiredParam[string](&request, "tagName")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			name, err := OptionalParam[string](&request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			description, err := OptionalParam[string](&request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			ref, err := OptionalParam[string](&request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			tagMessage, err := OptionalParam[string](&request, "tagMessage")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			milestones, err := OptionalParam[string](&request, "milestones")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			releasedAt, err := OptionalTimeParam(&request, "releasedAt")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			assetLinks, err := optionalAssetLinksParam(&request, "assetLinks")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			opts := &gl.CreateReleaseOptions{
+				TagName:    &tagName,
+				ReleasedAt: releasedAt,
+			}
+			if name != "" {
+				opts.Name = &name
+			}
+			if description != "" {
+				opts.Description = &description
+			}
+			if ref != "" {
+				opts.Ref = &ref
+			}
+			if tagMessage != "" {
+				opts.TagMessage = &tagMessage
+			}
+			if titles := splitCommaList(milestones); len(titles) > 0 {
+				opts.Milestones = &titles
+			}
+			if len(assetLinks) > 0 {
+				opts.Assets = &gl.ReleaseAssetsOptions{}
+				for _, link := range assetLinks {
+					linkOpts := &gl.ReleaseAssetLinkOptions{
+						Name: gl.Ptr(link.Name),
+						URL:  gl.Ptr(link.URL),
+					}
+					if link.LinkType != "" {
+						linkOpts.LinkType = gl.LinkType(gl.LinkTypeValue(link.LinkType))
+					}
+					if link.FilePath != "" {
+						linkOpts.DirectAssetPath = gl.Ptr(link.FilePath)
+					}
+					opts.Assets.Links = append(opts.Assets.Links, linkOpts)
+				}
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			release, resp, err := glClient.Releases.CreateRelease(projectIDStr, opts, gl.WithContext(ctx))
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to create release %q in project %q", tagName, projectIDStr),
+					fmt.Sprintf("project %q not found or access denied", projectIDStr),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newJSONResult(release, "release data")
+		}
+}
+
+// UpdateRelease defines the MCP tool for updating a release's name, notes and milestones, and adding asset links.
+func UpdateRelease(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"updateRelease",
+			mcp.WithDescription("Updates an existing release's name, release notes, milestones or release date, and attaches additional asset links. Omitted fields are left unchanged."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Update Release",
+				ReadOnlyHint: mcp.ToBoolPtr(false),
+			}),
+			WithResultSchema(gl.Release{}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("tagName",
+				mcp.Required(),
+				mcp.Description("The tag name the release is associated with."),
+			),
+			mcp.WithString("name",
+				mcp.Description("The new release name."),
+			),
+			mcp.WithString("description",
+				mcp.Description("The new release notes, in Markdown."),
+			),
+			mcp.WithString("milestones",
+				mcp.Description("Comma-separated list of milestone titles, replacing the current ones."),
+			),
+			mcp.WithString("releasedAt",
+				mcp.Description("The date the release is/was ready (ISO 8601 format)."),
+			),
+			withAssetLinks(),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			tagName, err := requiredParam[string](&request, "tagName")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			name, hasName, err := OptionalParamOK[string](&request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			description, hasDescription, err := OptionalParamOK[string](&request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			milestones, hasMilestones, err := OptionalParamOK[string](&request, "milestones")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			releasedAt, err := OptionalTimeParam(&request, "releasedAt")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			assetLinks, err := optionalAssetLinksParam(&request, "assetLinks")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Fetch the current release so omitted name/description are preserved
+			current, resp, err := glClient.Releases.GetRelease(projectIDStr, tagName, gl.WithContext(ctx))
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get release %q from project %q", tagName, projectIDStr),
+					fmt.Sprintf("release %q not found in project %q or access denied", tagName, projectIDStr),
+				), nil
+			}
+
+			// --- Construct GitLab API options
+			opts := &gl.UpdateReleaseOptions{
+				Name:        gl.Ptr(current.Name),
+				Description: gl.Ptr(current.Description),
+				ReleasedAt:  releasedAt,
+			}
+			if hasName {
+				opts.Name = &name
+			}
+			if hasDescription {
+				opts.Description = &description
+			}
+			if hasMilestones {
+				titles := splitCommaList(milestones)
+				if titles == nil {
+					titles = []string{} // An empty list removes all milestones
+				}
+				opts.Milestones = &titles
+			}
+
+			// --- Call GitLab API
+			release, resp, err := glClient.Releases.UpdateRelease(projectIDStr, tagName, opts, gl.WithContext(ctx))
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to update release %q in project %q", tagName, projectIDStr),
+					fmt.Sprintf("release %q not found in project %q or access denied", tagName, projectIDStr),
+				), nil
+			}
+
+			// --- Attach any new asset links
+			for _, link := range assetLinks {
+				linkOpts := &gl.CreateReleaseLinkOptions{
+					Name: gl.Ptr(link.Name),
+					URL:  gl.Ptr(link.URL),
+				}
+				if link.LinkType != "" {
+					linkOpts.LinkType = gl.LinkType(gl.LinkTypeValue(link.LinkType))
+				}
+				if link.FilePath != "" {
+					linkOpts.DirectAssetPath = gl.Ptr(link.FilePath)
+				}
+				created, resp, err := glClient.ReleaseLinks.CreateReleaseLink(projectIDStr, tagName, linkOpts, gl.WithContext(ctx))
+				if err != nil {
+					return apiErrorResult(err, resp,
+						fmt.Sprintf("release %q updated, but adding asset link %q failed", tagName, link.Name),
+						fmt.Sprintf("release %q not found in project %q or access denied", tagName, projectIDStr),
+					), nil
+				}
+				release.Assets.Links = append(release.Assets.Links, created)
+			}
+
+			// --- Marshal and return success
+			return newJSONResult(release, "release data")
+		}
+}
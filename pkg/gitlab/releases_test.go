@@ -0,0 +1,241 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestListReleasesHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockReleases, _, ctrl := setupMockClientForReleases(t)
+	defer ctrl.Finish()
+
+	mockGetClientReleases := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	listReleasesTool, listReleasesHandler := ListReleases(mockGetClientReleases)
+	projectID := "group/project"
+
+	t.Run("Success - Ordered List", func(t *testing.T) {
+		mockReleases.EXPECT().
+			ListReleases(projectID, gomock.AssignableToTypeOf(&gl.ListReleasesOptions{}), gomock.Any()).
+			DoAndReturn(func(_ interface{}, opts *gl.ListReleasesOptions, _ ...gl.RequestOptionFunc) ([]*gl.Release, *gl.Response, error) {
+				assert.Equal(t, "created_at", *opts.OrderBy)
+				assert.Equal(t, 1, opts.Page)
+				return []*gl.Release{{TagName: "v1.0.0", Name: "First"}}, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil
+			})
+
+		result, err := listReleasesHandler(ctx, createCallToolRequest(listReleasesTool.Name, map[string]any{"projectId": projectID, "orderBy": "created_at"}))
+		require.NoError(t, err)
+		items, _ := getListResult(t, result)
+		var actual []*gl.Release
+		require.NoError(t, json.Unmarshal([]byte(items), &actual))
+		require.Len(t, actual, 1)
+		assert.Equal(t, "v1.0.0", actual[0].TagName)
+	})
+
+	t.Run("Error - GitLab API Error (500)", func(t *testing.T) {
+		mockReleases.EXPECT().
+			ListReleases(projectID, gomock.Any(), gomock.Any()).
+			Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
+
+		result, err := listReleasesHandler(ctx, createCallToolRequest(listReleasesTool.Name, map[string]any{"projectId": projectID}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "failed to list releases")
+	})
+}
+
+func TestGetReleaseHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockReleases, _, ctrl := setupMockClientForReleases(t)
+	defer ctrl.Finish()
+
+	mockGetClientReleases := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	getReleaseTool, getReleaseHandler := GetRelease(mockGetClientReleases)
+	projectID := "group/project"
+
+	mockReleases.EXPECT().
+		GetRelease(projectID, "v2.0.0", gomock.Any()).
+		Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Not Found"))
+
+	result, err := getReleaseHandler(ctx, createCallToolRequest(getReleaseTool.Name, map[string]any{"projectId": projectID, "tagName": "v2.0.0"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, `release "v2.0.0" not found`)
+}
+
+func TestCreateReleaseHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockReleases, _, ctrl := setupMockClientForReleases(t)
+	defer ctrl.Finish()
+
+	mockGetClientReleases := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	createReleaseTool, createReleaseHandler := CreateRelease(mockGetClientReleases)
+	projectID := "group/project"
+	releasedAt, _ := time.Parse(time.RFC3339, "2024-05-01T10:00:00Z")
+
+	tests := []struct {
+		name              string
+		inputArgs         map[string]any
+		mockSetup         func()
+		expectResultError bool
+		errorContains     string
+	}{
+		{
+			name: "Success - Full Release",
+			inputArgs: map[string]any{
+				"projectId":   projectID,
+				"tagName":     "v1.3.0",
+				"name":        "Version 1.3",
+				"description": "## Changes\n- Added X",
+				"ref":         "main",
+				"milestones":  "1.3, Q2",
+				"releasedAt":  "2024-05-01T10:00:00Z",
+				"assetLinks": []any{
+					map[string]any{"name": "binary", "url": "https://example.com/bin", "linkType": "package", "filePath": "/bin/app"},
+				},
+			},
+			mockSetup: func() {
+				mockReleases.EXPECT().
+					CreateRelease(projectID, gomock.AssignableToTypeOf(&gl.CreateReleaseOptions{}), gomock.Any()).
+					DoAndReturn(func(_ interface{}, opts *gl.CreateReleaseOptions, _ ...gl.RequestOptionFunc) (*gl.Release, *gl.Response, error) {
+						assert.Equal(t, "v1.3.0", *opts.TagName)
+						assert.Equal(t, "Version 1.3", *opts.Name)
+						assert.Equal(t, "main", *opts.Ref)
+						assert.Equal(t, []string{"1.3", "Q2"}, *opts.Milestones)
+						assert.True(t, releasedAt.Equal(*opts.ReleasedAt))
+						require.NotNil(t, opts.Assets)
+						require.Len(t, opts.Assets.Links, 1)
+						assert.Equal(t, "binary", *opts.Assets.Links[0].Name)
+						assert.Equal(t, gl.PackageLinkType, *opts.Assets.Links[0].LinkType)
+						assert.Equal(t, "/bin/app", *opts.Assets.Links[0].DirectAssetPath)
+						return &gl.Release{TagName: "v1.3.0", Name: "Version 1.3"}, &gl.Response{Response: &http.Response{StatusCode: 201}}, nil
+					})
+			},
+		},
+		{
+			name:      "Error - Release Already Exists (409)",
+			inputArgs: map[string]any{"projectId": projectID, "tagName": "v1.3.0"},
+			mockSetup: func() {
+				mockReleases.EXPECT().
+					CreateRelease(projectID, gomock.Any(), gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 409}}, errors.New("Release already exists"))
+			},
+			expectResultError: true,
+			errorContains:     "Release already exists",
+		},
+		{
+			name: "Error - Asset Link Missing URL",
+			inputArgs: map[string]any{
+				"projectId":  projectID,
+				"tagName":    "v1.3.0",
+				"assetLinks": []any{map[string]any{"name": "binary"}},
+			},
+			mockSetup:         func() {},
+			expectResultError: true,
+			errorContains:     "requires both 'name' and 'url'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			result, err := createReleaseHandler(ctx, createCallToolRequest(createReleaseTool.Name, tc.inputArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectResultError {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.errorContains)
+				return
+			}
+			assert.False(t, result.IsError)
+			assert.Contains(t, textContent.Text, `"tag_name":"v1.3.0"`)
+		})
+	}
+}
+
+func TestUpdateReleaseHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockReleases, mockLinks, ctrl := setupMockClientForReleases(t)
+	defer ctrl.Finish()
+
+	mockGetClientReleases := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	updateReleaseTool, updateReleaseHandler := UpdateRelease(mockGetClientReleases)
+	projectID := "group/project"
+	current := &gl.Release{TagName: "v1.3.0", Name: "Version 1.3", Description: "Old notes"}
+	okResp := &gl.Response{Response: &http.Response{StatusCode: 200}}
+
+	t.Run("Success - Update Notes And Add Link", func(t *testing.T) {
+		mockReleases.EXPECT().GetRelease(projectID, "v1.3.0", gomock.Any()).Return(current, okResp, nil)
+		mockReleases.EXPECT().
+			UpdateRelease(projectID, "v1.3.0", gomock.AssignableToTypeOf(&gl.UpdateReleaseOptions{}), gomock.Any()).
+			DoAndReturn(func(_ interface{}, _ string, opts *gl.UpdateReleaseOptions, _ ...gl.RequestOptionFunc) (*gl.Release, *gl.Response, error) {
+				assert.Equal(t, "Version 1.3", *opts.Name, "omitted name should be preserved")
+				assert.Equal(t, "New notes", *opts.Description)
+				assert.Nil(t, opts.Milestones)
+				return &gl.Release{TagName: "v1.3.0", Name: "Version 1.3", Description: "New notes"}, okResp, nil
+			})
+		mockLinks.EXPECT().
+			CreateReleaseLink(projectID, "v1.3.0", &gl.CreateReleaseLinkOptions{Name: gl.Ptr("docs"), URL: gl.Ptr("https://example.com/docs")}, gomock.Any()).
+			Return(&gl.ReleaseLink{ID: 5, Name: "docs", URL: "https://example.com/docs"}, &gl.Response{Response: &http.Response{StatusCode: 201}}, nil)
+
+		args := map[string]any{
+			"projectId":   projectID,
+			"tagName":     "v1.3.0",
+			"description": "New notes",
+			"assetLinks":  []any{map[string]any{"name": "docs", "url": "https://example.com/docs"}},
+		}
+		result, err := updateReleaseHandler(ctx, createCallToolRequest(updateReleaseTool.Name, args))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		var actual gl.Release
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &actual))
+		assert.Equal(t, "New notes", actual.Description)
+		require.Len(t, actual.Assets.Links, 1)
+		assert.Equal(t, "docs", actual.Assets.Links[0].Name)
+	})
+
+	t.Run("Success - Clear Milestones", func(t *testing.T) {
+		mockReleases.EXPECT().GetRelease(projectID, "v1.3.0", gomock.Any()).Return(current, okResp, nil)
+		mockReleases.EXPECT().
+			UpdateRelease(projectID, "v1.3.0", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, _ string, opts *gl.UpdateReleaseOptions, _ ...gl.RequestOptionFunc) (*gl.Release, *gl.Response, error) {
+				require.NotNil(t, opts.Milestones)
+				assert.Empty(t, *opts.Milestones)
+				return current, okResp, nil
+			})
+
+		result, err := updateReleaseHandler(ctx, createCallToolRequest(updateReleaseTool.Name, map[string]any{"projectId": projectID, "tagName": "v1.3.0", "milestones": ""}))
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("Error - Release Not Found (404)", func(t *testing.T) {
+		mockReleases.EXPECT().
+			GetRelease(projectID, "v0", gomock.Any()).
+			Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Not Found"))
+
+		result, err := updateReleaseHandler(ctx, createCallToolRequest(updateReleaseTool.Name, map[string]any{"projectId": projectID, "tagName": "v0", "name": "x"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, `release "v0" not found`)
+	})
+}
@@ -0,0 +1,343 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Output formats accepted by the 'format' parameter and as the server-wide default.
+const (
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+	FormatTable    = "table"
+)
+
+// OutputFormats lists the supported output formats.
+var OutputFormats = []string{FormatJSON, FormatMarkdown, FormatTable}
+
+// maxCellLength caps the length of a table cell; longer values are cut with an ellipsis.
+const maxCellLength = 80
+
+// longTextFields are free-text fields rendered as paragraphs below a markdown card
+// instead of as bullets.
+var longTextFields = map[string]bool{"description": true, "body": true, "message": true}
+
+// titlePlaceholder matches the {path} placeholders of a resourceSchema title.
+var titlePlaceholder = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// WithOutputFormat returns a ToolOption adding the 'format' parameter.
+func WithOutputFormat() mcp.ToolOption {
+	return mcp.WithString("format",
+		mcp.Description("Result format: 'json' for the raw objects, 'markdown' for readable cards, or 'table' for a markdown table. "+
+			"Defaults to the server's configured format (json unless set otherwise)."),
+		mcp.Enum(OutputFormats...),
+	)
+}
+
+// optionalFormatParam parses the 'format' parameter, returning FormatJSON when it is absent.
+func optionalFormatParam(r *mcp.CallToolRequest) (string, error) {
+	format, err := OptionalParam[string](r, "format")
+	if err != nil {
+		return "", err
+	}
+	if format == "" {
+		return FormatJSON, nil
+	}
+	if !slices.Contains(OutputFormats, format) {
+		return "", fmt.Errorf("invalid 'format' parameter: must be one of %s, got %q", strings.Join(OutputFormats, ", "), format)
+	}
+	return format, nil
+}
+
+// rendered reports whether the result is rendered as text rather than returned as JSON.
+func (o outputOptions) rendered() bool {
+	return o.format == FormatMarkdown || o.format == FormatTable
+}
+
+// WithDefaultOutputFormat returns a server option that applies format to every tool call
+// that does not pass 'format' itself. Tools without a 'format' parameter ignore it.
+func WithDefaultOutputFormat(format string) server.ServerOption {
+	return server.WithToolHandlerMiddleware(func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if _, ok := request.GetArguments()["format"]; !ok {
+				// Copy the arguments so the caller's map is left untouched
+				args := make(map[string]any, len(request.GetArguments())+1)
+				maps.Copy(args, request.GetArguments())
+				args["format"] = format
+				request.Params.Arguments = args
+			}
+			return next(ctx, request)
+		}
+	})
+}
+
+// fieldEntry is a field of a rendered object: its dotted path and formatted value.
+type fieldEntry struct {
+	path  string
+	value string
+}
+
+// renderObject renders a single (projected) object as a markdown card or a Field/Value table.
+func renderObject(v any, output outputOptions) (string, error) {
+	generic, err := toGeneric(v)
+	if err != nil {
+		return "", err
+	}
+	obj, ok := generic.(map[string]any)
+	if !ok {
+		// Only objects have fields to lay out; anything else is shown as JSON
+		data, err := json.Marshal(generic)
+		return string(data), err
+	}
+
+	var b strings.Builder
+	entries := fieldEntries(obj, output.paths)
+	heading := renderTitle(output.title, obj)
+	if output.format == FormatTable {
+		if heading != "" {
+			b.WriteString("## " + heading + "\n\n")
+		}
+		b.WriteString("| Field | Value |\n|---|---|")
+		for _, e := range entries {
+			fmt.Fprintf(&b, "\n| %s | %s |", tableCell(e.path), tableCell(e.value))
+		}
+		return b.String(), nil
+	}
+	if heading != "" {
+		heading = "## " + heading
+	}
+	writeCard(&b, heading, entries, titlePaths(output.title))
+	return b.String(), nil
+}
+
+// renderList renders (projected) list items as markdown cards or as a markdown table with one
+// column per field, followed by a pagination summary.
+func renderList(v any, pagination *Pagination, output outputOptions) (string, error) {
+	generic, err := toGeneric(v)
+	if err != nil {
+		return "", err
+	}
+	items, _ := generic.([]any)
+
+	var b strings.Builder
+	switch {
+	case len(items) == 0:
+		b.WriteString("_No results._")
+	case output.format == FormatTable:
+		writeTable(&b, items, output.paths)
+	default:
+		skip := titlePaths(output.title)
+		for i, item := range items {
+			obj, _ := item.(map[string]any)
+			heading := renderTitle(output.title, obj)
+			if heading == "" {
+				heading = fmt.Sprintf("Item %d", i+1)
+			}
+			if i > 0 {
+				b.WriteString("\n\n")
+			}
+			writeCard(&b, "### "+heading, fieldEntries(obj, output.paths), skip)
+		}
+	}
+	if summary := paginationSummary(len(items), pagination); summary != "" {
+		b.WriteString("\n\n_" + summary + "_")
+	}
+	return b.String(), nil
+}
+
+// writeCard writes a markdown card: the heading, a bullet per non-empty field except those
+// in skip, then long free-text fields as paragraphs.
+func writeCard(b *strings.Builder, heading string, entries []fieldEntry, skip []string) {
+	b.WriteString(heading)
+	var texts []fieldEntry
+	for _, e := range entries {
+		if e.value == "" || slices.Contains(skip, e.path) {
+			continue
+		}
+		if longTextFields[e.path] {
+			texts = append(texts, e)
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(b, "- **%s:** %s", e.path, strings.ReplaceAll(e.value, "\n", " "))
+	}
+	for _, e := range texts {
+		fmt.Fprintf(b, "\n\n**%s:**\n\n%s", e.path, e.value)
+	}
+}
+
+// writeTable writes items as a markdown table. The columns are paths, or the union of the
+// items' fields in order of appearance when all fields were requested.
+func writeTable(b *strings.Builder, items []any, paths []string) {
+	columns := slices.Clone(paths)
+	rows := make([]map[string]string, len(items))
+	for i, item := range items {
+		obj, _ := item.(map[string]any)
+		rows[i] = make(map[string]string)
+		for _, e := range fieldEntries(obj, paths) {
+			if paths == nil && !slices.Contains(columns, e.path) {
+				columns = append(columns, e.path)
+			}
+			rows[i][e.path] = e.value
+		}
+	}
+
+	b.WriteString("|")
+	for _, col := range columns {
+		b.WriteString(" " + tableCell(col) + " |")
+	}
+	b.WriteString("\n|" + strings.Repeat("---|", len(columns)))
+	for _, row := range rows {
+		b.WriteString("\n|")
+		for _, col := range columns {
+			b.WriteString(" " + tableCell(row[col]) + " |")
+		}
+	}
+}
+
+// fieldEntries lists the fields of obj at paths, in order. When paths is nil, every leaf field
+// is listed by its dotted path, sorted, with nested objects flattened.
+func fieldEntries(obj map[string]any, paths []string) []fieldEntry {
+	if paths == nil {
+		var entries []fieldEntry
+		flattenFields(obj, "", &entries)
+		return entries
+	}
+	entries := make([]fieldEntry, len(paths))
+	for i, path := range paths {
+		entries[i] = fieldEntry{path: path, value: formatValue(lookupPath(obj, strings.Split(path, ".")))}
+	}
+	return entries
+}
+
+// flattenFields appends the leaf fields of obj, prefixed with prefix, to entries.
+func flattenFields(obj map[string]any, prefix string, entries *[]fieldEntry) {
+	keys := slices.Collect(maps.Keys(obj))
+	sort.Strings(keys)
+	for _, key := range keys {
+		path := prefix + key
+		if child, ok := obj[key].(map[string]any); ok && len(child) > 0 {
+			flattenFields(child, path+".", entries)
+			continue
+		}
+		*entries = append(*entries, fieldEntry{path: path, value: formatValue(obj[key])})
+	}
+}
+
+// lookupPath returns the value at the dotted path segments of v. Arrays are traversed
+// transparently, yielding the values found in each element.
+func lookupPath(v any, segments []string) any {
+	if len(segments) == 0 {
+		return v
+	}
+	switch val := v.(type) {
+	case map[string]any:
+		return lookupPath(val[segments[0]], segments[1:])
+	case []any:
+		found := make([]any, 0, len(val))
+		for _, item := range val {
+			if child := lookupPath(item, segments); child != nil {
+				found = append(found, child)
+			}
+		}
+		return found
+	default:
+		return nil
+	}
+}
+
+// formatValue formats a generic JSON value for display. Arrays are joined with commas and
+// objects are shown as compact JSON.
+func formatValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case []any:
+		parts := make([]string, 0, len(val))
+		for _, item := range val {
+			if s := formatValue(item); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(data)
+	}
+}
+
+// renderTitle fills the {path} placeholders of title with the values of obj.
+func renderTitle(title string, obj map[string]any) string {
+	filled := titlePlaceholder.ReplaceAllStringFunc(title, func(m string) string {
+		return formatValue(lookupPath(obj, strings.Split(m[1:len(m)-1], ".")))
+	})
+	return strings.TrimSpace(filled)
+}
+
+// titlePaths returns the dotted paths referenced by the placeholders of title.
+func titlePaths(title string) []string {
+	var paths []string
+	for _, m := range titlePlaceholder.FindAllStringSubmatch(title, -1) {
+		paths = append(paths, m[1])
+	}
+	return paths
+}
+
+// tableCell escapes s for use in a markdown table cell, keeping it on one line and cutting
+// it at maxCellLength characters.
+func tableCell(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > maxCellLength {
+		s = string(r[:maxCellLength-1]) + "…"
+	}
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// paginationSummary describes how many items a rendered list holds and how to continue it.
+func paginationSummary(count int, p *Pagination) string {
+	if p == nil {
+		return ""
+	}
+	parts := []string{fmt.Sprintf("%d item(s)", count)}
+	if p.Page > 0 {
+		parts = append(parts, fmt.Sprintf("from page %d", p.Page))
+	}
+	if p.TotalItems != nil {
+		parts = append(parts, fmt.Sprintf("%d in total", *p.TotalItems))
+	}
+	switch {
+	case p.Continuation != "":
+		parts = append(parts, fmt.Sprintf("cut to fit the size limit, rest with continuation %q", p.Continuation))
+	case p.NextCursor != "":
+		parts = append(parts, fmt.Sprintf("more with cursor %q", p.NextCursor))
+	case p.NextPage > 0:
+		parts = append(parts, fmt.Sprintf("more with page %d", p.NextPage))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// toGeneric converts v to its generic JSON form (maps, slices and scalars) for rendering.
+func toGeneric(v any) (any, error) {
+	return fieldSet{}.project(v)
+}
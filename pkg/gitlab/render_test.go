@@ -0,0 +1,170 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestRenderList(t *testing.T) {
+	issues := []any{
+		map[string]any{
+			"iid": 7, "title": "Fix login", "state": "opened", "labels": []any{"bug", "ui"},
+			"assignees":   []any{map[string]any{"username": "bob"}, map[string]any{"username": "carol"}},
+			"description": "Login fails\nwith SSO.",
+		},
+		map[string]any{"iid": 8, "title": "Add | pipes", "state": "closed", "labels": []any{}},
+	}
+	paths := []string{"iid", "title", "state", "labels", "assignees.username", "description"}
+	total := 12
+	pagination := &Pagination{Page: 1, PerPage: 2, PagesFetched: 1, NextPage: 2, TotalItems: &total, HasMore: true}
+
+	t.Run("Markdown Cards", func(t *testing.T) {
+		out := outputOptions{fields: parseFields(paths), paths: paths, format: FormatMarkdown, title: issueSchema.title}
+		text, err := renderList(issues, pagination, out)
+		require.NoError(t, err)
+		expected := "### #7 Fix login\n" +
+			"- **state:** opened\n" +
+			"- **labels:** bug, ui\n" +
+			"- **assignees.username:** bob, carol\n\n" +
+			"**description:**\n\nLogin fails\nwith SSO.\n\n" +
+			"### #8 Add | pipes\n" +
+			"- **state:** closed\n\n" +
+			"_2 item(s), from page 1, 12 in total, more with page 2_"
+		assert.Equal(t, expected, text)
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		out := outputOptions{fields: parseFields(paths), paths: paths, format: FormatTable, title: issueSchema.title}
+		text, err := renderList(issues, pagination, out)
+		require.NoError(t, err)
+		expected := "| iid | title | state | labels | assignees.username | description |\n" +
+			"|---|---|---|---|---|---|\n" +
+			"| 7 | Fix login | opened | bug, ui | bob, carol | Login fails with SSO. |\n" +
+			"| 8 | Add \\| pipes | closed |  |  |  |\n\n" +
+			"_2 item(s), from page 1, 12 in total, more with page 2_"
+		assert.Equal(t, expected, text)
+	})
+
+	t.Run("Table With All Fields", func(t *testing.T) {
+		items := []any{
+			map[string]any{"name": "main", "commit": map[string]any{"id": "abc"}},
+			map[string]any{"name": "dev", "protected": true},
+		}
+		text, err := renderList(items, nil, outputOptions{format: FormatTable})
+		require.NoError(t, err)
+		expected := "| commit.id | name | protected |\n" +
+			"|---|---|---|\n" +
+			"| abc | main |  |\n" +
+			"|  | dev | true |"
+		assert.Equal(t, expected, text)
+	})
+
+	t.Run("Empty List", func(t *testing.T) {
+		text, err := renderList([]any{}, &Pagination{PerPage: 20, PagesFetched: 1}, outputOptions{format: FormatMarkdown})
+		require.NoError(t, err)
+		assert.Equal(t, "_No results._\n\n_0 item(s)_", text)
+	})
+}
+
+func TestRenderObject(t *testing.T) {
+	mr := map[string]any{
+		"iid": 3, "title": "Add cache", "state": "merged",
+		"author":      map[string]any{"username": "alice"},
+		"description": "Caches GET requests.",
+	}
+	paths := []string{"iid", "title", "state", "author.username", "description"}
+
+	t.Run("Markdown Card", func(t *testing.T) {
+		out := outputOptions{fields: parseFields(paths), paths: paths, format: FormatMarkdown, title: mergeRequestSchema.title}
+		text, err := renderObject(mr, out)
+		require.NoError(t, err)
+		expected := "## !3 Add cache\n" +
+			"- **state:** merged\n" +
+			"- **author.username:** alice\n\n" +
+			"**description:**\n\nCaches GET requests."
+		assert.Equal(t, expected, text)
+	})
+
+	t.Run("Field Value Table", func(t *testing.T) {
+		out := outputOptions{format: FormatTable, title: mergeRequestSchema.title}
+		text, err := renderObject(mr, out)
+		require.NoError(t, err)
+		expected := "## !3 Add cache\n\n" +
+			"| Field | Value |\n|---|---|\n" +
+			"| author.username | alice |\n" +
+			"| description | Caches GET requests. |\n" +
+			"| iid | 3 |\n" +
+			"| state | merged |\n" +
+			"| title | Add cache |"
+		assert.Equal(t, expected, text)
+	})
+}
+
+func TestTableCell(t *testing.T) {
+	assert.Equal(t, `a \| b`, tableCell("a | b"))
+	assert.Equal(t, "one two", tableCell("one\n  two"))
+	long := []rune(tableCell(strings.Repeat("y", 200)))
+	assert.Len(t, long, maxCellLength)
+	assert.Equal(t, '…', long[maxCellLength-1])
+}
+
+func TestGetProjectCommitsHandlerFormat(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockCommits, ctrl := setupMockClientForCommits(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	tool, handler := GetProjectCommits(mockGetClient)
+
+	commits := []*gl.Commit{
+		{ID: "abc123", ShortID: "abc", Title: "Add cache", AuthorName: "Alice"},
+		{ID: "def456", ShortID: "def", Title: "Fix bug", AuthorName: "Bob"},
+	}
+	mockCommits.EXPECT().
+		ListCommits("group/project", gomock.Any(), gomock.Any()).
+		Return(commits, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil).
+		Times(2)
+
+	t.Run("Table", func(t *testing.T) {
+		args := map[string]any{"projectId": "group/project", "fields": "short_id,title,author_name", "format": "table"}
+		result, err := handler(ctx, createCallToolRequest(tool.Name, args))
+		require.NoError(t, err)
+		expected := "| short_id | title | author_name |\n" +
+			"|---|---|---|\n" +
+			"| abc | Add cache | Alice |\n" +
+			"| def | Fix bug | Bob |\n\n" +
+			"_2 item(s), from page 1_"
+		assert.Equal(t, expected, getTextResult(t, result).Text)
+	})
+
+	t.Run("Server Default Format", func(t *testing.T) {
+		s := NewServer("test", "0.0.0", WithDefaultOutputFormat(FormatMarkdown))
+		s.AddTool(tool, handler)
+		response := s.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call",`+
+			`"params":{"name":"getProjectCommits","arguments":{"projectId":"group/project","fields":"short_id,title"}}}`))
+		resp, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "Expected a JSON-RPC response, got %T", response)
+		result, ok := resp.Result.(mcp.CallToolResult)
+		require.True(t, ok, "Expected a tool result, got %T", resp.Result)
+		assert.Equal(t, "### abc Add cache\n\n### def Fix bug\n\n_2 item(s), from page 1_", getTextResult(t, &result).Text)
+	})
+
+	t.Run("Invalid Format", func(t *testing.T) {
+		args := map[string]any{"projectId": "group/project", "format": "xml"}
+		result, err := handler(ctx, createCallToolRequest(tool.Name, args))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "invalid 'format' parameter")
+	})
+}
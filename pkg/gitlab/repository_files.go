@@ -3,9 +3,7 @@ package gitlab
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
-	"net/http"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -16,10 +14,10 @@ import (
 func GetProjectFile(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool(
 			"getProjectFile",
-			mcp.WithDescription("Retrieves the content of a specific file within a GitLab project repository."),
+			mcp.WithDescription("Retrieves the content of a specific file within a GitLab project repository. Files larger than the server's response size limit are returned in parts ending with a continuation token."),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        "Get Project File Content",
-				ReadOnlyHint: true,
+				ReadOnlyHint: mcp.ToBoolPtr(true),
 			}),
 			mcp.WithString("projectId",
 				mcp.Required(),
@@ -32,6 +30,7 @@ func GetProjectFile(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHa
 			mcp.WithString("ref",
 				mcp.Description("The name of branch, tag, or commit SHA (defaults to the repository's default branch)."),
 			),
+			WithContinuation(),
 		),
 		// Handler function implementation
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -48,6 +47,10 @@ func GetProjectFile(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHa
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil // Should not happen with string?
 			}
+			cont, err := optionalContinuationParam(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
 
 			// --- Construct GitLab API options
 			opts := &gl.GetFileOptions{}
@@ -66,16 +69,11 @@ func GetProjectFile(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHa
 
 			// --- Handle API errors
 			if err != nil {
-				code := http.StatusInternalServerError
-				if resp != nil {
-					code = resp.StatusCode
-				}
-				if code == http.StatusNotFound {
-					// Could be project not found or file not found
-					msg := fmt.Sprintf("project %q or file %q not found, or access denied (ref: %q) (%d)", projectIDStr, filePath, ref, code)
-					return mcp.NewToolResultError(msg), nil
-				}
-				return nil, fmt.Errorf("failed to get file %q from project %q (ref: %q): %w (status: %d)", filePath, projectIDStr, ref, err, code)
+				// A 404 could be project not found or file not found
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get file %q from project %q (ref: %q)", filePath, projectIDStr, ref),
+					fmt.Sprintf("project %q or file %q not found, or access denied (ref: %q)", projectIDStr, filePath, ref),
+				), nil
 			}
 
 			// --- Decode Base64 content
@@ -84,9 +82,24 @@ func GetProjectFile(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHa
 				return nil, fmt.Errorf("failed to decode base64 content for file %q: %w", filePath, err)
 			}
 
-			// --- Return success
-			// Consider returning metadata as well? For now, just content.
-			return mcp.NewToolResultText(string(decodedContent)), nil
+			// --- Continue from a previous truncated read
+			content := string(decodedContent)
+			if cont.Version != "" && cont.Version != file.BlobID {
+				msg := fmt.Sprintf("file %q changed since the continuation was issued; read it again without 'continuation'", filePath)
+				return mcp.NewToolResultError(msg), nil
+			}
+			start := min(cont.Offset, len(content))
+
+			// --- Return success, cut at a line boundary when over the response budget
+			budget := responseBudget(ctx)
+			chunk, truncated := truncateText(content[start:], max(budget-truncationNoticeReserve, 1))
+			if budget <= 0 || !truncated {
+				return mcp.NewToolResultText(content[start:]), nil
+			}
+			end := start + len(chunk)
+			next := continuation{Tool: request.Params.Name, Offset: end, Version: file.BlobID}
+			notice := fmt.Sprintf("\n[truncated: bytes %d-%d of %d returned; call again with continuation %q for the rest]", start, end, len(content), next.encode())
+			return mcp.NewToolResultText(chunk + notice), nil
 		}
 }
 
@@ -97,7 +110,7 @@ func ListProjectFiles(getClient GetClientFn) (tool mcp.Tool, handler server.Tool
 			mcp.WithDescription("Retrieves a list of files and directories within a specific path in a GitLab project repository."),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        "List Project Files/Directories",
-				ReadOnlyHint: true,
+				ReadOnlyHint: mcp.ToBoolPtr(true),
 			}),
 			mcp.WithString("projectId",
 				mcp.Required(),
@@ -114,6 +127,7 @@ func ListProjectFiles(getClient GetClientFn) (tool mcp.Tool, handler server.Tool
 			),
 			// Add standard MCP pagination parameters for potentially large listings
 			WithPagination(),
+			WithListOutput(treeNodeSchema),
 		),
 		// Handler function implementation
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -134,7 +148,11 @@ func ListProjectFiles(getClient GetClientFn) (tool mcp.Tool, handler server.Tool
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
-			page, perPage, err := OptionalPaginationParams(&request)
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, treeNodeSchema)
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
 			}
@@ -142,8 +160,7 @@ func ListProjectFiles(getClient GetClientFn) (tool mcp.Tool, handler server.Tool
 			// --- Construct GitLab API options
 			opts := &gl.ListTreeOptions{
 				ListOptions: gl.ListOptions{
-					Page:    page,
-					PerPage: perPage,
+					PerPage: listParams.PerPage,
 				},
 				Recursive: recursive, // Assign directly, pointers handled by OptionalBoolParam
 			}
@@ -161,32 +178,21 @@ func ListProjectFiles(getClient GetClientFn) (tool mcp.Tool, handler server.Tool
 			}
 
 			// --- Call GitLab API
-			tree, resp, err := glClient.Repositories.ListTree(projectIDStr, opts, gl.WithContext(ctx))
+			tree, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.TreeNode, *gl.Response, error) {
+				opts.Page = page
+				return glClient.Repositories.ListTree(projectIDStr, opts, gl.WithContext(ctx))
+			})
 
 			// --- Handle API errors
 			if err != nil {
-				code := http.StatusInternalServerError
-				if resp != nil {
-					code = resp.StatusCode
-				}
-				if code == http.StatusNotFound {
-					// Could be project not found or path not found
-					msg := fmt.Sprintf("project %q or path %q not found, or access denied (ref: %q) (%d)", projectIDStr, path, ref, code)
-					return mcp.NewToolResultError(msg), nil
-				}
-				return nil, fmt.Errorf("failed to list repository tree for project %q (path: %q, ref: %q): %w (status: %d)", projectIDStr, path, ref, err, code)
+				// A 404 could be project not found or path not found
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list repository tree for project %q (path: %q, ref: %q)", projectIDStr, path, ref),
+					fmt.Sprintf("project %q or path %q not found, or access denied (ref: %q)", projectIDStr, path, ref),
+				), nil
 			}
 
 			// --- Marshal and return success
-			// Handle empty list gracefully
-			if len(tree) == 0 {
-				return mcp.NewToolResultText("[]"), nil // Return empty JSON array
-			}
-
-			data, err := json.Marshal(tree)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal repository tree data: %w", err)
-			}
-			return mcp.NewToolResultText(string(data)), nil
+			return newListResult(tree, pagination, output, "repository tree data")
 		}
 }
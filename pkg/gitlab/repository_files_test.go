@@ -110,9 +110,9 @@ func TestGetProjectFileHandler(t *testing.T) {
 					GetFile(projectID, filePath, expectedOpts, gomock.Any()).
 					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
 			},
-			expectedResult:     "",
-			expectHandlerError: true,
-			errorContains:      fmt.Sprintf("failed to get file %q from project %q", filePath, projectID),
+			expectedResult:    "",
+			expectResultError: true,
+			errorContains:     fmt.Sprintf("failed to get file %q from project %q", filePath, projectID),
 		},
 		{
 			name:               "Error - Missing projectId",
@@ -141,13 +141,7 @@ func TestGetProjectFileHandler(t *testing.T) {
 
 			// Create the request
 			req := mcp.CallToolRequest{
-				Params: struct {
-					Name      string                 `json:"name"`
-					Arguments map[string]interface{} `json:"arguments,omitempty"`
-					Meta      *struct {
-						ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-					} `json:"_meta,omitempty"`
-				}{
+				Params: mcp.CallToolParams{
 					Name:      getProjectFileTool.Name,
 					Arguments: tc.inputArgs,
 				},
@@ -331,9 +325,9 @@ func TestListProjectFilesHandler(t *testing.T) {
 					ListTree(projectID, gomock.Any(), gomock.Any()).
 					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
 			},
-			expectedResult:     nil,
-			expectHandlerError: true,
-			errorContains:      fmt.Sprintf("failed to list repository tree for project %q", projectID),
+			expectedResult:    nil,
+			expectResultError: true,
+			errorContains:     fmt.Sprintf("failed to list repository tree for project %q", projectID),
 		},
 		{
 			name:               "Error - Missing projectId",
@@ -353,13 +347,7 @@ func TestListProjectFilesHandler(t *testing.T) {
 
 			// Create the request
 			req := mcp.CallToolRequest{
-				Params: struct {
-					Name      string                 `json:"name"`
-					Arguments map[string]interface{} `json:"arguments,omitempty"`
-					Meta      *struct {
-						ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-					} `json:"_meta,omitempty"`
-				}{
+				Params: mcp.CallToolParams{
 					Name:      listProjectFilesTool.Name,
 					Arguments: tc.inputArgs,
 				},
@@ -382,8 +370,9 @@ func TestListProjectFilesHandler(t *testing.T) {
 					assert.Contains(t, textContent.Text, tc.errorContains, "Error message mismatch")
 				} else {
 					// Unmarshal expected and actual results
+					items, _ := getListResult(t, result)
 					var actualTree []*gl.TreeNode
-					err = json.Unmarshal([]byte(textContent.Text), &actualTree)
+					err = json.Unmarshal([]byte(items), &actualTree)
 					require.NoError(t, err, "Failed to unmarshal actual result JSON")
 
 					// Compare lengths first
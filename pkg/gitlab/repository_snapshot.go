@@ -0,0 +1,292 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	gl "gitlab.com/gitlab-org/api/client-go" // GitLab client library
+)
+
+// DefaultSnapshotMaxBytes defines the default total content budget of a repository snapshot.
+const DefaultSnapshotMaxBytes = 200000
+
+// DefaultSnapshotMaxFiles defines the default maximum number of files packed into a repository snapshot.
+const DefaultSnapshotMaxFiles = 100
+
+// snapshotFile is a single file packed into a repository snapshot.
+type snapshotFile struct {
+	Path    string `json:"path"`
+	Size    int    `json:"size"`
+	Content string `json:"content"`
+}
+
+// snapshotTreeLimit caps the number of tree entries a repository snapshot walks.
+const snapshotTreeLimit = MaxItemsLimit
+
+// snapshotSkipped records a file that was read but whose content was not packed, and why.
+type snapshotSkipped struct {
+	Path   string `json:"path"`
+	Size   int    `json:"size,omitempty"`
+	Reason string `json:"reason"` // "budget", "binary", "error", "response_limit"
+	Error  string `json:"error,omitempty"`
+}
+
+// snapshotResult is the payload returned by the getRepositorySnapshot tool.
+type snapshotResult struct {
+	Ref           string             `json:"ref,omitempty"`
+	Path          string             `json:"path,omitempty"`
+	FilesMatched  int                `json:"files_matched"`
+	TreeTruncated bool               `json:"tree_truncated,omitempty"` // the tree has more entries than were walked
+	FilesNotRead  int                `json:"files_not_read,omitempty"` // matched files left unread once maxFiles or the budget was reached
+	TotalBytes    int                `json:"total_bytes"`
+	Files         []*snapshotFile    `json:"files"`
+	Skipped       []*snapshotSkipped `json:"skipped,omitempty"`
+}
+
+// globToRegexp converts a path glob into an anchored regular expression.
+// '**' matches across directories, '*' and '?' match within a single path segment.
+func globToRegexp(glob string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				// "**/" also matches zero directories
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					i++
+					sb.WriteString("(?:.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// pathGlob is a compiled include/exclude glob.
+type pathGlob struct {
+	re *regexp.Regexp
+	// baseName is set for globs without '/', which are also matched against the file name alone.
+	baseName bool
+}
+
+// compileGlobs compiles a comma-separated list of globs.
+func compileGlobs(list string) ([]pathGlob, error) {
+	var res []pathGlob
+	for _, glob := range splitCommaList(list) {
+		re, err := globToRegexp(glob)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", glob, err)
+		}
+		res = append(res, pathGlob{re: re, baseName: !strings.Contains(glob, "/")})
+	}
+	return res, nil
+}
+
+// matchesAny reports whether path matches any of the globs.
+func matchesAny(globs []pathGlob, path string) bool {
+	base := path[strings.LastIndex(path, "/")+1:]
+	for _, g := range globs {
+		if g.re.MatchString(path) || (g.baseName && g.re.MatchString(base)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBinaryContent uses the same heuristic as git: content with a NUL byte or invalid UTF-8 is binary.
+func isBinaryContent(content []byte) bool {
+	sniff := content
+	if len(sniff) > 8000 {
+		sniff = sniff[:8000]
+	}
+	return bytes.IndexByte(sniff, 0) >= 0 || !utf8.Valid(content)
+}
+
+// GetRepositorySnapshot defines the MCP tool for packing the contents of many repository files into one result.
+func GetRepositorySnapshot(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getRepositorySnapshot",
+			mcp.WithDescription(fmt.Sprintf("Returns a packed snapshot (path, size, content) of the text files under a path prefix at a ref, filtered by include/exclude globs and limited by a total byte budget. Files are read in path order until 'maxFiles' files were read or the budget is spent; the rest are counted in 'files_not_read'. At most %d tree entries are walked ('tree_truncated' is set beyond). Use this instead of many getProjectFile calls to read a module.", snapshotTreeLimit)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get Repository Snapshot",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			WithResultSchema(snapshotResult{}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("ref",
+				mcp.Description("The name of branch, tag, or commit SHA (defaults to the repository's default branch)."),
+			),
+			mcp.WithString("path",
+				mcp.Description("The directory to snapshot, relative to the repository root. Defaults to the root directory."),
+			),
+			mcp.WithString("include",
+				mcp.Description("Comma-separated globs of files to include (e.g., '*.go,docs/**/*.md'). '**' matches across directories; a glob without '/' matches file names in any directory. Default: all files."),
+			),
+			mcp.WithString("exclude",
+				mcp.Description("Comma-separated globs of files to exclude, applied after 'include' (e.g., '*_test.go,vendor/**')."),
+			),
+			mcp.WithNumber("maxBytes",
+				mcp.Description(fmt.Sprintf("Total content budget in bytes, lowered to the server's response limit; files that would exceed it are listed as skipped (default: %d).", DefaultSnapshotMaxBytes)),
+			),
+			mcp.WithNumber("maxFiles",
+				mcp.Description(fmt.Sprintf("Maximum number of files to read, including the skipped ones (default: %d).", DefaultSnapshotMaxFiles)),
+			),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			ref, err := OptionalParam[string](&request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			path, err := OptionalParam[string](&request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			include, err := OptionalParam[string](&request, "include")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			exclude, err := OptionalParam[string](&request, "exclude")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			maxBytes, err := OptionalIntParamWithDefault(&request, "maxBytes", DefaultSnapshotMaxBytes)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			maxFiles, err := OptionalIntParamWithDefault(&request, "maxFiles", DefaultSnapshotMaxFiles)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			includeGlobs, err := compileGlobs(include)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: parameter 'include': %v", err)), nil
+			}
+			excludeGlobs, err := compileGlobs(exclude)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: parameter 'exclude': %v", err)), nil
+			}
+			path = strings.Trim(path, "/")
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Walk the recursive tree, collecting matching blobs
+			treeOpts := &gl.ListTreeOptions{
+				ListOptions: gl.ListOptions{PerPage: MaxPerPage},
+				Recursive:   gl.Ptr(true),
+			}
+			if path != "" {
+				treeOpts.Path = &path
+			}
+			if ref != "" {
+				treeOpts.Ref = &ref
+			}
+			treeParams := ListParams{Page: 1, PerPage: MaxPerPage, MaxItems: snapshotTreeLimit}
+			nodes, pagination, resp, err := collectPages(treeParams, func(page int) ([]*gl.TreeNode, *gl.Response, error) {
+				treeOpts.Page = page
+				return glClient.Repositories.ListTree(projectIDStr, treeOpts, gl.WithContext(ctx))
+			})
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list repository tree for project %q (path: %q, ref: %q)", projectIDStr, path, ref),
+					fmt.Sprintf("project %q or path %q not found, or access denied (ref: %q)", projectIDStr, path, ref),
+				), nil
+			}
+
+			var matched []string
+			for _, node := range nodes {
+				if node.Type != "blob" {
+					continue
+				}
+				rel := strings.TrimPrefix(strings.TrimPrefix(node.Path, path), "/")
+				if len(includeGlobs) > 0 && !matchesAny(includeGlobs, rel) {
+					continue
+				}
+				if matchesAny(excludeGlobs, rel) {
+					continue
+				}
+				matched = append(matched, node.Path)
+			}
+
+			// --- Fetch file contents within the budget
+			budget := responseBudget(ctx)
+			if budget > 0 {
+				// The content is only part of the response, which is trimmed further below
+				maxBytes = min(maxBytes, budget)
+			}
+			result := &snapshotResult{
+				Ref:           ref,
+				Path:          path,
+				FilesMatched:  len(matched),
+				TreeTruncated: pagination.HasMore,
+				Files:         []*snapshotFile{},
+			}
+			fileOpts := &gl.GetRawFileOptions{}
+			if ref != "" {
+				fileOpts.Ref = &ref
+			}
+			for i, filePath := range matched {
+				if i >= maxFiles || result.TotalBytes >= maxBytes {
+					result.FilesNotRead = len(matched) - i
+					break
+				}
+				content, _, err := glClient.RepositoryFiles.GetRawFile(projectIDStr, filePath, fileOpts, gl.WithContext(ctx))
+				if err != nil {
+					result.Skipped = append(result.Skipped, &snapshotSkipped{Path: filePath, Reason: "error", Error: err.Error()})
+					continue
+				}
+				if isBinaryContent(content) {
+					result.Skipped = append(result.Skipped, &snapshotSkipped{Path: filePath, Size: len(content), Reason: "binary"})
+					continue
+				}
+				if result.TotalBytes+len(content) > maxBytes {
+					result.Skipped = append(result.Skipped, &snapshotSkipped{Path: filePath, Size: len(content), Reason: "budget"})
+					continue
+				}
+				result.TotalBytes += len(content)
+				result.Files = append(result.Files, &snapshotFile{Path: filePath, Size: len(content), Content: string(content)})
+			}
+
+			// JSON escaping and the metadata can still take the response over its limit; the
+			// last packed files make room
+			for budget > 0 && len(result.Files) > 0 && jsonSize(result) > budget {
+				last := result.Files[len(result.Files)-1]
+				result.Files = result.Files[:len(result.Files)-1]
+				result.TotalBytes -= last.Size
+				result.Skipped = append(result.Skipped, &snapshotSkipped{Path: last.Path, Size: last.Size, Reason: "response_limit"})
+			}
+
+			// --- Marshal and return success
+			return newJSONResult(result, "repository snapshot data")
+		}
+}
@@ -0,0 +1,223 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		glob string
+		path string
+		want bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "pkg/main.go", false},
+		{"pkg/*.go", "pkg/main.go", true},
+		{"pkg/*.go", "pkg/sub/main.go", false},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "pkg/sub/main.go", true},
+		{"vendor/**", "vendor/a/b.go", true},
+		{"file?.txt", "file1.txt", true},
+		{"file?.txt", "file10.txt", false},
+		{"a.b", "axb", false},
+	}
+	for _, tc := range tests {
+		re, err := globToRegexp(tc.glob)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, re.MatchString(tc.path), "glob %q vs path %q", tc.glob, tc.path)
+	}
+
+	globs, err := compileGlobs("*_test.go, docs/*.md")
+	require.NoError(t, err)
+	assert.True(t, matchesAny(globs, "pkg/x/foo_test.go"), "base-name glob should match in any directory")
+	assert.True(t, matchesAny(globs, "docs/index.md"))
+	assert.False(t, matchesAny(globs, "pkg/docs/index.md"))
+}
+
+func TestGetRepositorySnapshotHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockRepos, mockFiles, ctrl := setupMockClientForTreeAndFiles(t)
+	defer ctrl.Finish()
+
+	mockGetClient := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	snapshotTool, snapshotHandler := GetRepositorySnapshot(mockGetClient)
+
+	projectID := "group/project"
+	ref := "main"
+	page1 := []*gl.TreeNode{
+		{Type: "tree", Path: "pkg/auth"},
+		{Type: "blob", Path: "pkg/auth/auth.go"},
+		{Type: "blob", Path: "pkg/auth/auth_test.go"},
+	}
+	page2 := []*gl.TreeNode{
+		{Type: "blob", Path: "pkg/auth/logo.png"},
+		{Type: "blob", Path: "pkg/auth/token.go"},
+		{Type: "blob", Path: "pkg/auth/README.md"},
+	}
+	contents := map[string][]byte{
+		"pkg/auth/auth.go":  []byte("package auth\n"),
+		"pkg/auth/token.go": []byte("package auth\n\nvar token string\n"),
+		"pkg/auth/logo.png": {0x89, 'P', 'N', 'G', 0x00, 0x01},
+	}
+
+	expectTree := func() {
+		mockRepos.EXPECT().
+			ListTree(projectID, gomock.AssignableToTypeOf(&gl.ListTreeOptions{}), gomock.Any()).
+			DoAndReturn(func(_ interface{}, opts *gl.ListTreeOptions, _ ...gl.RequestOptionFunc) ([]*gl.TreeNode, *gl.Response, error) {
+				assert.True(t, *opts.Recursive)
+				assert.Equal(t, "pkg/auth", *opts.Path)
+				assert.Equal(t, ref, *opts.Ref)
+				if opts.Page == 1 {
+					return page1, &gl.Response{Response: &http.Response{StatusCode: 200}, NextPage: 2}, nil
+				}
+				return page2, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil
+			}).Times(2)
+	}
+	expectFiles := func() {
+		mockFiles.EXPECT().
+			GetRawFile(projectID, gomock.Any(), &gl.GetRawFileOptions{Ref: &ref}, gomock.Any()).
+			DoAndReturn(func(_ interface{}, path string, _ *gl.GetRawFileOptions, _ ...gl.RequestOptionFunc) ([]byte, *gl.Response, error) {
+				if path == "pkg/auth/README.md" {
+					return nil, &gl.Response{Response: &http.Response{StatusCode: 403}}, errors.New("gitlab: 403 Forbidden")
+				}
+				return contents[path], &gl.Response{Response: &http.Response{StatusCode: 200}}, nil
+			}).AnyTimes()
+	}
+
+	t.Run("Success - Filters And Binary Detection", func(t *testing.T) {
+		expectTree()
+		expectFiles()
+
+		args := map[string]any{"projectId": projectID, "ref": ref, "path": "/pkg/auth/", "include": "*.go,*.png", "exclude": "*_test.go"}
+		result, err := snapshotHandler(ctx, createCallToolRequest(snapshotTool.Name, args))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var actual snapshotResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &actual))
+		assert.Equal(t, 3, actual.FilesMatched)
+		require.Len(t, actual.Files, 2)
+		assert.Equal(t, "pkg/auth/auth.go", actual.Files[0].Path)
+		assert.Equal(t, "package auth\n", actual.Files[0].Content)
+		assert.Equal(t, "pkg/auth/token.go", actual.Files[1].Path)
+		assert.Equal(t, len(contents["pkg/auth/auth.go"])+len(contents["pkg/auth/token.go"]), actual.TotalBytes)
+		require.Len(t, actual.Skipped, 1)
+		assert.Equal(t, "binary", actual.Skipped[0].Reason)
+	})
+
+	t.Run("Success - Byte Budget And File Cap", func(t *testing.T) {
+		expectTree()
+		expectFiles()
+
+		args := map[string]any{"projectId": projectID, "ref": ref, "path": "pkg/auth", "include": "*.go", "maxBytes": 20}
+		result, err := snapshotHandler(ctx, createCallToolRequest(snapshotTool.Name, args))
+		require.NoError(t, err)
+
+		var actual snapshotResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &actual))
+		require.Len(t, actual.Files, 2, "auth.go and auth_test.go fit the budget")
+		require.Len(t, actual.Skipped, 1)
+		assert.Equal(t, "pkg/auth/token.go", actual.Skipped[0].Path)
+		assert.Equal(t, "budget", actual.Skipped[0].Reason)
+		assert.LessOrEqual(t, actual.TotalBytes, 20)
+		assert.Zero(t, actual.FilesNotRead)
+	})
+
+	t.Run("Success - Stops Reading At File Cap", func(t *testing.T) {
+		// Fresh mocks, so no earlier expectation answers the reads counted here
+		mockClient, mockRepos, mockFiles, ctrl := setupMockClientForTreeAndFiles(t)
+		defer ctrl.Finish()
+		_, snapshotHandler := GetRepositorySnapshot(func(_ context.Context) (*gl.Client, error) { return mockClient, nil })
+
+		mockRepos.EXPECT().ListTree(projectID, gomock.Any(), gomock.Any()).
+			Return(append(page1, page2...), &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+		mockFiles.EXPECT().
+			GetRawFile(projectID, "pkg/auth/auth.go", gomock.Any(), gomock.Any()).
+			Return(contents["pkg/auth/auth.go"], &gl.Response{Response: &http.Response{StatusCode: 200}}, nil).
+			Times(1)
+
+		args := map[string]any{"projectId": projectID, "ref": ref, "path": "pkg/auth", "include": "*.go", "maxFiles": 1}
+		result, err := snapshotHandler(ctx, createCallToolRequest(snapshotTool.Name, args))
+		require.NoError(t, err)
+
+		var actual snapshotResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &actual))
+		assert.Equal(t, 3, actual.FilesMatched)
+		require.Len(t, actual.Files, 1)
+		assert.Empty(t, actual.Skipped, "Unread files are counted, not listed")
+		assert.Equal(t, 2, actual.FilesNotRead)
+	})
+
+	t.Run("Success - Unreadable File Is Skipped", func(t *testing.T) {
+		expectTree()
+		expectFiles()
+
+		args := map[string]any{"projectId": projectID, "ref": ref, "path": "pkg/auth", "include": "*.md,token.go"}
+		result, err := snapshotHandler(ctx, createCallToolRequest(snapshotTool.Name, args))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var actual snapshotResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &actual))
+		require.Len(t, actual.Files, 1)
+		assert.Equal(t, "pkg/auth/token.go", actual.Files[0].Path)
+		require.Len(t, actual.Skipped, 1)
+		assert.Equal(t, "pkg/auth/README.md", actual.Skipped[0].Path)
+		assert.Equal(t, "error", actual.Skipped[0].Reason)
+		assert.Contains(t, actual.Skipped[0].Error, "403")
+	})
+
+	t.Run("Success - Response Budget", func(t *testing.T) {
+		expectTree()
+		expectTree()
+		expectFiles()
+
+		args := map[string]any{"projectId": projectID, "ref": ref, "path": "pkg/auth", "include": "auth.go,token.go"}
+		result, err := snapshotHandler(ctx, createCallToolRequest(snapshotTool.Name, args))
+		require.NoError(t, err)
+		budget := len(getTextResult(t, result).Text) - 1
+
+		result, err = snapshotHandler(budgetContext(budget), createCallToolRequest(snapshotTool.Name, args))
+		require.NoError(t, err)
+		text := getTextResult(t, result).Text
+		assert.LessOrEqual(t, len(text), budget)
+
+		var actual snapshotResult
+		require.NoError(t, json.Unmarshal([]byte(text), &actual))
+		require.Len(t, actual.Files, 1)
+		assert.Equal(t, "pkg/auth/auth.go", actual.Files[0].Path)
+		require.Len(t, actual.Skipped, 1)
+		assert.Equal(t, "pkg/auth/token.go", actual.Skipped[0].Path)
+		assert.Equal(t, "response_limit", actual.Skipped[0].Reason)
+	})
+
+	t.Run("Error - Path Not Found (404)", func(t *testing.T) {
+		mockRepos.EXPECT().
+			ListTree(projectID, gomock.Any(), gomock.Any()).
+			Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Tree Not Found"))
+
+		result, err := snapshotHandler(ctx, createCallToolRequest(snapshotTool.Name, map[string]any{"projectId": projectID, "path": "nope"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "not found")
+	})
+
+	t.Run("Error - Invalid Glob Type", func(t *testing.T) {
+		result, err := snapshotHandler(ctx, createCallToolRequest(snapshotTool.Name, map[string]any{"projectId": projectID, "include": 5}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "Validation Error")
+	})
+}
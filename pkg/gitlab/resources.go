@@ -0,0 +1,265 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	gl "gitlab.com/gitlab-org/api/client-go" // GitLab client library
+)
+
+// URI templates of the GitLab resources, modeled on GitLab web URLs. {+project} accepts a
+// numeric ID or a full path with its slashes; refs containing a slash must be percent-encoded.
+const (
+	FileResourceTemplate         = "gitlab://{+project}/-/blob/{ref}/{+path}"
+	IssueResourceTemplate        = "gitlab://{+project}/-/issues/{iid}"
+	MergeRequestResourceTemplate = "gitlab://{+project}/-/merge_requests/{iid}"
+	PipelineResourceTemplate     = "gitlab://{+project}/-/pipelines/{id}"
+)
+
+// mergeRequestResource is the content of a merge request resource: the merge request and
+// the diffs of its files, capped like those of the compareRefs tool.
+type mergeRequestResource struct {
+	MergeRequest *gl.MergeRequest `json:"merge_request"`
+	Summary      struct {
+		FilesChanged int `json:"files_changed"`
+		Additions    int `json:"additions"`
+		Deletions    int `json:"deletions"`
+	} `json:"summary"`
+	Diffs []*fileDiff `json:"diffs"`
+	// DiffsOmitted counts file diffs dropped beyond DefaultMaxDiffFiles.
+	DiffsOmitted int `json:"diffs_omitted,omitempty"`
+}
+
+// FileResource defines the resource template for the content of a repository file at a ref.
+func FileResource(getClient GetClientFn) (template mcp.ResourceTemplate, handler server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			FileResourceTemplate,
+			"Repository file",
+			mcp.WithTemplateDescription("Content of a file in a GitLab repository at a branch, tag or commit SHA."),
+		),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			projectID, ref, filePath, err := resourceArguments3(request, "project", "ref", "path")
+			if err != nil {
+				return nil, err
+			}
+
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+			file, resp, err := glClient.RepositoryFiles.GetFile(projectID, filePath, &gl.GetFileOptions{Ref: &ref}, gl.WithContext(ctx))
+			if err != nil {
+				return nil, apiError(err, resp,
+					fmt.Sprintf("failed to get file %q from project %q (ref: %q)", filePath, projectID, ref),
+					fmt.Sprintf("project %q or file %q not found, or access denied (ref: %q)", projectID, filePath, ref),
+				)
+			}
+			content, err := base64.StdEncoding.DecodeString(file.Content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode base64 content for file %q: %w", filePath, err)
+			}
+
+			mimeType := mime.TypeByExtension(path.Ext(filePath))
+			if isBinaryContent(content) {
+				if mimeType == "" {
+					mimeType = "application/octet-stream"
+				}
+				return []mcp.ResourceContents{mcp.BlobResourceContents{URI: request.Params.URI, MIMEType: mimeType, Blob: file.Content}}, nil
+			}
+			if mimeType == "" {
+				mimeType = "text/plain"
+			}
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, MIMEType: mimeType, Text: string(content)}}, nil
+		}
+}
+
+// IssueResource defines the resource template for an issue.
+func IssueResource(getClient GetClientFn) (template mcp.ResourceTemplate, handler server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			IssueResourceTemplate,
+			"Issue",
+			mcp.WithTemplateDescription("A GitLab issue, as returned by the getIssue tool."),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			projectID, iid, err := resourceProjectAndIID(request)
+			if err != nil {
+				return nil, err
+			}
+
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+			issue, resp, err := glClient.Issues.GetIssue(projectID, iid, nil, gl.WithContext(ctx))
+			if err != nil {
+				return nil, apiError(err, resp,
+					fmt.Sprintf("failed to get issue %d from project %q", iid, projectID),
+					fmt.Sprintf("issue %d not found in project %q, or access denied", iid, projectID),
+				)
+			}
+			return jsonResourceContents(request.Params.URI, issue, "issue data")
+		}
+}
+
+// MergeRequestResource defines the resource template for a merge request with its diffs.
+func MergeRequestResource(getClient GetClientFn) (template mcp.ResourceTemplate, handler server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			MergeRequestResourceTemplate,
+			"Merge request",
+			mcp.WithTemplateDescription(fmt.Sprintf("A GitLab merge request with the diffs of its files (at most %d files of %d bytes each).", DefaultMaxDiffFiles, DefaultMaxDiffBytes)),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			projectID, iid, err := resourceProjectAndIID(request)
+			if err != nil {
+				return nil, err
+			}
+
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+			mr, resp, err := glClient.MergeRequests.GetMergeRequest(projectID, iid, nil, gl.WithContext(ctx))
+			if err != nil {
+				return nil, apiError(err, resp,
+					fmt.Sprintf("failed to get merge request %d from project %q", iid, projectID),
+					fmt.Sprintf("merge request %d not found in project %q, or access denied", iid, projectID),
+				)
+			}
+
+			params := ListParams{Page: 1, PerPage: MaxPerPage, MaxItems: MaxItemsLimit}
+			mrDiffs, _, resp, err := collectPages(params, func(page int) ([]*gl.MergeRequestDiff, *gl.Response, error) {
+				opts := &gl.ListMergeRequestDiffsOptions{ListOptions: gl.ListOptions{Page: page, PerPage: params.PerPage}}
+				return glClient.MergeRequests.ListMergeRequestDiffs(projectID, iid, opts, gl.WithContext(ctx))
+			})
+			if err != nil {
+				return nil, apiError(err, resp,
+					fmt.Sprintf("failed to list diffs of merge request %d in project %q", iid, projectID),
+					fmt.Sprintf("merge request %d not found in project %q, or access denied", iid, projectID),
+				)
+			}
+
+			diffs := make([]*gl.Diff, 0, len(mrDiffs))
+			for _, d := range mrDiffs {
+				diffs = append(diffs, &gl.Diff{
+					OldPath: d.OldPath, NewPath: d.NewPath, Diff: d.Diff,
+					NewFile: d.NewFile, RenamedFile: d.RenamedFile, DeletedFile: d.DeletedFile,
+				})
+			}
+			var summary diffSummary
+			result := mergeRequestResource{MergeRequest: mr}
+			result.Diffs, result.DiffsOmitted = buildFileDiffs(diffs, true, DefaultMaxDiffBytes, DefaultMaxDiffFiles, &summary)
+			result.Summary.FilesChanged, result.Summary.Additions, result.Summary.Deletions = summary.FilesChanged, summary.Additions, summary.Deletions
+			return jsonResourceContents(request.Params.URI, result, "merge request data")
+		}
+}
+
+// PipelineResource defines the resource template for a pipeline.
+func PipelineResource(getClient GetClientFn) (template mcp.ResourceTemplate, handler server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			PipelineResourceTemplate,
+			"Pipeline",
+			mcp.WithTemplateDescription("A GitLab CI/CD pipeline with its status. Subscribe to it to be notified when it changes, e.g., when it finishes."),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			projectID, err := resourceArgument(request, "project")
+			if err != nil {
+				return nil, err
+			}
+			rawID, err := resourceArgument(request, "id")
+			if err != nil {
+				return nil, err
+			}
+			pipelineID, err := strconv.Atoi(rawID)
+			if err != nil || pipelineID <= 0 {
+				return nil, fmt.Errorf("invalid resource URI %q: id must be a positive integer", request.Params.URI)
+			}
+
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+			pipeline, resp, err := glClient.Pipelines.GetPipeline(projectID, pipelineID, gl.WithContext(ctx))
+			if err != nil {
+				return nil, apiError(err, resp,
+					fmt.Sprintf("failed to get pipeline %d from project %q", pipelineID, projectID),
+					fmt.Sprintf("pipeline %d not found in project %q, or access denied", pipelineID, projectID),
+				)
+			}
+			return jsonResourceContents(request.Params.URI, pipeline, "pipeline data")
+		}
+}
+
+// apiError is the resource counterpart of apiErrorResult: resource reads have no error
+// result, so the same message is returned as the error of the read.
+func apiError(err error, resp *gl.Response, action, notFound string) error {
+	return errors.New(apiErrorMessage(err, resp, action, notFound, time.Now()))
+}
+
+// resourceArgument returns the variable name matched in the URI of a resource template.
+func resourceArgument(request mcp.ReadResourceRequest, name string) (string, error) {
+	var value string
+	switch v := request.Params.Arguments[name].(type) {
+	case string:
+		value = v
+	case []string:
+		if len(v) == 1 {
+			value = v[0]
+		}
+	}
+	if value == "" {
+		return "", fmt.Errorf("invalid resource URI %q: missing %s", request.Params.URI, name)
+	}
+	return value, nil
+}
+
+// resourceArguments3 returns three variables matched in the URI of a resource template.
+func resourceArguments3(request mcp.ReadResourceRequest, a, b, c string) (string, string, string, error) {
+	values := make([]string, 3)
+	for i, name := range []string{a, b, c} {
+		value, err := resourceArgument(request, name)
+		if err != nil {
+			return "", "", "", err
+		}
+		values[i] = value
+	}
+	return values[0], values[1], values[2], nil
+}
+
+// resourceProjectAndIID returns the project and the IID matched in an issue or merge request URI.
+func resourceProjectAndIID(request mcp.ReadResourceRequest) (string, int, error) {
+	projectID, err := resourceArgument(request, "project")
+	if err != nil {
+		return "", 0, err
+	}
+	rawIID, err := resourceArgument(request, "iid")
+	if err != nil {
+		return "", 0, err
+	}
+	iid, err := strconv.Atoi(rawIID)
+	if err != nil || iid <= 0 {
+		return "", 0, fmt.Errorf("invalid resource URI %q: iid must be a positive integer", request.Params.URI)
+	}
+	return projectID, iid, nil
+}
+
+// jsonResourceContents returns v encoded as the JSON content of the resource at uri. what
+// names the resource in the error message.
+func jsonResourceContents(uri string, v any, what string) ([]mcp.ResourceContents, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", what, err)
+	}
+	return []mcp.ResourceContents{mcp.TextResourceContents{URI: uri, MIMEType: "application/json", Text: string(data)}}, nil
+}
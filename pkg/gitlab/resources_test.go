@@ -0,0 +1,160 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gl "gitlab.com/gitlab-org/api/client-go"
+	mock_gitlab "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFileResource(t *testing.T) {
+	client, mockFiles, ctrl := setupMockClientForFiles(t)
+	defer ctrl.Finish()
+	s := NewServer("test", "0.0.0")
+	s.AddResourceTemplate(FileResource(func(_ context.Context) (*gl.Client, error) { return client, nil }))
+
+	t.Run("Text File In Nested Project", func(t *testing.T) {
+		content := "Hello\n"
+		mockFiles.EXPECT().
+			GetFile("group/sub/project", "docs/READ ME", &gl.GetFileOptions{Ref: gl.Ptr("feature/x")}, gomock.Any()).
+			Return(&gl.File{Content: base64.StdEncoding.EncodeToString([]byte(content))}, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+
+		uri := "gitlab://group/sub/project/-/blob/feature%2Fx/docs/READ%20ME"
+		contents, errMsg := readResource(t, s, uri)
+		require.Empty(t, errMsg)
+		require.Len(t, contents, 1)
+		text, ok := contents[0].(mcp.TextResourceContents)
+		require.True(t, ok, "Expected text contents, got %T", contents[0])
+		assert.Equal(t, uri, text.URI)
+		assert.Equal(t, content, text.Text)
+		assert.Equal(t, "text/plain", text.MIMEType, "Extensions without a known type are plain text")
+	})
+
+	t.Run("Binary File", func(t *testing.T) {
+		encoded := base64.StdEncoding.EncodeToString([]byte("\x89PNG\x00\x01"))
+		mockFiles.EXPECT().
+			GetFile("42", "logo.png", &gl.GetFileOptions{Ref: gl.Ptr("main")}, gomock.Any()).
+			Return(&gl.File{Content: encoded}, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+
+		contents, errMsg := readResource(t, s, "gitlab://42/-/blob/main/logo.png")
+		require.Empty(t, errMsg)
+		require.Len(t, contents, 1)
+		blob, ok := contents[0].(mcp.BlobResourceContents)
+		require.True(t, ok, "Expected blob contents, got %T", contents[0])
+		assert.Equal(t, encoded, blob.Blob)
+		assert.Equal(t, "image/png", blob.MIMEType)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockFiles.EXPECT().
+			GetFile("group/project", "missing.txt", &gl.GetFileOptions{Ref: gl.Ptr("main")}, gomock.Any()).
+			Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, gl.ErrNotFound)
+
+		_, errMsg := readResource(t, s, "gitlab://group/project/-/blob/main/missing.txt")
+		assert.Contains(t, errMsg, `project "group/project" or file "missing.txt" not found, or access denied`)
+	})
+}
+
+func TestIssueResource(t *testing.T) {
+	client, mockIssues, ctrl := setupMockClientForIssues(t)
+	defer ctrl.Finish()
+	s := NewServer("test", "0.0.0")
+	s.AddResourceTemplate(IssueResource(func(_ context.Context) (*gl.Client, error) { return client, nil }))
+
+	issue := &gl.Issue{ID: 100, IID: 7, ProjectID: 1, Title: "Broken build"}
+	mockIssues.EXPECT().
+		GetIssue("group/project", 7, nil, gomock.Any()).
+		Return(issue, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+
+	contents, errMsg := readResource(t, s, "gitlab://group/project/-/issues/7")
+	require.Empty(t, errMsg)
+	require.Len(t, contents, 1)
+	text, ok := contents[0].(mcp.TextResourceContents)
+	require.True(t, ok, "Expected text contents, got %T", contents[0])
+	assert.Equal(t, "application/json", text.MIMEType)
+	expected, err := json.Marshal(issue)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), text.Text)
+
+	_, errMsg = readResource(t, s, "gitlab://group/project/-/issues/abc")
+	assert.Contains(t, errMsg, "iid must be a positive integer")
+}
+
+func TestMergeRequestResource(t *testing.T) {
+	client, mockMRs, ctrl := setupMockClientForMergeRequests(t)
+	defer ctrl.Finish()
+	s := NewServer("test", "0.0.0")
+	s.AddResourceTemplate(MergeRequestResource(func(_ context.Context) (*gl.Client, error) { return client, nil }))
+
+	t.Run("With Diffs", func(t *testing.T) {
+		mr := &gl.MergeRequest{BasicMergeRequest: gl.BasicMergeRequest{ID: 300, IID: 3, Title: "Add feature"}}
+		mockMRs.EXPECT().
+			GetMergeRequest("group/project", 3, nil, gomock.Any()).
+			Return(mr, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+		mockMRs.EXPECT().
+			ListMergeRequestDiffs("group/project", 3, gomock.Any(), gomock.Any()).
+			Return([]*gl.MergeRequestDiff{
+				{OldPath: "a.go", NewPath: "a.go", Diff: "@@ -1 +1 @@\n-old\n+new\n"},
+				{OldPath: "b.go", NewPath: "b.go", NewFile: true, Diff: "@@ -0,0 +1 @@\n+" + strings.Repeat("x", DefaultMaxDiffBytes) + "\n"},
+			}, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+
+		contents, errMsg := readResource(t, s, "gitlab://group/project/-/merge_requests/3")
+		require.Empty(t, errMsg)
+		require.Len(t, contents, 1)
+		text, ok := contents[0].(mcp.TextResourceContents)
+		require.True(t, ok, "Expected text contents, got %T", contents[0])
+		assert.Equal(t, "application/json", text.MIMEType)
+
+		var result mergeRequestResource
+		require.NoError(t, json.Unmarshal([]byte(text.Text), &result))
+		require.NotNil(t, result.MergeRequest)
+		assert.Equal(t, 3, result.MergeRequest.IID)
+		assert.Equal(t, 2, result.Summary.FilesChanged)
+		require.Len(t, result.Diffs, 2)
+		assert.Equal(t, "@@ -1 +1 @@\n-old\n+new\n", result.Diffs[0].Diff)
+		assert.True(t, result.Diffs[1].Truncated, "Diffs are capped like those of compareRefs")
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockMRs.EXPECT().
+			GetMergeRequest("group/project", 999, nil, gomock.Any()).
+			Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, gl.ErrNotFound)
+
+		_, errMsg := readResource(t, s, "gitlab://group/project/-/merge_requests/999")
+		assert.Contains(t, errMsg, `merge request 999 not found in project "group/project", or access denied`)
+	})
+}
+
+func TestPipelineResource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPipelines := mock_gitlab.NewMockPipelinesServiceInterface(ctrl)
+	client := &gl.Client{Pipelines: mockPipelines}
+	s := NewServer("test", "0.0.0")
+	s.AddResourceTemplate(PipelineResource(func(_ context.Context) (*gl.Client, error) { return client, nil }))
+
+	pipeline := &gl.Pipeline{ID: 1001, Status: "failed", Ref: "main"}
+	mockPipelines.EXPECT().
+		GetPipeline("group/project", 1001, gomock.Any()).
+		Return(pipeline, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+
+	contents, errMsg := readResource(t, s, "gitlab://group/project/-/pipelines/1001")
+	require.Empty(t, errMsg)
+	require.Len(t, contents, 1)
+	text, ok := contents[0].(mcp.TextResourceContents)
+	require.True(t, ok, "Expected text contents, got %T", contents[0])
+	expected, err := json.Marshal(pipeline)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), text.Text)
+
+	_, errMsg = readResource(t, s, "gitlab://group/project/-/pipelines/0")
+	assert.Contains(t, errMsg, "id must be a positive integer")
+}
@@ -0,0 +1,276 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	gl "gitlab.com/gitlab-org/api/client-go" // GitLab client library
+)
+
+// searchScopeSchemas maps the scopes of the search tools to the presentation of their results.
+var searchScopeSchemas = map[string]resourceSchema{
+	"projects":       projectSchema,
+	"issues":         issueSchema,
+	"merge_requests": mergeRequestSchema,
+	"milestones":     milestoneSchema,
+	"blobs":          searchBlobSchema,
+	"commits":        commitSchema,
+	"wiki_blobs":     searchBlobSchema,
+	"notes":          searchNoteSchema,
+	"users":          userSchema,
+}
+
+// Scopes of the search tools. Projects cannot be searched within a project.
+var (
+	searchScopes        = []string{"projects", "issues", "merge_requests", "milestones", "blobs", "commits", "wiki_blobs", "notes", "users"}
+	projectSearchScopes = slices.DeleteFunc(slices.Clone(searchScopes), func(scope string) bool { return scope == "projects" })
+)
+
+// searchBlob is a code or wiki search result: the matching lines of a file and where they start.
+type searchBlob struct {
+	ProjectID int    `json:"project_id"`
+	Path      string `json:"path"`
+	Ref       string `json:"ref"`
+	Startline int    `json:"startline"`
+	Snippet   string `json:"snippet"`
+}
+
+// searchOptions are the query parameters of GitLab's search endpoints.
+type searchOptions struct {
+	gl.ListOptions
+	Scope  string  `url:"scope"`
+	Search string  `url:"search"`
+	Ref    *string `url:"ref,omitempty"`
+}
+
+// SearchGlobal defines the MCP tool for searching the whole GitLab instance.
+func SearchGlobal(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"searchGlobal",
+			mcp.WithDescription("Searches all projects visible to the current user. Code ('blobs'), 'commits', 'wiki_blobs' and 'notes' across projects require advanced search on the instance; "+
+				"otherwise use searchGroup or searchProject."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Search GitLab",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			withSearchParams(searchScopes),
+			WithPagination(),
+			withSearchOutput(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return handleSearch(ctx, getClient, &request, "search", "", searchScopes)
+		}
+}
+
+// SearchGroup defines the MCP tool for searching the projects of a group and its subgroups.
+func SearchGroup(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"searchGroup",
+			mcp.WithDescription("Searches the projects of a group and its subgroups, e.g., to find the repositories using a library without cloning them. "+
+				"Code ('blobs'), 'commits', 'wiki_blobs' and 'notes' require advanced search on the instance."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Search Group",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("groupId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the group."),
+			),
+			withSearchParams(searchScopes),
+			WithPagination(),
+			withSearchOutput(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			groupID, err := requiredParam[string](&request, "groupId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			return handleSearch(ctx, getClient, &request, fmt.Sprintf("groups/%s/search", gl.PathEscape(groupID)), fmt.Sprintf("group %q", groupID), searchScopes)
+		}
+}
+
+// SearchProject defines the MCP tool for searching a single project.
+func SearchProject(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"searchProject",
+			mcp.WithDescription("Searches a project: its code ('blobs') at a ref, commits, issues, merge requests, milestones, wiki, comments ('notes') or members."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Search Project",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			withSearchParams(projectSearchScopes),
+			mcp.WithString("ref",
+				mcp.Description("The branch, tag or commit SHA to search 'blobs' and 'commits' at. Default: the default branch."),
+			),
+			WithPagination(),
+			withSearchOutput(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			projectID, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			return handleSearch(ctx, getClient, &request, fmt.Sprintf("projects/%s/search", gl.PathEscape(projectID)), fmt.Sprintf("project %q", projectID), projectSearchScopes)
+		}
+}
+
+// withSearchParams adds the 'scope' and 'search' parameters of the search tools.
+func withSearchParams(scopes []string) mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		mcp.WithString("scope",
+			mcp.Required(),
+			mcp.Description("What to search. 'blobs' results carry the file path, ref, start line and a snippet of the matching lines."),
+			mcp.Enum(scopes...),
+		)(t)
+		mcp.WithString("search",
+			mcp.Required(),
+			mcp.Description("The search query. For 'blobs', filters such as 'filename:*.gradle', 'extension:go' or 'path:src/' can follow the terms."),
+		)(t)
+	}
+}
+
+// withSearchOutput is the WithListOutput of the search tools, whose items depend on the scope:
+// their output schema only describes the list envelope.
+func withSearchOutput() mcp.ToolOption {
+	return func(t *mcp.Tool) {
+		mcp.WithString("fields",
+			mcp.Description("Comma-separated dotted paths of the fields to return (e.g., 'iid,title,author.username'); "+
+				"paths through arrays apply to every element. Use 'all' for the complete GitLab object. Default: the fields returned by the list tool of the scope."),
+		)(t)
+		WithOutputFormat()(t)
+		WithContinuation()(t)
+		mcp.WithRawOutputSchema(mustMarshalSchema(map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"items":      map[string]any{"type": "array", "items": map[string]any{"type": "object"}},
+				"pagination": objectSchema(Pagination{}, nil),
+			},
+			"required": []string{"items", "pagination"},
+		}))(t)
+	}
+}
+
+// handleSearch runs the search described by the request at path, the search endpoint of the
+// instance, a group or a project. target names the searched group or project in errors.
+func handleSearch(ctx context.Context, getClient GetClientFn, request *mcp.CallToolRequest, path, target string, scopes []string) (*mcp.CallToolResult, error) {
+	// --- Parse parameters
+	scope, err := requiredParam[string](request, "scope")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+	}
+	if !slices.Contains(scopes, scope) {
+		return mcp.NewToolResultError(fmt.Sprintf("Validation Error: 'scope' must be one of %s, got %q", strings.Join(scopes, ", "), scope)), nil
+	}
+	search, err := requiredParam[string](request, "search")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+	}
+	ref, err := OptionalParam[string](request, "ref")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+	}
+	listParams, err := OptionalListParams(request)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+	}
+	output, err := optionalOutputParams(ctx, request, searchScopeSchemas[scope])
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+	}
+
+	// --- Construct GitLab API options
+	opts := &searchOptions{
+		ListOptions: gl.ListOptions{
+			PerPage: listParams.PerPage,
+		},
+		Scope:  scope,
+		Search: search,
+	}
+	if ref != "" {
+		opts.Ref = &ref
+	}
+
+	// --- Obtain GitLab client
+	glClient, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+	}
+
+	// --- Call GitLab API
+	results, pagination, resp, err := fetchSearchResults(ctx, glClient, path, opts, listParams)
+
+	// --- Handle API errors
+	if err != nil {
+		action := fmt.Sprintf("failed to search %s", scope)
+		notFound := "search endpoint not found"
+		if target != "" {
+			action += " in " + target
+			notFound = target + " not found or access denied"
+		}
+		return apiErrorResult(err, resp, action, notFound), nil
+	}
+
+	// --- Marshal and return success
+	return newListResult(results, pagination, output, "search results")
+}
+
+// fetchSearchResults returns the results of the search, decoded into the model of its scope.
+func fetchSearchResults(ctx context.Context, glClient *gl.Client, path string, opts *searchOptions, listParams ListParams) ([]any, *Pagination, *gl.Response, error) {
+	switch opts.Scope {
+	case "projects":
+		return searchPages[*gl.Project](ctx, glClient, path, opts, listParams, nil)
+	case "issues":
+		return searchPages[*gl.Issue](ctx, glClient, path, opts, listParams, nil)
+	case "merge_requests":
+		return searchPages[*gl.BasicMergeRequest](ctx, glClient, path, opts, listParams, nil)
+	case "milestones":
+		return searchPages[*gl.Milestone](ctx, glClient, path, opts, listParams, nil)
+	case "commits":
+		return searchPages[*gl.Commit](ctx, glClient, path, opts, listParams, nil)
+	case "notes":
+		return searchPages[*gl.Note](ctx, glClient, path, opts, listParams, nil)
+	case "users":
+		return searchPages[*gl.User](ctx, glClient, path, opts, listParams, nil)
+	default: // blobs and wiki_blobs
+		return searchPages(ctx, glClient, path, opts, listParams, func(b *gl.Blob) any {
+			return &searchBlob{ProjectID: b.ProjectID, Path: b.Path, Ref: b.Ref, Startline: b.Startline, Snippet: b.Data}
+		})
+	}
+}
+
+// searchPages collects the pages of search results decoded as T, converted by convert when
+// not nil. The requests are built here rather than with client-go's SearchService, which
+// decodes wiki results as wiki pages and cannot search notes outside a project.
+func searchPages[T any](ctx context.Context, glClient *gl.Client, path string, opts *searchOptions, listParams ListParams, convert func(T) any) ([]any, *Pagination, *gl.Response, error) {
+	items, pagination, resp, err := collectPages(listParams, func(page int) ([]T, *gl.Response, error) {
+		opts.Page = page
+		req, err := glClient.NewRequest(http.MethodGet, path, opts, []gl.RequestOptionFunc{gl.WithContext(ctx)})
+		if err != nil {
+			return nil, nil, err
+		}
+		var results []T
+		resp, err := glClient.Do(req, &results)
+		return results, resp, err
+	})
+	if err != nil {
+		return nil, nil, resp, err
+	}
+	results := make([]any, len(items))
+	for i, item := range items {
+		if convert != nil {
+			results[i] = convert(item)
+		} else {
+			results[i] = item
+		}
+	}
+	return results, pagination, resp, nil
+}
@@ -0,0 +1,144 @@
+package gitlab
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+// newSearchServer returns a fake GitLab API answering the search requests at path with body,
+// recording their query parameters in query.
+func newSearchServer(t *testing.T, path, body string, query *url.Values) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != path {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"message": "404 Group Not Found"}`)
+			return
+		}
+		*query = r.URL.Query()
+		if r.URL.Query().Get("search") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error": "search is missing"}`)
+			return
+		}
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func searchClient(t *testing.T, srv *httptest.Server) GetClientFn {
+	t.Helper()
+	client, err := gl.NewClient("token", gl.WithBaseURL(srv.URL), gl.WithoutRetries())
+	require.NoError(t, err)
+	return func(_ context.Context) (*gl.Client, error) { return client, nil }
+}
+
+func TestSearchGlobalHandler(t *testing.T) {
+	ctx := context.Background()
+	var query url.Values
+	srv := newSearchServer(t, "/api/v4/search", `[{"id": 11, "name": "api", "path_with_namespace": "platform/api", "default_branch": "main"}]`, &query)
+
+	searchGlobalTool, searchGlobalHandler := SearchGlobal(searchClient(t, srv))
+
+	result, err := searchGlobalHandler(ctx, createCallToolRequest(searchGlobalTool.Name, map[string]any{"scope": "projects", "search": "api"}))
+	require.NoError(t, err)
+	items, _ := getListResult(t, result)
+	projects := []*gl.Project{{ID: 11, Name: "api", PathWithNamespace: "platform/api", DefaultBranch: "main"}}
+	assert.JSONEq(t, projectedJSON(t, projects, projectSchema.fields), items, "Results are presented like the list tool of their scope")
+	assert.Equal(t, "projects", query.Get("scope"))
+	assert.Equal(t, "api", query.Get("search"))
+
+	result, err = searchGlobalHandler(ctx, createCallToolRequest(searchGlobalTool.Name, map[string]any{"scope": "snippets", "search": "api"}))
+	require.NoError(t, err)
+	assert.Contains(t, getTextResult(t, result).Text, "Validation Error: 'scope' must be one of")
+}
+
+func TestSearchGroupHandler(t *testing.T) {
+	ctx := context.Background()
+	var query url.Values
+	srv := newSearchServer(t, "/api/v4/groups/platform%2Fbackend/search", `[
+		{"basename": "build", "data": "implementation 'org.apache.logging.log4j:log4j-core:2.14.1'\n", "path": "build.gradle",
+		 "filename": "build.gradle", "ref": "main", "startline": 12, "project_id": 11}
+	]`, &query)
+
+	searchGroupTool, searchGroupHandler := SearchGroup(searchClient(t, srv))
+
+	tests := []struct {
+		name              string
+		inputArgs         map[string]any
+		expectedItems     string
+		expectResultError bool
+		errorContains     string
+	}{
+		{
+			name:      "Success - Blobs",
+			inputArgs: map[string]any{"groupId": "platform/backend", "scope": "blobs", "search": "log4j-core filename:*.gradle"},
+			expectedItems: `[{"project_id": 11, "path": "build.gradle", "ref": "main", "startline": 12,
+				"snippet": "implementation 'org.apache.logging.log4j:log4j-core:2.14.1'\n"}]`,
+		},
+		{
+			name:              "Error - Group Not Found (404)",
+			inputArgs:         map[string]any{"groupId": "platform/frontend", "scope": "blobs", "search": "log4j"},
+			expectResultError: true,
+			errorContains:     `group "platform/frontend" not found or access denied (404)`,
+		},
+		{
+			name:              "Error - Missing Search",
+			inputArgs:         map[string]any{"groupId": "platform/backend", "scope": "blobs"},
+			expectResultError: true,
+			errorContains:     "Validation Error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := searchGroupHandler(ctx, createCallToolRequest(searchGroupTool.Name, tc.inputArgs))
+			require.NoError(t, err)
+			if tc.expectResultError {
+				assert.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.errorContains)
+				return
+			}
+			items, _ := getListResult(t, result)
+			assert.JSONEq(t, tc.expectedItems, items)
+			assert.Equal(t, "blobs", query.Get("scope"))
+		})
+	}
+}
+
+func TestSearchProjectHandler(t *testing.T) {
+	ctx := context.Background()
+	var query url.Values
+	srv := newSearchServer(t, "/api/v4/projects/platform%2Fapi/search", `[
+		{"id": 1, "body": "Bumped log4j", "author": {"username": "alice"}, "project_id": 11, "noteable_type": "MergeRequest", "noteable_iid": 4}
+	]`, &query)
+
+	searchProjectTool, searchProjectHandler := SearchProject(searchClient(t, srv))
+
+	t.Run("Success - Notes At Ref", func(t *testing.T) {
+		result, err := searchProjectHandler(ctx, createCallToolRequest(searchProjectTool.Name, map[string]any{
+			"projectId": "platform/api", "scope": "notes", "search": "log4j", "ref": "release-1.0", "fields": "id,body,author.username",
+		}))
+		require.NoError(t, err)
+		items, _ := getListResult(t, result)
+		assert.JSONEq(t, `[{"id": 1, "body": "Bumped log4j", "author": {"username": "alice"}}]`, items)
+		assert.Equal(t, "notes", query.Get("scope"))
+		assert.Equal(t, "release-1.0", query.Get("ref"))
+	})
+
+	t.Run("Error - Projects Scope", func(t *testing.T) {
+		result, err := searchProjectHandler(ctx, createCallToolRequest(searchProjectTool.Name, map[string]any{"projectId": "platform/api", "scope": "projects", "search": "api"}))
+		require.NoError(t, err)
+		assert.Contains(t, getTextResult(t, result).Text, "Validation Error: 'scope' must be one of")
+	})
+}
@@ -0,0 +1,645 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	gl "gitlab.com/gitlab-org/api/client-go" // GitLab client library
+)
+
+// The security tools use the GraphQL API: the REST vulnerability endpoints are deprecated, cannot
+// filter vulnerabilities nor record comments on state changes, and report no scan summaries.
+
+// Values accepted by the vulnerability filters, in the lower case of the REST API.
+var (
+	vulnerabilitySeverities  = []string{"critical", "high", "medium", "low", "info", "unknown"}
+	vulnerabilityStates      = []string{"detected", "confirmed", "resolved", "dismissed"}
+	vulnerabilityReportTypes = []string{
+		"sast", "dast", "dependency_scanning", "container_scanning", "secret_detection",
+		"coverage_fuzzing", "api_fuzzing", "cluster_image_scanning", "generic",
+	}
+	vulnerabilityDismissalReasons = []string{"acceptable_risk", "false_positive", "mitigating_control", "used_in_tests", "not_applicable"}
+)
+
+// vulnerability is a vulnerability of the GraphQL API, its fields aliased to the names of the REST API.
+type vulnerability struct {
+	ID                globalID                   `json:"id"`
+	Title             string                     `json:"title"`
+	Description       string                     `json:"description"`
+	State             string                     `json:"state"`
+	Severity          string                     `json:"severity"`
+	ReportType        string                     `json:"report_type"`
+	Scanner           *vulnerabilityScanner      `json:"scanner"`
+	PrimaryIdentifier *vulnerabilityIdentifier   `json:"primary_identifier"`
+	Identifiers       []*vulnerabilityIdentifier `json:"identifiers"`
+	Location          *vulnerabilityLocation     `json:"location"`
+	Solution          string                     `json:"solution"`
+	DismissalReason   string                     `json:"dismissal_reason"`
+	DetectedAt        *time.Time                 `json:"detected_at"`
+	ConfirmedAt       *time.Time                 `json:"confirmed_at"`
+	ResolvedAt        *time.Time                 `json:"resolved_at"`
+	DismissedAt       *time.Time                 `json:"dismissed_at"`
+	UpdatedAt         *time.Time                 `json:"updated_at"`
+	Project           *struct {
+		FullPath string `json:"full_path"`
+	} `json:"project"`
+	WebURL string `json:"web_url"`
+}
+
+// vulnerabilityScanner is the analyzer that reported a vulnerability.
+type vulnerabilityScanner struct {
+	Name       string `json:"name"`
+	ExternalID string `json:"external_id"`
+	Vendor     string `json:"vendor"`
+}
+
+// vulnerabilityIdentifier is a reference to a vulnerability in an external catalog, such as a CVE or CWE.
+type vulnerabilityIdentifier struct {
+	Name         string `json:"name"`
+	ExternalType string `json:"external_type"`
+	ExternalID   string `json:"external_id"`
+	URL          string `json:"url"`
+}
+
+// vulnerabilityLocation is where a vulnerability was found. Its fields depend on the report type:
+// a file and lines for SAST and secret detection, a dependency for dependency and container
+// scanning, a URL path for DAST.
+type vulnerabilityLocation struct {
+	File             string                   `json:"file,omitempty"`
+	StartLine        string                   `json:"start_line,omitempty"`
+	EndLine          string                   `json:"end_line,omitempty"`
+	BlobPath         string                   `json:"blob_path,omitempty"`
+	VulnerableClass  string                   `json:"vulnerable_class,omitempty"`
+	VulnerableMethod string                   `json:"vulnerable_method,omitempty"`
+	Dependency       *vulnerabilityDependency `json:"dependency,omitempty"`
+	Image            string                   `json:"image,omitempty"`
+	OperatingSystem  string                   `json:"operating_system,omitempty"`
+	Hostname         string                   `json:"hostname,omitempty"`
+	Path             string                   `json:"path,omitempty"`
+	RequestMethod    string                   `json:"request_method,omitempty"`
+	Param            string                   `json:"param,omitempty"`
+}
+
+// vulnerabilityDependency is the vulnerable package version of a dependency or container scanning finding.
+type vulnerabilityDependency struct {
+	Package struct {
+		Name string `json:"name"`
+	} `json:"package"`
+	Version string `json:"version"`
+}
+
+// securityReportSummary counts the results of each security scan type of a pipeline, nil for
+// the scan types the pipeline did not run.
+type securityReportSummary struct {
+	SAST                 *securityReportSection `json:"sast"`
+	DAST                 *securityReportSection `json:"dast"`
+	DependencyScanning   *securityReportSection `json:"dependency_scanning"`
+	ContainerScanning    *securityReportSection `json:"container_scanning"`
+	SecretDetection      *securityReportSection `json:"secret_detection"`
+	CoverageFuzzing      *securityReportSection `json:"coverage_fuzzing"`
+	APIFuzzing           *securityReportSection `json:"api_fuzzing"`
+	ClusterImageScanning *securityReportSection `json:"cluster_image_scanning"`
+}
+
+// securityReportSection summarizes the scans of one type run by a pipeline.
+type securityReportSection struct {
+	VulnerabilitiesCount  int                             `json:"vulnerabilities_count"`
+	ScannedResourcesCount int                             `json:"scanned_resources_count"`
+	Scanners              nodeList[*vulnerabilityScanner] `json:"scanners"`
+	Scans                 nodeList[*securityScan]         `json:"scans"`
+}
+
+// securityScan is a security job of a pipeline, with the errors and warnings its report carried.
+type securityScan struct {
+	Name     string   `json:"name"`
+	Status   string   `json:"status"`
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings"`
+}
+
+// nodeList is a GraphQL connection decoded as the list of its nodes.
+type nodeList[T any] []T
+
+func (l *nodeList[T]) UnmarshalJSON(data []byte) error {
+	var conn graphQLConnection[T]
+	if err := json.Unmarshal(data, &conn); err != nil {
+		return err
+	}
+	*l = conn.Nodes
+	return nil
+}
+
+// vulnerabilityFragment selects the fields of vulnerability.
+const vulnerabilityFragment = `
+fragment VulnerabilityFields on Vulnerability {
+  id title description state severity
+  report_type: reportType
+  scanner { name external_id: externalId vendor }
+  primary_identifier: primaryIdentifier { name external_type: externalType external_id: externalId url }
+  identifiers { name external_type: externalType external_id: externalId url }
+  location {
+    ... on VulnerabilityLocationSast {
+      file start_line: startLine end_line: endLine blob_path: blobPath
+      vulnerable_class: vulnerableClass vulnerable_method: vulnerableMethod
+    }
+    ... on VulnerabilityLocationSecretDetection { file start_line: startLine end_line: endLine blob_path: blobPath }
+    ... on VulnerabilityLocationDependencyScanning { file blob_path: blobPath dependency { package { name } version } }
+    ... on VulnerabilityLocationContainerScanning { image operating_system: operatingSystem dependency { package { name } version } }
+    ... on VulnerabilityLocationDast { hostname path request_method: requestMethod param }
+  }
+  solution
+  dismissal_reason: dismissalReason
+  detected_at: detectedAt confirmed_at: confirmedAt resolved_at: resolvedAt dismissed_at: dismissedAt updated_at: updatedAt
+  project { full_path: fullPath }
+  web_url: webUrl
+}`
+
+const listVulnerabilitiesQuery = `
+query($fullPath: ID!, $first: Int, $after: String, $severity: [VulnerabilitySeverity!], $state: [VulnerabilityState!],
+      $scanner: [String!], $reportType: [VulnerabilityReportType!], $sort: VulnerabilitySort) {
+  project(fullPath: $fullPath) {
+    vulnerabilities(first: $first, after: $after, severity: $severity, state: $state, scanner: $scanner, reportType: $reportType, sort: $sort) {
+      nodes { ...VulnerabilityFields }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}` + vulnerabilityFragment
+
+const getVulnerabilityQuery = `
+query($id: VulnerabilityID!) {
+  vulnerability(id: $id) { ...VulnerabilityFields }
+}` + vulnerabilityFragment
+
+const pipelineSecuritySummaryQuery = `
+query($fullPath: ID!, $id: CiPipelineID!) {
+  project(fullPath: $fullPath) {
+    pipeline(id: $id) {
+      securityReportSummary {
+        sast { ...SectionFields }
+        dast { ...SectionFields }
+        dependency_scanning: dependencyScanning { ...SectionFields }
+        container_scanning: containerScanning { ...SectionFields }
+        secret_detection: secretDetection { ...SectionFields }
+        coverage_fuzzing: coverageFuzzing { ...SectionFields }
+        api_fuzzing: apiFuzzing { ...SectionFields }
+        cluster_image_scanning: clusterImageScanning { ...SectionFields }
+      }
+    }
+  }
+}
+
+fragment SectionFields on SecurityReportSummarySection {
+  vulnerabilities_count: vulnerabilitiesCount
+  scanned_resources_count: scannedResourcesCount
+  scanners { nodes { name external_id: externalId vendor } }
+  scans { nodes { name status errors warnings } }
+}`
+
+// ListVulnerabilities defines the MCP tool for listing the vulnerabilities of a project.
+func ListVulnerabilities(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"listVulnerabilities",
+			mcp.WithDescription("Lists the vulnerabilities detected by security scans (SAST, DAST, dependency scanning, etc.) on a project's default branch, most severe first. Requires GitLab Ultimate."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "List Vulnerabilities",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("severity",
+				mcp.Description(fmt.Sprintf("Comma-separated severities to include (%s).", strings.Join(vulnerabilitySeverities, ", "))),
+			),
+			mcp.WithString("state",
+				mcp.Description(fmt.Sprintf("Comma-separated states to include (%s).", strings.Join(vulnerabilityStates, ", "))),
+			),
+			mcp.WithString("scanner",
+				mcp.Description("Comma-separated external IDs of the scanners to include (e.g., 'semgrep,gemnasium')."),
+			),
+			mcp.WithString("reportType",
+				mcp.Description(fmt.Sprintf("Comma-separated report types to include (%s).", strings.Join(vulnerabilityReportTypes, ", "))),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort order. Default: severity_desc."),
+				mcp.Enum("severity_desc", "severity_asc", "detected_desc", "detected_asc"),
+			),
+			WithCursorPagination(),
+			WithListOutput(vulnerabilitySchema),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectID, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			severities, err := optionalEnumListParam(&request, "severity", vulnerabilitySeverities)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			states, err := optionalEnumListParam(&request, "state", vulnerabilityStates)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			scanners, err := OptionalParam[string](&request, "scanner")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			reportTypes, err := optionalEnumListParam(&request, "reportType", vulnerabilityReportTypes)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			sort, err := OptionalParam[string](&request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, vulnerabilitySchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			variables := map[string]any{}
+			if severities != nil {
+				variables["severity"] = severities
+			}
+			if states != nil {
+				variables["state"] = states
+			}
+			if scanners != "" {
+				variables["scanner"] = splitCommaList(scanners)
+			}
+			if reportTypes != nil {
+				variables["reportType"] = reportTypes
+			}
+			if sort != "" {
+				variables["sort"] = sort
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			fullPath, resp, err := projectFullPath(ctx, glClient, projectID)
+			var vulnerabilities []*vulnerability
+			var pagination *Pagination
+			projectFound := true
+			if err == nil {
+				variables["fullPath"] = fullPath
+				vulnerabilities, pagination, resp, err = collectConnection(listParams, func(after string, first int) (*graphQLConnection[*vulnerability], *gl.Response, error) {
+					variables["first"] = first
+					if after != "" {
+						variables["after"] = after
+					}
+					var data struct {
+						Project *struct {
+							Vulnerabilities *graphQLConnection[*vulnerability] `json:"vulnerabilities"`
+						} `json:"project"`
+					}
+					resp, err := doGraphQL(ctx, glClient, listVulnerabilitiesQuery, variables, &data)
+					if err != nil {
+						return nil, resp, err
+					}
+					if data.Project == nil {
+						projectFound = false
+						return nil, resp, nil
+					}
+					return data.Project.Vulnerabilities, resp, nil
+				})
+			}
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list vulnerabilities of project %q", projectID),
+					fmt.Sprintf("project %q not found or access denied", projectID),
+				), nil
+			}
+			if !projectFound {
+				return mcp.NewToolResultError(fmt.Sprintf("project %q not found or access denied", projectID)), nil
+			}
+
+			// --- Marshal and return success
+			return newListResult(vulnerabilities, pagination, output, "vulnerability list data")
+		}
+}
+
+// GetVulnerability defines the MCP tool for retrieving a vulnerability.
+func GetVulnerability(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getVulnerability",
+			mcp.WithDescription("Retrieves a vulnerability with its description, location (file and lines, dependency, image or URL), identifiers (CVE, CWE, etc.) and suggested solution."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get Vulnerability",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			withVulnerabilityIDParam(),
+			WithOutput(vulnerabilityDetailSchema),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			vulnerabilityID, err := requiredVulnerabilityIDParam(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, vulnerabilityDetailSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			var data struct {
+				Vulnerability *vulnerability `json:"vulnerability"`
+			}
+			resp, err := doGraphQL(ctx, glClient, getVulnerabilityQuery, map[string]any{"id": toGlobalID("Vulnerability", vulnerabilityID)}, &data)
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get vulnerability %d", vulnerabilityID),
+					fmt.Sprintf("vulnerability %d not found or access denied", vulnerabilityID),
+				), nil
+			}
+			if data.Vulnerability == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("vulnerability %d not found or access denied", vulnerabilityID)), nil
+			}
+
+			// --- Marshal and return success
+			return newProjectedResult(data.Vulnerability, output, "vulnerability data")
+		}
+}
+
+// GetPipelineSecuritySummary defines the MCP tool for summarizing the security reports of a pipeline.
+func GetPipelineSecuritySummary(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getPipelineSecuritySummary",
+			mcp.WithDescription("Summarizes the security reports of a pipeline: for each scan type it ran (SAST, DAST, dependency scanning, etc.), the number of vulnerabilities found and "+
+				"resources scanned, the scanners used and the errors or warnings of each scan job. Scan types the pipeline did not run are null."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get Pipeline Security Summary",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithNumber("pipelineId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) of the pipeline."),
+			),
+			WithOutput(securityReportSummarySchema),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectID, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			pipelineIDFloat, err := requiredParam[float64](&request, "pipelineId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			pipelineID := int(pipelineIDFloat)
+			if float64(pipelineID) != pipelineIDFloat {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: pipelineId %v is not a valid integer", pipelineIDFloat)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, securityReportSummarySchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			var data struct {
+				Project *struct {
+					Pipeline *struct {
+						SecurityReportSummary *securityReportSummary `json:"securityReportSummary"`
+					} `json:"pipeline"`
+				} `json:"project"`
+			}
+			fullPath, resp, err := projectFullPath(ctx, glClient, projectID)
+			if err == nil {
+				resp, err = doGraphQL(ctx, glClient, pipelineSecuritySummaryQuery, map[string]any{
+					"fullPath": fullPath,
+					"id":       toGlobalID("Ci::Pipeline", pipelineID),
+				}, &data)
+			}
+
+			// --- Handle API errors
+			notFound := fmt.Sprintf("pipeline %d not found in project %q or access denied", pipelineID, projectID)
+			if err != nil {
+				return apiErrorResult(err, resp, fmt.Sprintf("failed to get security summary of pipeline %d in project %q", pipelineID, projectID), notFound), nil
+			}
+			if data.Project == nil || data.Project.Pipeline == nil {
+				return mcp.NewToolResultError(notFound), nil
+			}
+
+			// --- Marshal and return success
+			summary := data.Project.Pipeline.SecurityReportSummary
+			if summary == nil {
+				summary = &securityReportSummary{} // No security reports
+			}
+			return newProjectedResult(summary, output, "security report summary data")
+		}
+}
+
+// DismissVulnerability defines the MCP tool for dismissing a vulnerability.
+func DismissVulnerability(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"dismissVulnerability",
+			mcp.WithDescription("Dismisses a vulnerability that does not need to be fixed, e.g., a false positive or an accepted risk. The comment is recorded in the vulnerability's history."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Dismiss Vulnerability",
+				ReadOnlyHint:    mcp.ToBoolPtr(false),
+				DestructiveHint: mcp.ToBoolPtr(false),
+				IdempotentHint:  mcp.ToBoolPtr(true),
+			}),
+			WithResultSchema(vulnerability{}),
+			withVulnerabilityIDParam(),
+			withVulnerabilityCommentParam(),
+			mcp.WithString("dismissalReason",
+				mcp.Description("Why the vulnerability is dismissed."),
+				mcp.Enum(vulnerabilityDismissalReasons...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			reason, err := OptionalParam[string](&request, "dismissalReason")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			input := map[string]any{}
+			if reason != "" {
+				if !slices.Contains(vulnerabilityDismissalReasons, reason) {
+					return mcp.NewToolResultError(fmt.Sprintf("Validation Error: 'dismissalReason' must be one of %s, got %q", strings.Join(vulnerabilityDismissalReasons, ", "), reason)), nil
+				}
+				input["dismissalReason"] = strings.ToUpper(reason)
+			}
+			return handleVulnerabilityStateChange(ctx, getClient, &request, "vulnerabilityDismiss", "dismiss", input)
+		}
+}
+
+// ConfirmVulnerability defines the MCP tool for confirming a vulnerability.
+func ConfirmVulnerability(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"confirmVulnerability",
+			mcp.WithDescription("Confirms a detected vulnerability as a real issue to fix. The comment is recorded in the vulnerability's history."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Confirm Vulnerability",
+				ReadOnlyHint:    mcp.ToBoolPtr(false),
+				DestructiveHint: mcp.ToBoolPtr(false),
+				IdempotentHint:  mcp.ToBoolPtr(true),
+			}),
+			WithResultSchema(vulnerability{}),
+			withVulnerabilityIDParam(),
+			withVulnerabilityCommentParam(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return handleVulnerabilityStateChange(ctx, getClient, &request, "vulnerabilityConfirm", "confirm", map[string]any{})
+		}
+}
+
+// ResolveVulnerability defines the MCP tool for resolving a vulnerability.
+func ResolveVulnerability(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"resolveVulnerability",
+			mcp.WithDescription("Marks a vulnerability as resolved once it is fixed. It is detected again if a later scan still finds it. The comment is recorded in the vulnerability's history."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Resolve Vulnerability",
+				ReadOnlyHint:    mcp.ToBoolPtr(false),
+				DestructiveHint: mcp.ToBoolPtr(false),
+				IdempotentHint:  mcp.ToBoolPtr(true),
+			}),
+			WithResultSchema(vulnerability{}),
+			withVulnerabilityIDParam(),
+			withVulnerabilityCommentParam(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return handleVulnerabilityStateChange(ctx, getClient, &request, "vulnerabilityResolve", "resolve", map[string]any{})
+		}
+}
+
+// handleVulnerabilityStateChange runs the GraphQL mutation changing the state of the vulnerability
+// of the request, with input completed by the vulnerability ID and comment. verb names the change in errors.
+func handleVulnerabilityStateChange(ctx context.Context, getClient GetClientFn, request *mcp.CallToolRequest, mutation, verb string, input map[string]any) (*mcp.CallToolResult, error) {
+	// --- Parse parameters
+	vulnerabilityID, err := requiredVulnerabilityIDParam(request)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+	}
+	comment, err := OptionalParam[string](request, "comment")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+	}
+
+	// --- Construct GitLab API options
+	input["id"] = toGlobalID("Vulnerability", vulnerabilityID)
+	if comment != "" {
+		input["comment"] = comment
+	}
+	query := fmt.Sprintf(`
+mutation($input: %sInput!) {
+  %s(input: $input) {
+    vulnerability { ...VulnerabilityFields }
+    errors
+  }
+}`, strings.ToUpper(mutation[:1])+mutation[1:], mutation) + vulnerabilityFragment
+
+	// --- Obtain GitLab client
+	glClient, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+	}
+
+	// --- Call GitLab API
+	var data map[string]*struct {
+		Vulnerability *vulnerability `json:"vulnerability"`
+		Errors        graphQLErrors  `json:"errors"`
+	}
+	resp, err := doGraphQL(ctx, glClient, query, map[string]any{"input": input}, &data)
+	if err == nil && data[mutation] != nil && len(data[mutation].Errors) > 0 {
+		err = data[mutation].Errors
+	}
+
+	// --- Handle API errors
+	if err != nil {
+		return apiErrorResult(err, resp,
+			fmt.Sprintf("failed to %s vulnerability %d", verb, vulnerabilityID),
+			fmt.Sprintf("vulnerability %d not found or access denied", vulnerabilityID),
+		), nil
+	}
+	if data[mutation] == nil || data[mutation].Vulnerability == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("vulnerability %d not found or access denied", vulnerabilityID)), nil
+	}
+
+	// --- Marshal and return success
+	return newJSONResult(data[mutation].Vulnerability, "vulnerability data")
+}
+
+// withVulnerabilityIDParam adds the 'vulnerabilityId' parameter of the tools handling a single vulnerability.
+func withVulnerabilityIDParam() mcp.ToolOption {
+	return mcp.WithNumber("vulnerabilityId",
+		mcp.Required(),
+		mcp.Description("The ID (integer) of the vulnerability, as returned by listVulnerabilities."),
+	)
+}
+
+// withVulnerabilityCommentParam adds the 'comment' parameter of the tools changing the state of a vulnerability.
+func withVulnerabilityCommentParam() mcp.ToolOption {
+	return mcp.WithString("comment",
+		mcp.Description("Why the state is changed, recorded in the vulnerability's history."),
+	)
+}
+
+// requiredVulnerabilityIDParam parses the 'vulnerabilityId' parameter.
+func requiredVulnerabilityIDParam(r *mcp.CallToolRequest) (int, error) {
+	idFloat, err := requiredParam[float64](r, "vulnerabilityId")
+	if err != nil {
+		return 0, err
+	}
+	id := int(idFloat)
+	if float64(id) != idFloat {
+		return 0, fmt.Errorf("vulnerabilityId %v is not a valid integer", idFloat)
+	}
+	return id, nil
+}
+
+// optionalEnumListParam parses an optional comma-separated list of values among allowed, returned
+// in upper case as GraphQL enum values. It returns nil if the parameter is missing or empty.
+func optionalEnumListParam(r *mcp.CallToolRequest, p string, allowed []string) ([]string, error) {
+	val, err := OptionalParam[string](r, p)
+	if err != nil || val == "" {
+		return nil, err
+	}
+	values := splitCommaList(val)
+	for i, v := range values {
+		if !slices.Contains(allowed, strings.ToLower(v)) {
+			return nil, fmt.Errorf("parameter '%s' values must be among %s, got %q", p, strings.Join(allowed, ", "), v)
+		}
+		values[i] = strings.ToUpper(v)
+	}
+	return values, nil
+}
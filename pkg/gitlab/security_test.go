@@ -0,0 +1,227 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+// newGraphQLServer returns a fake GitLab API answering GraphQL requests with the body returned
+// by respond, and the REST requests of routes (by escaped path) with their JSON body.
+func newGraphQLServer(t *testing.T, routes map[string]string, respond func(req graphQLRequest) string) GetClientFn {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == gl.GraphQLAPIEndpoint && r.Method == http.MethodPost {
+			var req graphQLRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			_, _ = io.WriteString(w, respond(req))
+			return
+		}
+		body, ok := routes[r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"message": "404 Project Not Found"}`)
+			return
+		}
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	client, err := gl.NewClient("token", gl.WithBaseURL(srv.URL), gl.WithoutRetries())
+	require.NoError(t, err)
+	return func(_ context.Context) (*gl.Client, error) { return client, nil }
+}
+
+const sastVulnerabilityJSON = `{
+	"id": "gid://gitlab/Vulnerability/42", "title": "SQL injection", "description": "Unsanitized input", "state": "DETECTED",
+	"severity": "CRITICAL", "report_type": "SAST", "scanner": {"name": "Semgrep", "external_id": "semgrep", "vendor": "GitLab"},
+	"primary_identifier": {"name": "CWE-89", "external_type": "cwe", "external_id": "89", "url": "https://cwe.mitre.org/data/definitions/89.html"},
+	"identifiers": [{"name": "CWE-89", "external_type": "cwe", "external_id": "89", "url": "https://cwe.mitre.org/data/definitions/89.html"}],
+	"location": {"file": "app/db.go", "start_line": "12", "end_line": "14", "blob_path": "/platform/api/-/blob/abc/app/db.go#L12"},
+	"solution": null, "dismissal_reason": null, "detected_at": "2025-05-01T10:00:00Z", "confirmed_at": null,
+	"resolved_at": null, "dismissed_at": null, "updated_at": "2025-05-01T10:00:00Z",
+	"project": {"full_path": "platform/api"}, "web_url": "https://gitlab.example.com/platform/api/-/security/vulnerabilities/42"
+}`
+
+func TestListVulnerabilitiesHandler(t *testing.T) {
+	ctx := context.Background()
+	var requests []graphQLRequest
+	getClient := newGraphQLServer(t, map[string]string{
+		"/api/v4/projects/15": `{"id": 15, "path_with_namespace": "platform/api"}`,
+	}, func(req graphQLRequest) string {
+		requests = append(requests, req)
+		switch req.Variables["fullPath"] {
+		case "platform/api":
+			if req.Variables["after"] == nil {
+				return `{"data": {"project": {"vulnerabilities": {"nodes": [` + sastVulnerabilityJSON + `], "pageInfo": {"hasNextPage": true, "endCursor": "c1"}}}}}`
+			}
+			return `{"data": {"project": {"vulnerabilities": {"nodes": [], "pageInfo": {"hasNextPage": false, "endCursor": null}}}}}`
+		default:
+			return `{"data": {"project": null}}`
+		}
+	})
+
+	listVulnerabilitiesTool, listVulnerabilitiesHandler := ListVulnerabilities(getClient)
+
+	t.Run("Success - Numeric ID With Filters", func(t *testing.T) {
+		requests = nil
+		result, err := listVulnerabilitiesHandler(ctx, createCallToolRequest(listVulnerabilitiesTool.Name, map[string]any{
+			"projectId": "15", "severity": "critical, high", "state": "detected", "scanner": "semgrep", "reportType": "sast", "per_page": 20,
+		}))
+		require.NoError(t, err)
+		items, pagination := getListResult(t, result)
+		assert.JSONEq(t, `[{
+			"id": 42, "title": "SQL injection", "severity": "CRITICAL", "state": "DETECTED", "report_type": "SAST",
+			"scanner": {"external_id": "semgrep"}, "primary_identifier": {"name": "CWE-89"},
+			"location": {"file": "app/db.go", "start_line": "12", "end_line": "14", "blob_path": "/platform/api/-/blob/abc/app/db.go#L12"},
+			"detected_at": "2025-05-01T10:00:00Z", "web_url": "https://gitlab.example.com/platform/api/-/security/vulnerabilities/42"
+		}]`, items)
+		assert.True(t, pagination.HasMore)
+		assert.Equal(t, "c1", pagination.NextCursor)
+
+		require.Len(t, requests, 1)
+		assert.Equal(t, map[string]any{
+			"fullPath": "platform/api", "first": float64(20), "severity": []any{"CRITICAL", "HIGH"}, "state": []any{"DETECTED"},
+			"scanner": []any{"semgrep"}, "reportType": []any{"SAST"},
+		}, requests[0].Variables)
+	})
+
+	t.Run("Success - All Pages", func(t *testing.T) {
+		requests = nil
+		result, err := listVulnerabilitiesHandler(ctx, createCallToolRequest(listVulnerabilitiesTool.Name, map[string]any{"projectId": "platform/api", "all": true}))
+		require.NoError(t, err)
+		_, pagination := getListResult(t, result)
+		assert.False(t, pagination.HasMore)
+		assert.Equal(t, 2, pagination.PagesFetched)
+		require.Len(t, requests, 2)
+		assert.Equal(t, "c1", requests[1].Variables["after"])
+	})
+
+	t.Run("Error - Project Not Found", func(t *testing.T) {
+		result, err := listVulnerabilitiesHandler(ctx, createCallToolRequest(listVulnerabilitiesTool.Name, map[string]any{"projectId": "platform/unknown"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, `project "platform/unknown" not found or access denied`)
+	})
+
+	t.Run("Error - Invalid Severity", func(t *testing.T) {
+		result, err := listVulnerabilitiesHandler(ctx, createCallToolRequest(listVulnerabilitiesTool.Name, map[string]any{"projectId": "platform/api", "severity": "urgent"}))
+		require.NoError(t, err)
+		assert.Contains(t, getTextResult(t, result).Text, "Validation Error: parameter 'severity' values must be among")
+	})
+}
+
+func TestGetVulnerabilityHandler(t *testing.T) {
+	ctx := context.Background()
+	getClient := newGraphQLServer(t, nil, func(req graphQLRequest) string {
+		if req.Variables["id"] == "gid://gitlab/Vulnerability/42" {
+			return `{"data": {"vulnerability": ` + sastVulnerabilityJSON + `}}`
+		}
+		return `{"data": {"vulnerability": null}, "errors": [{"message": "The resource that you are attempting to access does not exist or you don't have permission to perform this action"}]}`
+	})
+
+	getVulnerabilityTool, getVulnerabilityHandler := GetVulnerability(getClient)
+
+	result, err := getVulnerabilityHandler(ctx, createCallToolRequest(getVulnerabilityTool.Name, map[string]any{"vulnerabilityId": float64(42), "fields": "id,identifiers,location.file"}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": 42, "location": {"file": "app/db.go"},
+		"identifiers": [{"name": "CWE-89", "external_type": "cwe", "external_id": "89", "url": "https://cwe.mitre.org/data/definitions/89.html"}]
+	}`, getTextResult(t, result).Text)
+
+	result, err = getVulnerabilityHandler(ctx, createCallToolRequest(getVulnerabilityTool.Name, map[string]any{"vulnerabilityId": float64(7)}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, "failed to get vulnerability 7: The resource that you are attempting to access does not exist or you don't have permission to perform this action",
+		getTextResult(t, result).Text)
+}
+
+func TestGetPipelineSecuritySummaryHandler(t *testing.T) {
+	ctx := context.Background()
+	var request graphQLRequest
+	getClient := newGraphQLServer(t, nil, func(req graphQLRequest) string {
+		request = req
+		if req.Variables["id"] != "gid://gitlab/Ci::Pipeline/900" {
+			return `{"data": {"project": {"pipeline": null}}}`
+		}
+		return `{"data": {"project": {"pipeline": {"securityReportSummary": {
+			"sast": {"vulnerabilities_count": 3, "scanned_resources_count": 0,
+				"scanners": {"nodes": [{"name": "Semgrep", "external_id": "semgrep", "vendor": "GitLab"}]},
+				"scans": {"nodes": [{"name": "semgrep-sast", "status": "SUCCEEDED", "errors": [], "warnings": []}]}},
+			"dast": null, "dependency_scanning": null, "container_scanning": null, "secret_detection": null,
+			"coverage_fuzzing": null, "api_fuzzing": null, "cluster_image_scanning": null
+		}}}}}`
+	})
+
+	getSummaryTool, getSummaryHandler := GetPipelineSecuritySummary(getClient)
+
+	result, err := getSummaryHandler(ctx, createCallToolRequest(getSummaryTool.Name, map[string]any{"projectId": "platform/api", "pipelineId": float64(900), "fields": "sast,dast"}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"sast": {"vulnerabilities_count": 3, "scanned_resources_count": 0,
+			"scanners": [{"name": "Semgrep", "external_id": "semgrep", "vendor": "GitLab"}],
+			"scans": [{"name": "semgrep-sast", "status": "SUCCEEDED", "errors": [], "warnings": []}]},
+		"dast": null
+	}`, getTextResult(t, result).Text)
+	assert.Equal(t, "platform/api", request.Variables["fullPath"])
+
+	result, err = getSummaryHandler(ctx, createCallToolRequest(getSummaryTool.Name, map[string]any{"projectId": "platform/api", "pipelineId": float64(901)}))
+	require.NoError(t, err)
+	assert.Equal(t, `pipeline 901 not found in project "platform/api" or access denied`, getTextResult(t, result).Text)
+}
+
+func TestVulnerabilityStateChangeHandlers(t *testing.T) {
+	ctx := context.Background()
+	var request graphQLRequest
+	getClient := newGraphQLServer(t, nil, func(req graphQLRequest) string {
+		request = req
+		input := req.Variables["input"].(map[string]any)
+		if input["id"] != "gid://gitlab/Vulnerability/42" {
+			return `{"data": {"vulnerabilityResolve": {"vulnerability": null, "errors": ["Vulnerability is not resolvable"]}}}`
+		}
+		for _, mutation := range []string{"vulnerabilityDismiss", "vulnerabilityConfirm", "vulnerabilityResolve"} {
+			if strings.Contains(req.Query, mutation+"(input: $input)") {
+				return `{"data": {"` + mutation + `": {"vulnerability": ` + sastVulnerabilityJSON + `, "errors": []}}}`
+			}
+		}
+		return `{"errors": [{"message": "unknown mutation"}]}`
+	})
+
+	t.Run("Dismiss With Reason", func(t *testing.T) {
+		dismissTool, dismissHandler := DismissVulnerability(getClient)
+		result, err := dismissHandler(ctx, createCallToolRequest(dismissTool.Name, map[string]any{
+			"vulnerabilityId": float64(42), "comment": "Test fixture", "dismissalReason": "used_in_tests",
+		}))
+		require.NoError(t, err)
+		assert.False(t, result.IsError, getTextResult(t, result).Text)
+		assert.Contains(t, request.Query, "mutation($input: VulnerabilityDismissInput!)")
+		assert.Equal(t, map[string]any{"id": "gid://gitlab/Vulnerability/42", "comment": "Test fixture", "dismissalReason": "USED_IN_TESTS"}, request.Variables["input"])
+	})
+
+	t.Run("Confirm", func(t *testing.T) {
+		confirmTool, confirmHandler := ConfirmVulnerability(getClient)
+		result, err := confirmHandler(ctx, createCallToolRequest(confirmTool.Name, map[string]any{"vulnerabilityId": float64(42)}))
+		require.NoError(t, err)
+		var v map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &v))
+		assert.Equal(t, float64(42), v["id"], "The REST API ID of the vulnerability is returned")
+		assert.Equal(t, "Unsanitized input", v["description"])
+		assert.Equal(t, map[string]any{"id": "gid://gitlab/Vulnerability/42"}, request.Variables["input"], "No comment is sent when omitted")
+	})
+
+	t.Run("Error - Mutation Errors", func(t *testing.T) {
+		resolveTool, resolveHandler := ResolveVulnerability(getClient)
+		result, err := resolveHandler(ctx, createCallToolRequest(resolveTool.Name, map[string]any{"vulnerabilityId": float64(7), "comment": "Fixed"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Equal(t, "failed to resolve vulnerability 7: Vulnerability is not resolvable", getTextResult(t, result).Text)
+	})
+}
@@ -18,7 +18,8 @@ const DefaultPerPage = 20
 const MaxPerPage = 100
 
 // NewServer creates a new MCP server instance with default options suitable for GitLab.
-func NewServer(appName, appVersion string) *server.MCPServer {
+// Additional options, such as WithDefaultOutputFormat, are applied after the defaults.
+func NewServer(appName, appVersion string, extraOpts ...server.ServerOption) *server.MCPServer {
 	// Configure default server options here if needed
 	opts := []server.ServerOption{
 		// Add server options similar to github-mcp-server if needed
@@ -26,6 +27,7 @@ func NewServer(appName, appVersion string) *server.MCPServer {
 		server.WithResourceCapabilities(true, true), // Assuming these exist and are desired
 		server.WithLogging(),                        // Assuming this exists
 	}
+	opts = append(opts, extraOpts...)
 	return server.NewMCPServer(appName, appVersion, opts...)
 }
 
@@ -35,7 +37,7 @@ func NewServer(appName, appVersion string) *server.MCPServer {
 func requiredParam[T comparable](r *mcp.CallToolRequest, p string) (T, error) {
 	var zero T
 
-	val, ok := r.Params.Arguments[p]
+	val, ok := r.GetArguments()[p]
 	if !ok {
 		return zero, fmt.Errorf("missing required parameter: %s", p)
 	}
@@ -58,7 +60,7 @@ func requiredParam[T comparable](r *mcp.CallToolRequest, p string) (T, error) {
 func OptionalParam[T any](r *mcp.CallToolRequest, p string) (T, error) {
 	var zero T
 
-	val, ok := r.Params.Arguments[p]
+	val, ok := r.GetArguments()[p]
 	if !ok {
 		return zero, nil // Not present, return zero value, no error
 	}
@@ -74,7 +76,7 @@ func OptionalParam[T any](r *mcp.CallToolRequest, p string) (T, error) {
 
 // OptionalParamOK fetches an optional parameter, returning value, presence bool, and type error.
 func OptionalParamOK[T any](r *mcp.CallToolRequest, p string) (value T, ok bool, err error) {
-	val, exists := r.Params.Arguments[p]
+	val, exists := r.GetArguments()[p]
 	if !exists {
 		// Not present, return zero value, false, no error
 		return
@@ -172,7 +174,7 @@ func OptionalIntParamWithDefault(r *mcp.CallToolRequest, p string, defaultValue
 // It returns a pointer to the boolean value if found and valid, or nil if not present.
 // Returns an error if the parameter exists but is not a valid boolean.
 func OptionalBoolParam(r *mcp.CallToolRequest, p string) (*bool, error) {
-	rawVal, ok := r.Params.Arguments[p]
+	rawVal, ok := r.GetArguments()[p]
 	if !ok || rawVal == nil {
 		return nil, nil // Not present or explicitly null
 	}
@@ -223,7 +225,7 @@ func OptionalTimeParam(request *mcp.CallToolRequest, name string) (*time.Time, e
 
 // --- Pagination Helpers ---
 
-// WithPagination returns a ToolOption to add standard 'page' and 'per_page' parameters.
+// WithPagination returns a ToolOption to add standard 'page', 'per_page', 'all' and 'max_items' parameters.
 // Parameters are optional by default.
 func WithPagination() mcp.ToolOption {
 	return func(tool *mcp.Tool) {
@@ -239,6 +241,15 @@ func WithPagination() mcp.ToolOption {
 			// mcp.Min(1),
 			// mcp.Max(MaxPerPage), // Uncomment if mcp-go supports Min/Max
 		)(tool)
+
+		// Apply options for multi-page fetching
+		mcp.WithBoolean("all",
+			mcp.Description(fmt.Sprintf("Fetch successive pages starting at 'page' until the last page or 'max_items' (default: %d) is reached.", DefaultMaxItems)),
+		)(tool)
+		mcp.WithNumber("max_items",
+			mcp.Description(fmt.Sprintf("Fetch successive pages until this many items are collected (max: %d). Implies 'all'. "+
+				"Pages are never split, so fewer items may be returned when it is not a multiple of 'per_page'; 'next_page' or 'next_cursor' resumes right after them.", MaxItemsLimit)),
+		)(tool)
 	}
 }
 
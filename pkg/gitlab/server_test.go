@@ -11,13 +11,7 @@ import (
 // createMCPRequest is a helper function to create a CallToolRequest pointer for tests
 func createMCPRequest(params map[string]interface{}) *mcp.CallToolRequest {
 	return &mcp.CallToolRequest{
-		Params: struct {
-			Name      string                 `json:"name"`
-			Arguments map[string]interface{} `json:"arguments,omitempty"`
-			Meta      *struct {
-				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
-			} `json:"_meta,omitempty"`
-		}{
+		Params: mcp.CallToolParams{
 			Arguments: params,
 		},
 	}
@@ -0,0 +1,406 @@
+package gitlab
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	log "github.com/sirupsen/logrus"
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+// DefaultSubscriptionPollInterval is the default interval between checks of subscribed resources.
+const DefaultSubscriptionPollInterval = 30 * time.Second
+
+// MCP methods for resource subscriptions, which the mcp-go server does not dispatch.
+const (
+	methodResourcesSubscribe   = "resources/subscribe"
+	methodResourcesUnsubscribe = "resources/unsubscribe"
+)
+
+// stdioSessionID is the ID of the only session of mcp-go's stdio transport.
+const stdioSessionID = "stdio"
+
+// Kinds of resources that can be subscribed to.
+const (
+	subscriptionIssue        = "issue"
+	subscriptionMergeRequest = "merge_request"
+	subscriptionPipeline     = "pipeline"
+)
+
+// subscribableTemplates maps the kinds of subscribable resources to their URI template, whose
+// variables are "project" and a numeric ID.
+var subscribableTemplates = map[string]mcp.ResourceTemplate{
+	subscriptionIssue:        mcp.NewResourceTemplate(IssueResourceTemplate, subscriptionIssue),
+	subscriptionMergeRequest: mcp.NewResourceTemplate(MergeRequestResourceTemplate, subscriptionMergeRequest),
+	subscriptionPipeline:     mcp.NewResourceTemplate(PipelineResourceTemplate, subscriptionPipeline),
+}
+
+// watchedResource is a resource subscribed to by at least one session.
+type watchedResource struct {
+	kind    string
+	project string
+	id      int // IID of issues and merge requests, ID of pipelines
+	// since is the last update notified, or the time of the first subscription.
+	since time.Time
+	// finished is set once a pipeline reached a final status, after which it is no longer checked.
+	finished bool
+	sessions map[string]struct{}
+}
+
+// finalPipelineStatuses are the statuses of pipelines that are done running.
+var finalPipelineStatuses = []string{string(gl.Success), string(gl.Failed), string(gl.Canceled), string(gl.Skipped)}
+
+// watchedGroup is the key of the resources checked with a single list request.
+type watchedGroup struct {
+	kind    string
+	project string
+}
+
+// Subscriptions tracks the issues, merge requests and pipelines that MCP sessions subscribed
+// to, and sends them notifications/resources/updated when GitLab reports a change. Changes are
+// found by polling each project with an updated_after filter, and each pipeline until it is
+// finished, or reported with Notify.
+type Subscriptions struct {
+	server    *server.MCPServer
+	getClient GetClientFn
+	logger    log.FieldLogger
+	now       func() time.Time
+
+	mu      sync.Mutex
+	watched map[string]*watchedResource // by URI
+}
+
+// NewSubscriptions returns subscriptions checked with the client of getClient. They serve the
+// sessions of the server created with WithSubscriptions.
+func NewSubscriptions(getClient GetClientFn, logger log.FieldLogger) *Subscriptions {
+	return &Subscriptions{
+		getClient: getClient,
+		logger:    logger,
+		now:       time.Now,
+		watched:   make(map[string]*watchedResource),
+	}
+}
+
+// Subscribe registers the session for updates of the resource at uri, an issue, merge request
+// or pipeline URI.
+func (m *Subscriptions) Subscribe(sessionID, uri string) error {
+	kind, project, id, err := parseSubscribableURI(uri)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res, ok := m.watched[uri]
+	if !ok {
+		res = &watchedResource{kind: kind, project: project, id: id, since: m.now(), sessions: make(map[string]struct{})}
+		m.watched[uri] = res
+	}
+	res.sessions[sessionID] = struct{}{}
+	return nil
+}
+
+// Unsubscribe cancels the subscription of the session to the resource at uri, if any.
+func (m *Subscriptions) Unsubscribe(sessionID, uri string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if res, ok := m.watched[uri]; ok {
+		delete(res.sessions, sessionID)
+		if len(res.sessions) == 0 {
+			delete(m.watched, uri)
+		}
+	}
+}
+
+// RemoveSession cancels all subscriptions of the session, once it has ended.
+func (m *Subscriptions) RemoveSession(sessionID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for uri, res := range m.watched {
+		delete(res.sessions, sessionID)
+		if len(res.sessions) == 0 {
+			delete(m.watched, uri)
+		}
+	}
+}
+
+// Notify sends notifications/resources/updated for the resource at uri to its subscribers.
+func (m *Subscriptions) Notify(uri string) {
+	m.mu.Lock()
+	var sessions []string
+	if res, ok := m.watched[uri]; ok {
+		for sessionID := range res.sessions {
+			sessions = append(sessions, sessionID)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, sessionID := range sessions {
+		err := m.server.SendNotificationToSpecificClient(sessionID, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+		if err != nil {
+			m.logger.WithError(err).Warnf("Failed to notify session %s of the update of %s", sessionID, uri)
+		}
+	}
+}
+
+// PollEvery checks the subscribed resources every interval until ctx is done.
+func (m *Subscriptions) PollEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.poll(ctx)
+		}
+	}
+}
+
+// poll lists, for each project and kind of subscribed resources, those updated since the
+// last notified update, and notifies the subscribers of the updated ones.
+func (m *Subscriptions) poll(ctx context.Context) {
+	m.mu.Lock()
+	groups := make(map[watchedGroup]map[int]time.Time)
+	for _, res := range m.watched {
+		if res.finished {
+			continue
+		}
+		key := watchedGroup{kind: res.kind, project: res.project}
+		if groups[key] == nil {
+			groups[key] = make(map[int]time.Time)
+		}
+		if since, ok := groups[key][res.id]; !ok || res.since.Before(since) {
+			groups[key][res.id] = res.since
+		}
+	}
+	m.mu.Unlock()
+	if len(groups) == 0 {
+		return
+	}
+
+	glClient, err := m.getClient(ctx)
+	if err != nil {
+		m.logger.WithError(err).Warn("Failed to get GitLab client to check subscribed resources")
+		return
+	}
+	for key, ids := range groups {
+		updated, finished, err := listUpdated(ctx, glClient, key, ids)
+		if err != nil {
+			m.logger.WithError(err).Warnf("Failed to check subscribed %s resources of project %s", key.kind, key.project)
+		}
+		m.notifyUpdated(key, updated, finished)
+	}
+}
+
+// notifyUpdated notifies the subscribers of the resources of the project and kind of key whose
+// update time in updated is later than the last one notified, and marks the finished ones. A
+// resource may have been subscribed to under several URIs, such as with a numeric project ID
+// and with its path.
+func (m *Subscriptions) notifyUpdated(key watchedGroup, updated map[int]time.Time, finished []int) {
+	var uris []string
+	m.mu.Lock()
+	for uri, res := range m.watched {
+		if res.kind != key.kind || res.project != key.project {
+			continue
+		}
+		if slices.Contains(finished, res.id) {
+			res.finished = true
+		}
+		if updatedAt, ok := updated[res.id]; ok && updatedAt.After(res.since) {
+			res.since = updatedAt
+			uris = append(uris, uri)
+		}
+	}
+	m.mu.Unlock()
+	for _, uri := range uris {
+		m.Notify(uri)
+	}
+}
+
+// listUpdated returns the update times of the resources among ids of the project and kind of
+// key that GitLab reports as updated after their time in ids, and the pipelines among them
+// that are finished. Pipelines that cannot be read are reported in the error, after the others.
+func listUpdated(ctx context.Context, glClient *gl.Client, key watchedGroup, ids map[int]time.Time) (updated map[int]time.Time, finished []int, err error) {
+	var since time.Time
+	iids := make([]int, 0, len(ids))
+	for id, t := range ids {
+		if since.IsZero() || t.Before(since) {
+			since = t
+		}
+		iids = append(iids, id)
+	}
+	slices.Sort(iids)
+
+	updated = make(map[int]time.Time)
+	add := func(id int, updatedAt *time.Time) {
+		if _, ok := ids[id]; ok && updatedAt != nil && updatedAt.After(ids[id]) {
+			updated[id] = *updatedAt
+		}
+	}
+	params := ListParams{Page: 1, PerPage: MaxPerPage, MaxItems: MaxItemsLimit}
+	switch key.kind {
+	case subscriptionIssue:
+		issues, _, _, err := collectPages(params, func(page int) ([]*gl.Issue, *gl.Response, error) {
+			opts := &gl.ListProjectIssuesOptions{ListOptions: gl.ListOptions{Page: page, PerPage: params.PerPage}, IIDs: &iids, UpdatedAfter: &since}
+			return glClient.Issues.ListProjectIssues(key.project, opts, gl.WithContext(ctx))
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, issue := range issues {
+			add(issue.IID, issue.UpdatedAt)
+		}
+	case subscriptionMergeRequest:
+		mrs, _, _, err := collectPages(params, func(page int) ([]*gl.BasicMergeRequest, *gl.Response, error) {
+			opts := &gl.ListProjectMergeRequestsOptions{ListOptions: gl.ListOptions{Page: page, PerPage: params.PerPage}, IIDs: &iids, UpdatedAfter: &since}
+			return glClient.MergeRequests.ListProjectMergeRequests(key.project, opts, gl.WithContext(ctx))
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, mr := range mrs {
+			add(mr.IID, mr.UpdatedAt)
+		}
+	case subscriptionPipeline:
+		// Pipelines cannot be filtered by ID, and the project may run many more than are
+		// subscribed to, so each one is read
+		var errs []error
+		for _, id := range iids {
+			pipeline, _, err := glClient.Pipelines.GetPipeline(key.project, id, gl.WithContext(ctx))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("pipeline %d: %w", id, err))
+				continue
+			}
+			add(pipeline.ID, pipeline.UpdatedAt)
+			if slices.Contains(finalPipelineStatuses, pipeline.Status) {
+				finished = append(finished, pipeline.ID)
+			}
+		}
+		return updated, finished, errors.Join(errs...)
+	}
+	return updated, finished, nil
+}
+
+// parseSubscribableURI returns the kind, project and ID of the resource at uri, or an error if
+// it cannot be subscribed to.
+func parseSubscribableURI(uri string) (kind, project string, id int, err error) {
+	for kind, template := range subscribableTemplates {
+		values := template.URITemplate.Match(uri)
+		if values == nil {
+			continue
+		}
+		idName := "iid"
+		if kind == subscriptionPipeline {
+			idName = "id"
+		}
+		project = values.Get("project").String()
+		id, convErr := strconv.Atoi(values.Get(idName).String())
+		if convErr == nil && id > 0 && project != "" {
+			return kind, project, id, nil
+		}
+	}
+	return "", "", 0, fmt.Errorf("resource %q cannot be subscribed to: only issue, merge request and pipeline resources can", uri)
+}
+
+// WithSubscriptions returns a server option making m notify the sessions of the server, and
+// cancel their subscriptions as they end.
+func WithSubscriptions(m *Subscriptions) server.ServerOption {
+	hooks := &server.Hooks{}
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		m.RemoveSession(session.SessionID())
+	})
+	return func(s *server.MCPServer) {
+		m.server = s
+		server.WithHooks(hooks)(s)
+	}
+}
+
+// ListenStdio serves stdio with the stdio transport, answering the resources/subscribe and
+// resources/unsubscribe requests that the transport would reject as unknown methods.
+func (m *Subscriptions) ListenStdio(ctx context.Context, stdio *server.StdioServer, stdin io.Reader, stdout io.Writer) error {
+	out := &syncWriter{w: stdout}
+	requests, forward := io.Pipe()
+	go func() {
+		_ = forward.CloseWithError(m.filterRequests(stdin, forward, out))
+	}()
+	return stdio.Listen(ctx, requests, out)
+}
+
+// filterRequests copies the messages read from in to forward, except subscription requests
+// of the stdio session, which it answers on out.
+func (m *Subscriptions) filterRequests(in io.Reader, forward, out io.Writer) error {
+	reader := bufio.NewReader(in)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if response := m.handleMessage(stdioSessionID, line); response != nil {
+				data, marshalErr := json.Marshal(response)
+				if marshalErr != nil {
+					return marshalErr
+				}
+				if _, writeErr := out.Write(append(data, '\n')); writeErr != nil {
+					return writeErr
+				}
+			} else if _, writeErr := forward.Write(line); writeErr != nil {
+				return writeErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// handleMessage returns the response to a subscription request of the session, or nil if the
+// message is not one.
+func (m *Subscriptions) handleMessage(sessionID string, message []byte) mcp.JSONRPCMessage {
+	var request struct {
+		ID     any    `json:"id"`
+		Method string `json:"method"`
+		Params struct {
+			URI string `json:"uri"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil || request.ID == nil {
+		return nil
+	}
+	id := mcp.NewRequestId(request.ID)
+	switch request.Method {
+	case methodResourcesSubscribe:
+		if err := m.Subscribe(sessionID, request.Params.URI); err != nil {
+			return mcp.NewJSONRPCError(id, mcp.INVALID_PARAMS, err.Error(), nil)
+		}
+		m.logger.Infof("Session %s subscribed to %s", sessionID, request.Params.URI)
+	case methodResourcesUnsubscribe:
+		m.Unsubscribe(sessionID, request.Params.URI)
+	default:
+		return nil
+	}
+	return mcp.NewJSONRPCResponse(id, mcp.Result{})
+}
+
+// syncWriter serializes the writes of the responses of the stdio transport and of filterRequests.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
@@ -0,0 +1,218 @@
+package gitlab
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gl "gitlab.com/gitlab-org/api/client-go"
+	mock_gitlab "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeSession is an initialized MCP session recording the notifications sent to it.
+type fakeSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+	logLevel      mcp.LoggingLevel
+}
+
+func newFakeSession(t *testing.T, s *server.MCPServer, id string) *fakeSession {
+	t.Helper()
+	session := &fakeSession{id: id, notifications: make(chan mcp.JSONRPCNotification, 10), logLevel: mcp.LoggingLevelInfo}
+	require.NoError(t, s.RegisterSession(context.Background(), session))
+	return session
+}
+
+func (f *fakeSession) Initialize()                                         {}
+func (f *fakeSession) Initialized() bool                                   { return true }
+func (f *fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return f.notifications }
+func (f *fakeSession) SessionID() string                                   { return f.id }
+func (f *fakeSession) SetLogLevel(level mcp.LoggingLevel)                  { f.logLevel = level }
+func (f *fakeSession) GetLogLevel() mcp.LoggingLevel                       { return f.logLevel }
+
+// updatedURIs drains the notifications/resources/updated received by the session and returns their URIs.
+func (f *fakeSession) updatedURIs(t *testing.T) []string {
+	t.Helper()
+	var uris []string
+	for {
+		select {
+		case notification := <-f.notifications:
+			require.Equal(t, mcp.MethodNotificationResourceUpdated, notification.Method)
+			uris = append(uris, notification.Params.AdditionalFields["uri"].(string))
+		default:
+			return uris
+		}
+	}
+}
+
+func TestParseSubscribableURI(t *testing.T) {
+	tests := []struct {
+		uri             string
+		expectedKind    string
+		expectedProject string
+		expectedID      int
+	}{
+		{"gitlab://group/project/-/issues/7", subscriptionIssue, "group/project", 7},
+		{"gitlab://group%2Fsub%2Fproject/-/merge_requests/3", subscriptionMergeRequest, "group/sub/project", 3},
+		{"gitlab://42/-/pipelines/1001", subscriptionPipeline, "42", 1001},
+		{"gitlab://group/project/-/blob/main/README.md", "", "", 0},
+		{"gitlab://group/project/-/issues/abc", "", "", 0},
+		{"https://gitlab.com/group/project/-/issues/7", "", "", 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.uri, func(t *testing.T) {
+			kind, project, id, err := parseSubscribableURI(tc.uri)
+			if tc.expectedKind == "" {
+				assert.ErrorContains(t, err, "cannot be subscribed to")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedKind, kind)
+			assert.Equal(t, tc.expectedProject, project)
+			assert.Equal(t, tc.expectedID, id)
+		})
+	}
+}
+
+func TestSubscriptionsPoll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIssues := mock_gitlab.NewMockIssuesServiceInterface(ctrl)
+	mockPipelines := mock_gitlab.NewMockPipelinesServiceInterface(ctrl)
+	client := &gl.Client{Issues: mockIssues, Pipelines: mockPipelines}
+	logger, _ := test.NewNullLogger()
+
+	subscribedAt := time.Date(2025, 5, 6, 10, 0, 0, 0, time.UTC)
+	m := NewSubscriptions(func(_ context.Context) (*gl.Client, error) { return client, nil }, logger)
+	m.now = func() time.Time { return subscribedAt }
+	s := NewServer("test", "0.0.0", WithSubscriptions(m))
+	alice, bob := newFakeSession(t, s, "alice"), newFakeSession(t, s, "bob")
+
+	issueURI, encodedIssueURI := "gitlab://group/project/-/issues/7", "gitlab://group%2Fproject/-/issues/7"
+	pipelineURI := "gitlab://group/project/-/pipelines/5"
+	require.NoError(t, m.Subscribe("alice", issueURI))
+	require.NoError(t, m.Subscribe("alice", pipelineURI))
+	require.NoError(t, m.Subscribe("bob", encodedIssueURI))
+	require.Error(t, m.Subscribe("alice", "gitlab://group/project/-/blob/main/README.md"))
+
+	okResp := &gl.Response{Response: &http.Response{StatusCode: 200}}
+	issueUpdatedAt := subscribedAt.Add(time.Minute)
+	pipelineUpdatedAt := subscribedAt.Add(2 * time.Minute)
+	mockIssues.EXPECT().
+		ListProjectIssues("group/project", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, opts *gl.ListProjectIssuesOptions, _ ...gl.RequestOptionFunc) ([]*gl.Issue, *gl.Response, error) {
+			assert.Equal(t, []int{7}, *opts.IIDs, "Both URIs of the issue are checked with a single request")
+			assert.Equal(t, subscribedAt, *opts.UpdatedAfter)
+			return []*gl.Issue{{IID: 7, UpdatedAt: &issueUpdatedAt}}, okResp, nil
+		})
+	mockPipelines.EXPECT().
+		GetPipeline("group/project", 5, gomock.Any()).
+		Return(&gl.Pipeline{ID: 5, Status: "running", UpdatedAt: &pipelineUpdatedAt}, okResp, nil)
+	m.poll(context.Background())
+	assert.ElementsMatch(t, []string{issueURI, pipelineURI}, alice.updatedURIs(t), "Only subscribed resources are notified")
+	assert.Equal(t, []string{encodedIssueURI}, bob.updatedURIs(t), "Sessions are notified with the URI they subscribed to")
+
+	t.Run("Finished Pipeline", func(t *testing.T) {
+		finishedAt := pipelineUpdatedAt.Add(time.Minute)
+		mockIssues.EXPECT().
+			ListProjectIssues("group/project", gomock.Any(), gomock.Any()).
+			Return([]*gl.Issue{}, okResp, nil).
+			Times(2)
+		mockPipelines.EXPECT().
+			GetPipeline("group/project", 5, gomock.Any()).
+			Return(&gl.Pipeline{ID: 5, Status: "success", UpdatedAt: &finishedAt}, okResp, nil)
+		m.poll(context.Background())
+		assert.Equal(t, []string{pipelineURI}, alice.updatedURIs(t), "The final update is notified")
+
+		m.poll(context.Background()) // The finished pipeline is no longer read
+		assert.Empty(t, alice.updatedURIs(t))
+	})
+
+	t.Run("Unchanged Since Last Notification", func(t *testing.T) {
+		m.Unsubscribe("alice", pipelineURI)
+		mockIssues.EXPECT().
+			ListProjectIssues("group/project", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, opts *gl.ListProjectIssuesOptions, _ ...gl.RequestOptionFunc) ([]*gl.Issue, *gl.Response, error) {
+				assert.Equal(t, issueUpdatedAt, *opts.UpdatedAfter, "Checks start from the last notified update")
+				return []*gl.Issue{{IID: 7, UpdatedAt: &issueUpdatedAt}}, okResp, nil
+			})
+		m.poll(context.Background())
+		assert.Empty(t, alice.updatedURIs(t))
+		assert.Empty(t, bob.updatedURIs(t))
+	})
+
+	t.Run("Ended Sessions", func(t *testing.T) {
+		s.UnregisterSession(context.Background(), "alice")
+		s.UnregisterSession(context.Background(), "bob")
+		m.poll(context.Background()) // No subscription left, so no request is expected
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		assert.Empty(t, m.watched)
+	})
+}
+
+func TestSubscriptionsListenStdio(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	m := NewSubscriptions(func(_ context.Context) (*gl.Client, error) { return &gl.Client{}, nil }, logger)
+	s := NewServer("test", "0.0.0", WithSubscriptions(m))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stdin, clientOut := io.Pipe()
+	clientIn, stdout := io.Pipe()
+	errC := make(chan error, 1)
+	go func() { errC <- m.ListenStdio(ctx, server.NewStdioServer(s), stdin, stdout) }()
+
+	responses := bufio.NewScanner(clientIn)
+	send := func(message string) {
+		_, err := io.WriteString(clientOut, message+"\n")
+		require.NoError(t, err)
+	}
+	receive := func() map[string]any {
+		require.True(t, responses.Scan(), "Expected a message on stdout")
+		var message map[string]any
+		require.NoError(t, json.Unmarshal(responses.Bytes(), &message))
+		return message
+	}
+
+	send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"0.0.0"}}}`)
+	initialized := receive()
+	capabilities := initialized["result"].(map[string]any)["capabilities"].(map[string]any)
+	assert.Equal(t, true, capabilities["resources"].(map[string]any)["subscribe"])
+	send(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+
+	send(`{"jsonrpc":"2.0","id":2,"method":"resources/subscribe","params":{"uri":"gitlab://group/project/-/pipelines/5"}}`)
+	subscribed := receive()
+	assert.EqualValues(t, 2, subscribed["id"])
+	assert.Equal(t, map[string]any{}, subscribed["result"])
+
+	send(`{"jsonrpc":"2.0","id":3,"method":"resources/subscribe","params":{"uri":"gitlab://group/project/-/blob/main/README.md"}}`)
+	rejected := receive()
+	assert.EqualValues(t, 3, rejected["id"])
+	assert.EqualValues(t, mcp.INVALID_PARAMS, rejected["error"].(map[string]any)["code"])
+
+	m.Notify("gitlab://group/project/-/pipelines/5")
+	notification := receive()
+	assert.Equal(t, mcp.MethodNotificationResourceUpdated, notification["method"])
+	assert.Equal(t, "gitlab://group/project/-/pipelines/5", notification["params"].(map[string]any)["uri"])
+
+	send(`{"jsonrpc":"2.0","id":4,"method":"resources/unsubscribe","params":{"uri":"gitlab://group/project/-/pipelines/5"}}`)
+	assert.EqualValues(t, 4, receive()["id"])
+	send(`{"jsonrpc":"2.0","id":5,"method":"ping"}`)
+	assert.EqualValues(t, 5, receive()["id"], "Other requests are handled by the server")
+
+	require.NoError(t, clientOut.Close())
+	assert.NoError(t, <-errC, "The server stops at the end of stdin")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	assert.Empty(t, m.watched)
+}
@@ -0,0 +1,294 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	gl "gitlab.com/gitlab-org/api/client-go" // GitLab client library
+)
+
+// ListTags defines the MCP tool for listing repository tags in a project.
+func ListTags(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"listTags",
+			mcp.WithDescription("Retrieves a list of repository tags from a project, sorted by update date in descending order by default."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "List Tags",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("search",
+				mcp.Description("Return tags matching the search criteria. Use '^term' and 'term$' to match prefixes and suffixes."),
+			),
+			mcp.WithString("orderBy",
+				mcp.Description("Return tags ordered by field. Default: 'updated'."),
+				mcp.Enum("name", "updated", "version"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Return tags sorted in asc or desc order. Default: 'desc'."),
+				mcp.Enum("asc", "desc"),
+			),
+			// Add standard MCP pagination parameters
+			WithPagination(),
+			WithListOutput(tagSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			search, err := OptionalParam[string](&request, "search")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			orderBy, err := OptionalParam[string](&request, "orderBy")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			sort, err := OptionalParam[string](&request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, tagSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			opts := &gl.ListTagsOptions{
+				ListOptions: gl.ListOptions{
+					PerPage: listParams.PerPage,
+				},
+			}
+			if search != "" {
+				opts.Search = &search
+			}
+			if orderBy != "" {
+				opts.OrderBy = &orderBy
+			}
+			if sort != "" {
+				opts.Sort = &sort
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			tags, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.Tag, *gl.Response, error) {
+				opts.Page = page
+				return glClient.Tags.ListTags(projectIDStr, opts, gl.WithContext(ctx))
+			})
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list tags for project %q", projectIDStr),
+					fmt.Sprintf("project %q not found or access denied", projectIDStr),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newListResult(tags, pagination, output, "tag list data")
+		}
+}
+
+// GetTag defines the MCP tool for retrieving a single repository tag.
+func GetTag(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getTag",
+			mcp.WithDescription("Retrieves a single repository tag, including its target commit and release notes."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get Tag",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("tagName",
+				mcp.Required(),
+				mcp.Description("The name of the tag."),
+			),
+			WithOutput(tagSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, tagSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			tagName, err := requiredParam[string](&request, "tagName")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			tag, resp, err := glClient.Tags.GetTag(projectIDStr, tagName, gl.WithContext(ctx))
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get tag %q from project %q", tagName, projectIDStr),
+					fmt.Sprintf("tag %q not found in project %q or access denied", tagName, projectIDStr),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newProjectedResult(tag, output, "tag data")
+		}
+}
+
+// CreateTag defines the MCP tool for creating a repository tag.
+func CreateTag(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"createTag",
+			mcp.WithDescription("Creates a new repository tag pointing to a branch, tag or commit SHA. Providing a message creates an annotated tag."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Create Tag",
+				ReadOnlyHint: mcp.ToBoolPtr(false),
+			}),
+			WithResultSchema(gl.Tag{}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("tagName",
+				mcp.Required(),
+				mcp.Description("The name of the new tag."),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("The branch name, tag or commit SHA to create the tag from."),
+			),
+			mcp.WithString("message",
+				mcp.Description("The message for an annotated tag."),
+			),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			tagName, err := requiredParam[string](&request, "tagName")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			ref, err := requiredParam[string](&request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			message, err := OptionalParam[string](&request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			opts := &gl.CreateTagOptions{
+				TagName: &tagName,
+				Ref:     &ref,
+			}
+			if message != "" {
+				opts.Message = &message
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			tag, resp, err := glClient.Tags.CreateTag(projectIDStr, opts, gl.WithContext(ctx))
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to create tag %q from %q in project %q", tagName, ref, projectIDStr),
+					fmt.Sprintf("project %q or ref %q not found, or access denied", projectIDStr, ref),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newJSONResult(tag, "tag data")
+		}
+}
+
+// DeleteTag defines the MCP tool for deleting a repository tag.
+func DeleteTag(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"deleteTag",
+			mcp.WithDescription("Deletes a repository tag. Protected tags can only be deleted by users allowed by the protection rule."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Delete Tag",
+				ReadOnlyHint:    mcp.ToBoolPtr(false),
+				DestructiveHint: mcp.ToBoolPtr(true),
+				IdempotentHint:  mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("projectId",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or URL-encoded path (string) of the project."),
+			),
+			mcp.WithString("tagName",
+				mcp.Required(),
+				mcp.Description("The name of the tag to delete."),
+			),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			projectIDStr, err := requiredParam[string](&request, "projectId")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			tagName, err := requiredParam[string](&request, "tagName")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			resp, err := glClient.Tags.DeleteTag(projectIDStr, tagName, gl.WithContext(ctx))
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to delete tag %q from project %q", tagName, projectIDStr),
+					fmt.Sprintf("tag %q not found in project %q or access denied", tagName, projectIDStr),
+				), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("tag %q deleted from project %q", tagName, projectIDStr)), nil
+		}
+}
@@ -0,0 +1,218 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestListTagsHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockTags, ctrl := setupMockClientForTags(t)
+	defer ctrl.Finish()
+
+	mockGetClientTags := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	listTagsTool, listTagsHandler := ListTags(mockGetClientTags)
+	projectID := "group/project"
+
+	tests := []struct {
+		name               string
+		inputArgs          map[string]any
+		mockSetup          func()
+		expectedResult     []*gl.Tag
+		expectHandlerError bool
+		expectResultError  bool
+		errorContains      string
+	}{
+		{
+			name:      "Success - With Filters",
+			inputArgs: map[string]any{"projectId": projectID, "search": "^v1", "orderBy": "version", "sort": "asc", "per_page": 5},
+			mockSetup: func() {
+				mockTags.EXPECT().
+					ListTags(projectID, gomock.AssignableToTypeOf(&gl.ListTagsOptions{}), gomock.Any()).
+					DoAndReturn(func(_ interface{}, opts *gl.ListTagsOptions, _ ...gl.RequestOptionFunc) ([]*gl.Tag, *gl.Response, error) {
+						assert.Equal(t, "^v1", *opts.Search)
+						assert.Equal(t, "version", *opts.OrderBy)
+						assert.Equal(t, "asc", *opts.Sort)
+						assert.Equal(t, 5, opts.PerPage)
+						return []*gl.Tag{{Name: "v1.0.0"}, {Name: "v1.1.0"}}, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil
+					})
+			},
+			expectedResult: []*gl.Tag{{Name: "v1.0.0"}, {Name: "v1.1.0"}},
+		},
+		{
+			name:      "Success - Empty List",
+			inputArgs: map[string]any{"projectId": projectID},
+			mockSetup: func() {
+				mockTags.EXPECT().
+					ListTags(projectID, gomock.Any(), gomock.Any()).
+					Return([]*gl.Tag{}, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+			},
+			expectedResult: []*gl.Tag{},
+		},
+		{
+			name:      "Error - Project Not Found (404)",
+			inputArgs: map[string]any{"projectId": projectID},
+			mockSetup: func() {
+				mockTags.EXPECT().
+					ListTags(projectID, gomock.Any(), gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Not Found"))
+			},
+			expectResultError: true,
+			errorContains:     "not found or access denied",
+		},
+		{
+			name:      "Error - GitLab API Error (500)",
+			inputArgs: map[string]any{"projectId": projectID},
+			mockSetup: func() {
+				mockTags.EXPECT().
+					ListTags(projectID, gomock.Any(), gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
+			},
+			expectResultError: true,
+			errorContains:     "failed to list tags",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			result, err := listTagsHandler(ctx, createCallToolRequest(listTagsTool.Name, tc.inputArgs))
+
+			if tc.expectHandlerError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorContains)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectResultError {
+				assert.Contains(t, textContent.Text, tc.errorContains)
+				return
+			}
+			items, _ := getListResult(t, result)
+			expectedJSON, _ := json.Marshal(tc.expectedResult)
+			assert.JSONEq(t, string(expectedJSON), items)
+		})
+	}
+}
+
+func TestGetTagHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockTags, ctrl := setupMockClientForTags(t)
+	defer ctrl.Finish()
+
+	mockGetClientTags := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	getTagTool, getTagHandler := GetTag(mockGetClientTags)
+	projectID := "group/project"
+
+	t.Run("Success - Get Tag", func(t *testing.T) {
+		mockTags.EXPECT().
+			GetTag(projectID, "v1.0.0", gomock.Any()).
+			Return(&gl.Tag{Name: "v1.0.0", Target: "abc123"}, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil)
+
+		result, err := getTagHandler(ctx, createCallToolRequest(getTagTool.Name, map[string]any{"projectId": projectID, "tagName": "v1.0.0"}))
+		require.NoError(t, err)
+		var actual gl.Tag
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &actual))
+		assert.Equal(t, "abc123", actual.Target)
+	})
+
+	t.Run("Error - Tag Not Found (404)", func(t *testing.T) {
+		mockTags.EXPECT().
+			GetTag(projectID, "v9", gomock.Any()).
+			Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Not Found"))
+
+		result, err := getTagHandler(ctx, createCallToolRequest(getTagTool.Name, map[string]any{"projectId": projectID, "tagName": "v9"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, `tag "v9" not found`)
+	})
+}
+
+func TestCreateTagHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockTags, ctrl := setupMockClientForTags(t)
+	defer ctrl.Finish()
+
+	mockGetClientTags := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	createTagTool, createTagHandler := CreateTag(mockGetClientTags)
+	projectID := "group/project"
+
+	t.Run("Success - Annotated Tag", func(t *testing.T) {
+		expectedOpts := &gl.CreateTagOptions{TagName: gl.Ptr("v1.2.0"), Ref: gl.Ptr("main"), Message: gl.Ptr("Release 1.2.0")}
+		mockTags.EXPECT().
+			CreateTag(projectID, expectedOpts, gomock.Any()).
+			Return(&gl.Tag{Name: "v1.2.0", Message: "Release 1.2.0"}, &gl.Response{Response: &http.Response{StatusCode: 201}}, nil)
+
+		args := map[string]any{"projectId": projectID, "tagName": "v1.2.0", "ref": "main", "message": "Release 1.2.0"}
+		result, err := createTagHandler(ctx, createCallToolRequest(createTagTool.Name, args))
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, `"name":"v1.2.0"`)
+	})
+
+	t.Run("Error - Tag Already Exists (400)", func(t *testing.T) {
+		mockTags.EXPECT().
+			CreateTag(projectID, gomock.Any(), gomock.Any()).
+			Return(nil, &gl.Response{Response: &http.Response{StatusCode: 400}}, errors.New("Tag v1.2.0 already exists"))
+
+		args := map[string]any{"projectId": projectID, "tagName": "v1.2.0", "ref": "main"}
+		result, err := createTagHandler(ctx, createCallToolRequest(createTagTool.Name, args))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "already exists")
+	})
+
+	t.Run("Error - Missing ref", func(t *testing.T) {
+		result, err := createTagHandler(ctx, createCallToolRequest(createTagTool.Name, map[string]any{"projectId": projectID, "tagName": "v1.2.0"}))
+		require.NoError(t, err)
+		assert.Contains(t, getTextResult(t, result).Text, "Validation Error: missing required parameter: ref")
+	})
+}
+
+func TestDeleteTagHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockTags, ctrl := setupMockClientForTags(t)
+	defer ctrl.Finish()
+
+	mockGetClientTags := func(_ context.Context) (*gl.Client, error) {
+		return mockClient, nil
+	}
+	deleteTagTool, deleteTagHandler := DeleteTag(mockGetClientTags)
+	projectID := "group/project"
+
+	t.Run("Success - Delete Tag", func(t *testing.T) {
+		mockTags.EXPECT().DeleteTag(projectID, "v1.0.0", gomock.Any()).Return(&gl.Response{Response: &http.Response{StatusCode: 204}}, nil)
+
+		result, err := deleteTagHandler(ctx, createCallToolRequest(deleteTagTool.Name, map[string]any{"projectId": projectID, "tagName": "v1.0.0"}))
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, `tag "v1.0.0" deleted`)
+	})
+
+	t.Run("Error - Protected Tag (403)", func(t *testing.T) {
+		mockTags.EXPECT().DeleteTag(projectID, "v1.0.0", gomock.Any()).Return(&gl.Response{Response: &http.Response{StatusCode: 403}}, errors.New("gitlab: 403 Forbidden"))
+
+		result, err := deleteTagHandler(ctx, createCallToolRequest(deleteTagTool.Name, map[string]any{"projectId": projectID, "tagName": "v1.0.0"}))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "permission denied (403)")
+	})
+}
@@ -12,6 +12,12 @@ import (
 // This allows decoupling toolset initialization from direct client creation.
 type GetClientFn func(context.Context) (*gl.Client, error)
 
+// ToolsetConfig carries server-level settings that change how individual tools behave.
+type ToolsetConfig struct {
+	// AllowProtectedBranchWrites lets branch write tools create or delete protected branches.
+	AllowProtectedBranchWrites bool
+}
+
 // DefaultTools defines the list of toolsets enabled by default.
 var DefaultTools = []string{"all"}
 
@@ -21,6 +27,7 @@ func InitToolsets(
 	enabledToolsets []string,
 	readOnly bool,
 	getClient GetClientFn, // Restore parameter name
+	cfg ToolsetConfig,
 	// t translations.TranslationHelperFunc, // Removed for now
 ) (*toolsets.ToolsetGroup, error) {
 
@@ -31,6 +38,7 @@ func InitToolsets(
 	projectsTS := toolsets.NewToolset("projects", "Tools for interacting with GitLab projects, repositories, branches, commits, tags.")
 	issuesTS := toolsets.NewToolset("issues", "Tools for CRUD operations on GitLab issues, comments, labels.")
 	mergeRequestsTS := toolsets.NewToolset("merge_requests", "Tools for CRUD operations on GitLab merge requests, comments, approvals, diffs.")
+	groupsTS := toolsets.NewToolset("groups", "Tools for browsing GitLab groups, their subgroups, projects and members.")
 	securityTS := toolsets.NewToolset("security", "Tools for accessing GitLab security scan results (SAST, DAST, etc.).")
 	usersTS := toolsets.NewToolset("users", "Tools for looking up GitLab user information.")
 	searchTS := toolsets.NewToolset("search", "Tools for utilizing GitLab's scoped search capabilities.")
@@ -46,10 +54,41 @@ func InitToolsets(
 		toolsets.NewServerTool(ListProjects(getClient /*, t */)),
 		toolsets.NewServerTool(GetProjectFile(getClient /*, t */)),
 		toolsets.NewServerTool(ListProjectFiles(getClient /*, t */)),
+		toolsets.NewServerTool(GetRepositorySnapshot(getClient)),
 		toolsets.NewServerTool(GetProjectBranches(getClient /*, t */)),
+		toolsets.NewServerTool(GetBranch(getClient)),
+		toolsets.NewServerTool(ListProtectedBranches(getClient)),
+		toolsets.NewServerTool(GetProtectedBranch(getClient)),
 		toolsets.NewServerTool(GetProjectCommits(getClient /*, t */)),
+		toolsets.NewServerTool(GetCommit(getClient)),
+		toolsets.NewServerTool(GetCommitDiff(getClient)),
+		toolsets.NewServerTool(GetCommitRefs(getClient)),
+		toolsets.NewServerTool(GetCommitMergeRequests(getClient)),
+		toolsets.NewServerTool(GetCommitStatuses(getClient)),
+		toolsets.NewServerTool(CompareRefs(getClient)),
+		toolsets.NewServerTool(ListTags(getClient)),
+		toolsets.NewServerTool(GetTag(getClient)),
+		toolsets.NewServerTool(ListReleases(getClient)),
+		toolsets.NewServerTool(GetRelease(getClient)),
+	)
+	projectsTS.AddWriteTools(
+		toolsets.NewServerTool(CreateBranch(getClient, cfg.AllowProtectedBranchWrites)),
+		toolsets.NewServerTool(DeleteBranch(getClient, cfg.AllowProtectedBranchWrites)),
+		toolsets.NewServerTool(DeleteMergedBranches(getClient)),
+		toolsets.NewServerTool(CreateTag(getClient)),
+		toolsets.NewServerTool(DeleteTag(getClient)),
+		toolsets.NewServerTool(CreateRelease(getClient)),
+		toolsets.NewServerTool(UpdateRelease(getClient)),
+	)
+	projectsTS.AddResourceTemplates(
+		toolsets.NewServerResourceTemplate(FileResource(getClient)),
+		toolsets.NewServerResourceTemplate(PipelineResource(getClient)),
+	)
+	projectsTS.AddPrompts(
+		toolsets.NewServerPrompt(SummarizePipelineFailurePrompt(getClient)),
+		toolsets.NewServerPrompt(ReleaseNotesPrompt(getClient)),
+		toolsets.NewServerPrompt(ExplainFileHistoryPrompt(getClient)),
 	)
-	// projectsTS.AddWriteTools(...)
 
 	// --- Add tools to issuesTS (Task 8 & 13) ---
 	issuesTS.AddReadTools(
@@ -59,6 +98,8 @@ func InitToolsets(
 		toolsets.NewServerTool(GetIssueLabels(getClient)),
 	)
 	// issuesTS.AddWriteTools(...)
+	issuesTS.AddResourceTemplates(toolsets.NewServerResourceTemplate(IssueResource(getClient)))
+	issuesTS.AddPrompts(toolsets.NewServerPrompt(TriageIssuesPrompt(getClient)))
 
 	// --- Add tools to mergeRequestsTS (Task 9 & 14) ---
 	mergeRequestsTS.AddReadTools(
@@ -67,20 +108,54 @@ func InitToolsets(
 		toolsets.NewServerTool(GetMergeRequestComments(getClient)),
 	)
 	// mergeRequestsTS.AddWriteTools(...)
+	mergeRequestsTS.AddResourceTemplates(toolsets.NewServerResourceTemplate(MergeRequestResource(getClient)))
+	mergeRequestsTS.AddPrompts(toolsets.NewServerPrompt(ReviewMergeRequestPrompt(getClient)))
+
+	// --- Add tools to groupsTS ---
+	groupsTS.AddReadTools(
+		toolsets.NewServerTool(GetGroup(getClient)),
+		toolsets.NewServerTool(ListGroups(getClient)),
+		toolsets.NewServerTool(ListSubgroups(getClient)),
+		toolsets.NewServerTool(ListGroupProjects(getClient)),
+		toolsets.NewServerTool(ListGroupMembers(getClient)),
+	)
 
-	// --- Add tools to securityTS (Part of future tasks?) ---
-	// securityTS.AddReadTools(...) // Likely read-only
+	// --- Add tools to securityTS ---
+	securityTS.AddReadTools(
+		toolsets.NewServerTool(ListVulnerabilities(getClient)),
+		toolsets.NewServerTool(GetVulnerability(getClient)),
+		toolsets.NewServerTool(GetPipelineSecuritySummary(getClient)),
+		toolsets.NewServerTool(ListDependencies(getClient)),
+		toolsets.NewServerTool(ListGroupDependencies(getClient)),
+		toolsets.NewServerTool(ListLicenseViolations(getClient)),
+	)
+	securityTS.AddWriteTools(
+		toolsets.NewServerTool(DismissVulnerability(getClient)),
+		toolsets.NewServerTool(ConfirmVulnerability(getClient)),
+		toolsets.NewServerTool(ResolveVulnerability(getClient)),
+	)
 
 	// --- Add tools to usersTS (Task 10) ---
-	// usersTS.AddReadTools(...) // Likely read-only
+	usersTS.AddReadTools(
+		toolsets.NewServerTool(GetCurrentUser(getClient)),
+		toolsets.NewServerTool(GetUser(getClient)),
+		toolsets.NewServerTool(SearchUsers(getClient)),
+		toolsets.NewServerTool(ListUserEvents(getClient)),
+		toolsets.NewServerTool(GetUserStatus(getClient)),
+	)
 
 	// --- Add tools to searchTS (Task 10/11) ---
-	// searchTS.AddReadTools(...) // Likely read-only
+	searchTS.AddReadTools(
+		toolsets.NewServerTool(SearchGlobal(getClient)),
+		toolsets.NewServerTool(SearchGroup(getClient)),
+		toolsets.NewServerTool(SearchProject(getClient)),
+	)
 
 	// 4. Add defined Toolsets to the Group
 	tg.AddToolset(projectsTS)
 	tg.AddToolset(issuesTS)
 	tg.AddToolset(mergeRequestsTS)
+	tg.AddToolset(groupsTS)
 	tg.AddToolset(securityTS)
 	tg.AddToolset(usersTS)
 	tg.AddToolset(searchTS)
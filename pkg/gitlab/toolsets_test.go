@@ -28,6 +28,7 @@ func TestInitToolsets(t *testing.T) {
 		"projects",
 		"issues",
 		"merge_requests",
+		"groups",
 		"security",
 		"users",
 		"search",
@@ -93,7 +94,7 @@ func TestInitToolsets(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Call InitToolsets using the mock function again
 			// Pass nil for the translation helper for now
-			tg, err := InitToolsets(tc.enabledToolsets, tc.readOnly, mockGetClientFn /*, nil */)
+			tg, err := InitToolsets(tc.enabledToolsets, tc.readOnly, mockGetClientFn, ToolsetConfig{} /*, nil */)
 
 			if tc.expectError {
 				require.Error(t, err)
@@ -0,0 +1,119 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans created by this package.
+const tracerName = "github.com/LuisCusihuaman/gitlab-mcp-server/pkg/gitlab"
+
+// Attributes of the spans that have no OpenTelemetry semantic convention.
+const (
+	attrToolName  = attribute.Key("mcp.tool.name")
+	attrSessionID = attribute.Key("mcp.session.id")
+	attrPage      = attribute.Key("gitlab.page")
+	attrPerPage   = attribute.Key("gitlab.per_page")
+)
+
+// NewTracerProvider returns a tracer provider exporting spans over OTLP/HTTP to endpoint
+// (e.g., "http://localhost:4318"). The standard OTEL_EXPORTER_OTLP_* environment variables
+// configure the exporter further, such as its headers. The caller shuts the provider down
+// to flush pending spans.
+func NewTracerProvider(ctx context.Context, endpoint, serviceName, serviceVersion string) (*sdktrace.TracerProvider, error) {
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(serviceVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+	return sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res)), nil
+}
+
+// WithTracing returns a server option recording every tool call as a span of tp. The span
+// is carried by the context handed to the tool handler, so that the GitLab API requests
+// traced by TracingTransport become its children.
+func WithTracing(tp trace.TracerProvider) server.ServerOption {
+	tracer := tp.Tracer(tracerName)
+	return server.WithToolHandlerMiddleware(func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			tool := request.Params.Name
+			ctx, span := tracer.Start(ctx, "tools/call "+tool, trace.WithAttributes(attrToolName.String(tool)))
+			defer span.End()
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				span.SetAttributes(attrSessionID.String(session.SessionID()))
+			}
+
+			result, err := next(ctx, request)
+			switch {
+			case err != nil:
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			case result != nil && result.IsError:
+				span.SetStatus(codes.Error, resultText(result))
+			}
+			return result, err
+		}
+	})
+}
+
+// TracingTransport returns an HTTP transport recording every request sent through next as a
+// client span of tp, child of the span in the request's context. Placed beneath the retry
+// layer of the GitLab client, it records each attempt.
+func TracingTransport(next http.RoundTripper, tp trace.TracerProvider) http.RoundTripper {
+	return tracingTransport{tracer: tp.Tracer(tracerName), next: next}
+}
+
+// tracingTransport is the transport returned by TracingTransport.
+type tracingTransport struct {
+	tracer trace.Tracer
+	next   http.RoundTripper
+}
+
+func (t tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	route := routeTemplate(req.URL)
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.HTTPRoute(route),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	query := req.URL.Query()
+	if page, err := strconv.Atoi(query.Get("page")); err == nil {
+		attrs = append(attrs, attrPage.Int(page))
+	}
+	if perPage, err := strconv.Atoi(query.Get("per_page")); err == nil {
+		attrs = append(attrs, attrPerPage.Int(perPage))
+	}
+	ctx, span := t.tracer.Start(req.Context(), req.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	defer span.End()
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	return resp, nil
+}
@@ -0,0 +1,102 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+// spanAttribute returns the value of the attribute key of span, or an invalid value.
+func spanAttribute(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, attr := range span.Attributes {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Header().Set("X-Next-Page", "2")
+			_, _ = io.WriteString(w, `[{"id": 1, "name": "v1.0"}]`)
+		case "2":
+			_, _ = io.WriteString(w, `[{"id": 2, "name": "v0.9"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"message": "404 Not Found"}`)
+		}
+	}))
+	defer srv.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	httpClient := &http.Client{Transport: TracingTransport(http.DefaultTransport, tp)}
+	client, err := gl.NewClient("token", gl.WithBaseURL(srv.URL), gl.WithHTTPClient(httpClient), gl.WithoutRetries())
+	require.NoError(t, err)
+	getClient := func(_ context.Context) (*gl.Client, error) { return client, nil }
+
+	s := NewServer("test", "0.0.0", WithTracing(tp))
+	s.AddTool(ListTags(getClient))
+	s.AddTool(GetTag(getClient))
+
+	t.Run("Tool Call With Pages", func(t *testing.T) {
+		exporter.Reset()
+		result := callTool(t, s, "listTags", map[string]any{"projectId": "group/project", "all": true, "per_page": 1})
+		require.False(t, result.IsError, getTextResult(t, &result).Text)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 3, "One span per page and one for the tool call")
+		pages, toolSpan := spans[:2], spans[2]
+		assert.Equal(t, "tools/call listTags", toolSpan.Name)
+		assert.Equal(t, "listTags", spanAttribute(toolSpan, attrToolName).AsString())
+		assert.Equal(t, codes.Unset, toolSpan.Status.Code)
+		for i, span := range pages {
+			assert.Equal(t, "GET /projects/:id/repository/tags", span.Name)
+			assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+			assert.Equal(t, toolSpan.SpanContext.SpanID(), span.Parent.SpanID(), "HTTP spans are children of the tool call")
+			assert.Equal(t, toolSpan.SpanContext.TraceID(), span.SpanContext.TraceID())
+			assert.Equal(t, "/projects/:id/repository/tags", spanAttribute(span, semconv.HTTPRouteKey).AsString())
+			assert.Equal(t, http.MethodGet, spanAttribute(span, semconv.HTTPRequestMethodKey).AsString())
+			assert.EqualValues(t, http.StatusOK, spanAttribute(span, semconv.HTTPResponseStatusCodeKey).AsInt64())
+			assert.EqualValues(t, i+1, spanAttribute(span, attrPage).AsInt64(), fmt.Sprintf("page of span %d", i))
+		}
+	})
+
+	t.Run("Failed Request", func(t *testing.T) {
+		exporter.Reset()
+		result := callTool(t, s, "getTag", map[string]any{"projectId": "group/project", "tagName": "missing"})
+		require.True(t, result.IsError)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		request, toolSpan := spans[0], spans[1]
+		assert.Equal(t, "GET /projects/:id/repository/tags/:tag_name", request.Name)
+		assert.EqualValues(t, http.StatusNotFound, spanAttribute(request, semconv.HTTPResponseStatusCodeKey).AsInt64())
+		assert.Equal(t, codes.Error, request.Status.Code)
+		assert.Equal(t, codes.Error, toolSpan.Status.Code)
+		assert.Contains(t, toolSpan.Status.Description, "(404)")
+	})
+}
+
+func TestNewTracerProvider(t *testing.T) {
+	tp, err := NewTracerProvider(context.Background(), "http://localhost:4318", "gitlab-mcp-server", "test")
+	require.NoError(t, err)
+	assert.NoError(t, tp.Shutdown(context.Background()))
+}
@@ -0,0 +1,407 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	gl "gitlab.com/gitlab-org/api/client-go" // GitLab client library
+)
+
+// currentUser is the result of getCurrentUser: the authenticated user and the token the
+// server authenticates with.
+type currentUser struct {
+	User *gl.User `json:"user"`
+	// Token is nil when GitLab cannot describe the token, as for OAuth tokens.
+	Token *gl.PersonalAccessToken `json:"token"`
+}
+
+// GetCurrentUser defines the MCP tool for retrieving the user and token the server acts as.
+func GetCurrentUser(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getCurrentUser",
+			mcp.WithDescription("Retrieves the user the server acts as, including whether they are an administrator, and the name, scopes and expiry of the access token in use."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get Current User",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			WithOutput(currentUserSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			output, err := optionalOutputParams(ctx, &request, currentUserSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			user, resp, err := glClient.Users.CurrentUser(gl.WithContext(ctx))
+			if err != nil {
+				return apiErrorResult(err, resp, "failed to get current user", "current user not found"), nil
+			}
+			result := currentUser{User: user}
+			// Only personal, group and project access tokens can describe themselves
+			token, resp, err := glClient.PersonalAccessTokens.GetSinglePersonalAccessToken(gl.WithContext(ctx))
+			switch code := statusCode(resp); {
+			case err == nil:
+				result.Token = token
+			case code != http.StatusUnauthorized && code != http.StatusForbidden && code != http.StatusNotFound:
+				return apiErrorResult(err, resp, "failed to get current access token", "current access token not found"), nil
+			}
+
+			// --- Marshal and return success
+			return newProjectedResult(result, output, "current user data")
+		}
+}
+
+// GetUser defines the MCP tool for retrieving a user by ID or username.
+func GetUser(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getUser",
+			mcp.WithDescription("Retrieves a user's profile by ID or username, e.g., to resolve '@alice' to the user ID expected by 'assigneeId' parameters."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get User",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("user",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or username of the user, with or without its leading '@'."),
+			),
+			WithOutput(userDetailSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			userStr, uid, err := requiredUserParam(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, userDetailSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			userID, found, resp, err := resolveUserID(ctx, glClient, uid)
+			if err == nil && found {
+				var user *gl.User
+				user, resp, err = glClient.Users.GetUser(userID, gl.GetUsersOptions{}, gl.WithContext(ctx))
+				if err == nil {
+					return newProjectedResult(user, output, "user data")
+				}
+			}
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get user %q", userStr),
+					fmt.Sprintf("user %q not found", userStr),
+				), nil
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("user %q not found", userStr)), nil
+		}
+}
+
+// SearchUsers defines the MCP tool for searching users by name, username or email.
+func SearchUsers(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"searchUsers",
+			mcp.WithDescription("Searches users by name, username or public email. Use it to find the user ID of a person known by name."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Search Users",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("search",
+				mcp.Required(),
+				mcp.Description("The name, username or email to search for."),
+			),
+			mcp.WithBoolean("active", mcp.Description("Limit to active users, excluding blocked and deactivated ones.")),
+			mcp.WithBoolean("humans", mcp.Description("Limit to human users, excluding bots and service accounts.")),
+			// Add standard MCP pagination parameters
+			WithPagination(),
+			WithListOutput(userSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			search, err := requiredParam[string](&request, "search")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			active, err := OptionalBoolParam(&request, "active")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			humans, err := OptionalBoolParam(&request, "humans")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, userSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			opts := &gl.ListUsersOptions{
+				ListOptions: gl.ListOptions{
+					PerPage: listParams.PerPage,
+				},
+				Search: &search,
+				Active: active,
+				Humans: humans,
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			users, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.User, *gl.Response, error) {
+				opts.Page = page
+				return glClient.Users.ListUsers(opts, gl.WithContext(ctx))
+			})
+
+			// --- Handle API errors
+			if err != nil {
+				// No resource to report for 404s, as an empty list is a valid result
+				return apiErrorResult(err, resp, "failed to search users", "failed to search users"), nil
+			}
+
+			// --- Marshal and return success
+			return newListResult(users, pagination, output, "user list data")
+		}
+}
+
+// ListUserEvents defines the MCP tool for listing the contribution events of a user.
+func ListUserEvents(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"listUserEvents",
+			mcp.WithDescription("Retrieves the recent activity of a user (pushes, comments, opened or merged merge requests...), newest first. Only events in projects visible to the current user are returned."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "List User Events",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("user",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or username of the user, with or without its leading '@'."),
+			),
+			mcp.WithString("action",
+				mcp.Description("Limit to events of this action."),
+				mcp.Enum("approved", "closed", "commented", "created", "destroyed", "expired", "joined", "left", "merged", "pushed", "reopened", "updated"),
+			),
+			mcp.WithString("targetType",
+				mcp.Description("Limit to events on this type of target."),
+				mcp.Enum("issue", "milestone", "merge_request", "note", "project", "snippet", "user"),
+			),
+			mcp.WithString("after", mcp.Description("Limit to events created after this date (YYYY-MM-DD).")),
+			mcp.WithString("before", mcp.Description("Limit to events created before this date (YYYY-MM-DD).")),
+			mcp.WithString("sort",
+				mcp.Description("Sort events by creation date in asc or desc order. Default: 'desc'."),
+				mcp.Enum("asc", "desc"),
+			),
+			// Add standard MCP pagination parameters
+			WithPagination(),
+			WithListOutput(contributionEventSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			userStr, uid, err := requiredUserParam(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			action, err := OptionalParam[string](&request, "action")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			targetType, err := OptionalParam[string](&request, "targetType")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			after, err := optionalDateParam(&request, "after")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			before, err := optionalDateParam(&request, "before")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			sort, err := OptionalParam[string](&request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			listParams, err := OptionalListParams(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, contributionEventSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Construct GitLab API options
+			opts := &gl.ListContributionEventsOptions{
+				ListOptions: gl.ListOptions{
+					PerPage: listParams.PerPage,
+				},
+				After:  after,
+				Before: before,
+			}
+			if action != "" {
+				opts.Action = gl.Ptr(gl.EventTypeValue(action))
+			}
+			if targetType != "" {
+				opts.TargetType = gl.Ptr(gl.EventTargetTypeValue(targetType))
+			}
+			if sort != "" {
+				opts.Sort = &sort
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API, which accepts the username in place of the ID
+			events, pagination, resp, err := collectPages(listParams, func(page int) ([]*gl.ContributionEvent, *gl.Response, error) {
+				opts.Page = page
+				return glClient.Users.ListUserContributionEvents(uid, opts, gl.WithContext(ctx))
+			})
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to list events of user %q", userStr),
+					fmt.Sprintf("user %q not found, or their activity is private", userStr),
+				), nil
+			}
+
+			// --- Marshal and return success
+			return newListResult(events, pagination, output, "user event list data")
+		}
+}
+
+// GetUserStatus defines the MCP tool for retrieving the status of a user.
+func GetUserStatus(getClient GetClientFn) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"getUserStatus",
+			mcp.WithDescription("Retrieves the status a user set: their availability ('busy' or 'not_set'), emoji and message, such as 'Out of office until Monday'."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        "Get User Status",
+				ReadOnlyHint: mcp.ToBoolPtr(true),
+			}),
+			mcp.WithString("user",
+				mcp.Required(),
+				mcp.Description("The ID (integer) or username of the user, with or without its leading '@'."),
+			),
+			WithOutput(userStatusSchema),
+		),
+		// Handler function implementation
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// --- Parse parameters
+			userStr, uid, err := requiredUserParam(&request)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+			output, err := optionalOutputParams(ctx, &request, userStatusSchema)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Validation Error: %v", err)), nil
+			}
+
+			// --- Obtain GitLab client
+			glClient, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitLab client: %w", err)
+			}
+
+			// --- Call GitLab API
+			userID, found, resp, err := resolveUserID(ctx, glClient, uid)
+			if err == nil && found {
+				var status *gl.UserStatus
+				status, resp, err = glClient.Users.GetUserStatus(userID, gl.WithContext(ctx))
+				if err == nil {
+					return newProjectedResult(status, output, "user status data")
+				}
+			}
+
+			// --- Handle API errors
+			if err != nil {
+				return apiErrorResult(err, resp,
+					fmt.Sprintf("failed to get status of user %q", userStr),
+					fmt.Sprintf("user %q not found", userStr),
+				), nil
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("user %q not found", userStr)), nil
+		}
+}
+
+// requiredUserParam returns the user identified by the required 'user' parameter: its ID when
+// numeric, its username without the leading '@' otherwise.
+func requiredUserParam(r *mcp.CallToolRequest) (userStr string, uid any, err error) {
+	userStr, err = requiredParam[string](r, "user")
+	if err != nil {
+		return "", nil, err
+	}
+	user := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(userStr), "@"))
+	if user == "" {
+		return "", nil, fmt.Errorf("parameter 'user' must be a user ID or username, got %q", userStr)
+	}
+	if id, err := strconv.Atoi(user); err == nil {
+		return userStr, id, nil
+	}
+	return userStr, user, nil
+}
+
+// resolveUserID returns the ID of the user identified by uid, as returned by requiredUserParam,
+// looking up usernames. found is false when no user has the username.
+func resolveUserID(ctx context.Context, glClient *gl.Client, uid any) (id int, found bool, resp *gl.Response, err error) {
+	if id, ok := uid.(int); ok {
+		return id, true, nil, nil
+	}
+	username := uid.(string)
+	users, resp, err := glClient.Users.ListUsers(&gl.ListUsersOptions{Username: &username}, gl.WithContext(ctx))
+	if err != nil || len(users) == 0 {
+		return 0, false, resp, err
+	}
+	return users[0].ID, true, resp, nil
+}
+
+// optionalDateParam parses an optional calendar date parameter in the YYYY-MM-DD format.
+// It returns nil if the parameter is missing, empty, or null.
+func optionalDateParam(r *mcp.CallToolRequest, p string) (*gl.ISOTime, error) {
+	val, err := OptionalParam[string](r, p)
+	if err != nil || val == "" {
+		return nil, err
+	}
+	t, err := time.Parse("2006-01-02", val)
+	if err != nil {
+		return nil, fmt.Errorf("parameter '%s' must be a date in the YYYY-MM-DD format, got %q", p, val)
+	}
+	date := gl.ISOTime(t)
+	return &date, nil
+}
@@ -0,0 +1,246 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestGetCurrentUserHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockUsers, mockTokens, ctrl := setupMockClientForUsers(t)
+	defer ctrl.Finish()
+
+	getCurrentUserTool, getCurrentUserHandler := GetCurrentUser(func(_ context.Context) (*gl.Client, error) { return mockClient, nil })
+	user := &gl.User{ID: 1, Username: "alice", Name: "Alice", IsAdmin: true}
+	token := &gl.PersonalAccessToken{ID: 9, Name: "mcp", Scopes: []string{"api", "read_user"}, Active: true}
+	okResp := &gl.Response{Response: &http.Response{StatusCode: 200}}
+
+	tests := []struct {
+		name              string
+		mockSetup         func()
+		expectedResult    currentUser
+		expectResultError bool
+		errorContains     string
+	}{
+		{
+			name: "Success - Personal Access Token",
+			mockSetup: func() {
+				mockUsers.EXPECT().CurrentUser(gomock.Any()).Return(user, okResp, nil)
+				mockTokens.EXPECT().GetSinglePersonalAccessToken(gomock.Any()).Return(token, okResp, nil)
+			},
+			expectedResult: currentUser{User: user, Token: token},
+		},
+		{
+			name: "Success - Token Not Describable (OAuth)",
+			mockSetup: func() {
+				mockUsers.EXPECT().CurrentUser(gomock.Any()).Return(user, okResp, nil)
+				mockTokens.EXPECT().GetSinglePersonalAccessToken(gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Not Found"))
+			},
+			expectedResult: currentUser{User: user},
+		},
+		{
+			name: "Error - Invalid Token (401)",
+			mockSetup: func() {
+				mockUsers.EXPECT().CurrentUser(gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 401}}, errors.New("gitlab: 401 Unauthorized"))
+			},
+			expectResultError: true,
+			errorContains:     "failed to get current user: authentication failed (401)",
+		},
+		{
+			name: "Error - Token Lookup Server Error (500)",
+			mockSetup: func() {
+				mockUsers.EXPECT().CurrentUser(gomock.Any()).Return(user, okResp, nil)
+				mockTokens.EXPECT().GetSinglePersonalAccessToken(gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 500}}, errors.New("gitlab: 500 Internal Server Error"))
+			},
+			expectResultError: true,
+			errorContains:     "failed to get current access token",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			result, err := getCurrentUserHandler(ctx, createCallToolRequest(getCurrentUserTool.Name, map[string]any{}))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectResultError {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.errorContains)
+				return
+			}
+			assert.JSONEq(t, projectedJSON(t, tc.expectedResult, currentUserSchema.fields), textContent.Text)
+		})
+	}
+}
+
+func TestGetUserHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockUsers, _, ctrl := setupMockClientForUsers(t)
+	defer ctrl.Finish()
+
+	getUserTool, getUserHandler := GetUser(func(_ context.Context) (*gl.Client, error) { return mockClient, nil })
+	user := &gl.User{ID: 42, Username: "alice", Name: "Alice", State: "active", Bio: "Platform team"}
+	okResp := &gl.Response{Response: &http.Response{StatusCode: 200}}
+
+	tests := []struct {
+		name              string
+		user              string
+		mockSetup         func()
+		expectResultError bool
+		errorContains     string
+	}{
+		{
+			name: "Success - By ID",
+			user: "42",
+			mockSetup: func() {
+				mockUsers.EXPECT().GetUser(42, gl.GetUsersOptions{}, gomock.Any()).Return(user, okResp, nil)
+			},
+		},
+		{
+			name: "Success - By Mention",
+			user: "@alice",
+			mockSetup: func() {
+				mockUsers.EXPECT().
+					ListUsers(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(opts *gl.ListUsersOptions, _ ...gl.RequestOptionFunc) ([]*gl.User, *gl.Response, error) {
+						assert.Equal(t, "alice", *opts.Username, "The leading '@' is removed")
+						return []*gl.User{{ID: 42, Username: "alice"}}, okResp, nil
+					})
+				mockUsers.EXPECT().GetUser(42, gl.GetUsersOptions{}, gomock.Any()).Return(user, okResp, nil)
+			},
+		},
+		{
+			name: "Error - Unknown Username",
+			user: "nobody",
+			mockSetup: func() {
+				mockUsers.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Return([]*gl.User{}, okResp, nil)
+			},
+			expectResultError: true,
+			errorContains:     `user "nobody" not found`,
+		},
+		{
+			name: "Error - Unknown ID (404)",
+			user: "404",
+			mockSetup: func() {
+				mockUsers.EXPECT().GetUser(404, gl.GetUsersOptions{}, gomock.Any()).
+					Return(nil, &gl.Response{Response: &http.Response{StatusCode: 404}}, errors.New("gitlab: 404 Not Found"))
+			},
+			expectResultError: true,
+			errorContains:     `user "404" not found (404)`,
+		},
+		{
+			name:              "Error - Bare Mention",
+			user:              " @ ",
+			mockSetup:         func() {},
+			expectResultError: true,
+			errorContains:     `Validation Error: parameter 'user' must be a user ID or username, got " @ "`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			result, err := getUserHandler(ctx, createCallToolRequest(getUserTool.Name, map[string]any{"user": tc.user}))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectResultError {
+				assert.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.errorContains)
+				return
+			}
+			assert.JSONEq(t, projectedJSON(t, user, userDetailSchema.fields), textContent.Text)
+		})
+	}
+}
+
+func TestSearchUsersHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockUsers, _, ctrl := setupMockClientForUsers(t)
+	defer ctrl.Finish()
+
+	searchUsersTool, searchUsersHandler := SearchUsers(func(_ context.Context) (*gl.Client, error) { return mockClient, nil })
+	users := []*gl.User{{ID: 42, Username: "alice", Name: "Alice Liddell"}}
+
+	mockUsers.EXPECT().
+		ListUsers(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(opts *gl.ListUsersOptions, _ ...gl.RequestOptionFunc) ([]*gl.User, *gl.Response, error) {
+			assert.Equal(t, "Alice", *opts.Search)
+			assert.True(t, *opts.Active)
+			assert.Nil(t, opts.Humans)
+			return users, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil
+		})
+
+	result, err := searchUsersHandler(ctx, createCallToolRequest(searchUsersTool.Name, map[string]any{"search": "Alice", "active": true}))
+	require.NoError(t, err)
+	items, _ := getListResult(t, result)
+	assert.JSONEq(t, projectedJSON(t, users, userSchema.fields), items)
+
+	result, err = searchUsersHandler(ctx, createCallToolRequest(searchUsersTool.Name, map[string]any{}))
+	require.NoError(t, err)
+	assert.Contains(t, getTextResult(t, result).Text, "Validation Error", "A search term is required")
+}
+
+func TestListUserEventsHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockUsers, _, ctrl := setupMockClientForUsers(t)
+	defer ctrl.Finish()
+
+	listUserEventsTool, listUserEventsHandler := ListUserEvents(func(_ context.Context) (*gl.Client, error) { return mockClient, nil })
+	events := []*gl.ContributionEvent{{ID: 5, ActionName: "opened", TargetType: "MergeRequest", TargetIID: 3, TargetTitle: "Add parser", ProjectID: 15}}
+
+	t.Run("Success - With Filters", func(t *testing.T) {
+		mockUsers.EXPECT().
+			ListUserContributionEvents("alice", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, opts *gl.ListContributionEventsOptions, _ ...gl.RequestOptionFunc) ([]*gl.ContributionEvent, *gl.Response, error) {
+				assert.Equal(t, gl.CreatedEventType, *opts.Action)
+				assert.Equal(t, gl.MergeRequestEventTargetType, *opts.TargetType)
+				assert.Equal(t, gl.ISOTime(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)), *opts.After)
+				assert.Nil(t, opts.Before)
+				return events, &gl.Response{Response: &http.Response{StatusCode: 200}}, nil
+			})
+
+		result, err := listUserEventsHandler(ctx, createCallToolRequest(listUserEventsTool.Name, map[string]any{
+			"user": "@alice", "action": "created", "targetType": "merge_request", "after": "2025-05-01",
+		}))
+		require.NoError(t, err)
+		items, _ := getListResult(t, result)
+		assert.JSONEq(t, projectedJSON(t, events, contributionEventSchema.fields), items)
+	})
+
+	t.Run("Error - Invalid Date", func(t *testing.T) {
+		result, err := listUserEventsHandler(ctx, createCallToolRequest(listUserEventsTool.Name, map[string]any{"user": "42", "before": "last week"}))
+		require.NoError(t, err)
+		assert.Contains(t, getTextResult(t, result).Text, "must be a date in the YYYY-MM-DD format")
+	})
+}
+
+func TestGetUserStatusHandler(t *testing.T) {
+	ctx := context.Background()
+	mockClient, mockUsers, _, ctrl := setupMockClientForUsers(t)
+	defer ctrl.Finish()
+
+	getUserStatusTool, getUserStatusHandler := GetUserStatus(func(_ context.Context) (*gl.Client, error) { return mockClient, nil })
+	status := &gl.UserStatus{Emoji: "palm_tree", Availability: gl.Busy, Message: "Out of office until Monday"}
+	okResp := &gl.Response{Response: &http.Response{StatusCode: 200}}
+
+	mockUsers.EXPECT().ListUsers(gomock.Any(), gomock.Any()).Return([]*gl.User{{ID: 42, Username: "alice"}}, okResp, nil)
+	mockUsers.EXPECT().GetUserStatus(42, gomock.Any()).Return(status, okResp, nil)
+
+	result, err := getUserStatusHandler(ctx, createCallToolRequest(getUserStatusTool.Name, map[string]any{"user": "alice"}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"emoji": "palm_tree", "availability": "busy", "message": "Out of office until Monday", "message_html": ""}`, getTextResult(t, result).Text)
+}
@@ -0,0 +1,263 @@
+package gitlab
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	log "github.com/sirupsen/logrus"
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+// maxWebhookBytes bounds the size of an accepted webhook payload.
+const maxWebhookBytes = 5 << 20
+
+// maxWebhookNoteChars bounds the excerpt of a comment quoted in the message of a note event.
+const maxWebhookNoteChars = 200
+
+// webhookLogger is the logger name of the notifications/message sent for webhook events.
+const webhookLogger = "gitlab-webhook"
+
+// webhookResource identifies a subscribable resource updated by a webhook event.
+type webhookResource struct {
+	kind string
+	id   int
+}
+
+// webhookEvent is a GitLab webhook event, as sent in the data of the notifications/message of
+// the sessions subscribed to a resource of its project.
+type webhookEvent struct {
+	Event   string `json:"event"`
+	Project string `json:"project"`
+	Action  string `json:"action,omitempty"`
+	Message string `json:"message"`
+	URL     string `json:"url,omitempty"`
+
+	projectID int
+	level     mcp.LoggingLevel
+	resources []webhookResource
+}
+
+// NewWebhookHandler returns the HTTP handler of GitLab webhooks. Requests must carry secret in
+// their X-Gitlab-Token header. Push, merge request, note, pipeline and issue events are sent
+// as notifications/message to the sessions subscribed to a resource of their project, and as
+// notifications/resources/updated to the subscribers of the resources they update.
+func NewWebhookHandler(secret string, subscriptions *Subscriptions, logger log.FieldLogger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Gitlab-Token")), []byte(secret)) != 1 {
+			logger.Warnf("Rejected webhook request from %s: invalid X-Gitlab-Token", r.RemoteAddr)
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "failed to read payload", http.StatusBadRequest)
+			return
+		}
+		eventType := gl.HookEventType(r)
+		event, err := parseWebhookEvent(eventType, payload)
+		if err != nil {
+			logger.WithError(err).Warnf("Failed to parse %q webhook", eventType)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// Other events are acknowledged all the same: GitLab disables hooks that keep failing.
+		if event != nil {
+			logger.Debugf("Received %s webhook of project %s", event.Event, event.Project)
+			subscriptions.notifyEvent(event)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// notifyEvent sends the webhook event to the sessions subscribed to a resource of its project,
+// which GitLab identifies by ID and path, and notifies the subscribers of the resources it
+// updates. Polling may notify these updates again.
+func (m *Subscriptions) notifyEvent(event *webhookEvent) {
+	projects := []string{strconv.Itoa(event.projectID), event.Project}
+	sessions := make(map[string]struct{})
+	var uris []string
+	m.mu.Lock()
+	for uri, res := range m.watched {
+		if !slices.Contains(projects, res.project) {
+			continue
+		}
+		for sessionID := range res.sessions {
+			sessions[sessionID] = struct{}{}
+		}
+		if slices.Contains(event.resources, webhookResource{kind: res.kind, id: res.id}) {
+			uris = append(uris, uri)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, uri := range uris {
+		m.Notify(uri)
+	}
+	for sessionID := range sessions {
+		notification := mcp.NewLoggingMessageNotification(event.level, webhookLogger, event)
+		if err := m.server.SendLogMessageToSpecificClient(sessionID, notification); err != nil {
+			m.logger.WithError(err).Warnf("Failed to send %s event of project %s to session %s", event.Event, event.Project, sessionID)
+		}
+	}
+}
+
+// parseWebhookEvent parses the payload of a webhook of the given type. It returns nil for
+// events other than push, merge request, note, pipeline and issue events.
+func parseWebhookEvent(eventType gl.EventType, payload []byte) (*webhookEvent, error) {
+	switch eventType {
+	case gl.EventTypePush, gl.EventTypeMergeRequest, gl.EventTypeNote, gl.EventConfidentialNote,
+		gl.EventTypePipeline, gl.EventTypeIssue, gl.EventConfidentialIssue:
+	default:
+		return nil, nil
+	}
+	parsed, err := gl.ParseWebhook(eventType, payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %q payload: %w", eventType, err)
+	}
+
+	switch e := parsed.(type) {
+	case *gl.PushEvent:
+		return &webhookEvent{
+			Event: "push", Project: e.Project.PathWithNamespace, projectID: e.ProjectID,
+			Message: fmt.Sprintf("%s pushed %d commit(s) to %s", e.UserUsername, e.TotalCommitsCount, strings.TrimPrefix(e.Ref, "refs/heads/")),
+			URL:     e.Project.WebURL,
+			level:   mcp.LoggingLevelInfo,
+		}, nil
+	case *gl.MergeEvent:
+		return &webhookEvent{
+			Event: "merge_request", Project: e.Project.PathWithNamespace, projectID: e.Project.ID, Action: e.ObjectAttributes.Action,
+			Message:   fmt.Sprintf("%s %s merge request !%d %q", eventUsername(e.User), webhookActionVerb(e.ObjectAttributes.Action), e.ObjectAttributes.IID, e.ObjectAttributes.Title),
+			URL:       e.ObjectAttributes.URL,
+			level:     mcp.LoggingLevelInfo,
+			resources: []webhookResource{{kind: subscriptionMergeRequest, id: e.ObjectAttributes.IID}},
+		}, nil
+	case *gl.IssueEvent:
+		return &webhookEvent{
+			Event: "issue", Project: e.Project.PathWithNamespace, projectID: e.Project.ID, Action: e.ObjectAttributes.Action,
+			Message:   fmt.Sprintf("%s %s issue #%d %q", eventUsername(e.User), webhookActionVerb(e.ObjectAttributes.Action), e.ObjectAttributes.IID, e.ObjectAttributes.Title),
+			URL:       e.ObjectAttributes.URL,
+			level:     mcp.LoggingLevelInfo,
+			resources: []webhookResource{{kind: subscriptionIssue, id: e.ObjectAttributes.IID}},
+		}, nil
+	case *gl.MergeCommentEvent:
+		return &webhookEvent{
+			Event: "note", Project: e.Project.PathWithNamespace, projectID: e.ProjectID,
+			Message:   fmt.Sprintf("%s commented on merge request !%d: %s", eventUsername(e.User), e.MergeRequest.IID, noteExcerpt(e.ObjectAttributes.Note)),
+			URL:       e.ObjectAttributes.URL,
+			level:     mcp.LoggingLevelInfo,
+			resources: []webhookResource{{kind: subscriptionMergeRequest, id: e.MergeRequest.IID}},
+		}, nil
+	case *gl.IssueCommentEvent:
+		return &webhookEvent{
+			Event: "note", Project: e.Project.PathWithNamespace, projectID: e.ProjectID,
+			Message:   fmt.Sprintf("%s commented on issue #%d: %s", userUsername(e.User), e.Issue.IID, noteExcerpt(e.ObjectAttributes.Note)),
+			URL:       e.ObjectAttributes.URL,
+			level:     mcp.LoggingLevelInfo,
+			resources: []webhookResource{{kind: subscriptionIssue, id: e.Issue.IID}},
+		}, nil
+	case *gl.CommitCommentEvent:
+		return &webhookEvent{
+			Event: "note", Project: e.Project.PathWithNamespace, projectID: e.ProjectID,
+			Message: fmt.Sprintf("%s commented on commit %s: %s", userUsername(e.User), shortSHA(e.Commit.ID), noteExcerpt(e.ObjectAttributes.Note)),
+			URL:     e.ObjectAttributes.URL,
+			level:   mcp.LoggingLevelInfo,
+		}, nil
+	case *gl.SnippetCommentEvent:
+		return &webhookEvent{
+			Event: "note", Project: e.Project.PathWithNamespace, projectID: e.ProjectID,
+			Message: fmt.Sprintf("%s commented on snippet $%d: %s", eventUsername(e.User), e.Snippet.ID, noteExcerpt(e.ObjectAttributes.Note)),
+			URL:     e.ObjectAttributes.URL,
+			level:   mcp.LoggingLevelInfo,
+		}, nil
+	case *gl.PipelineEvent:
+		attrs := e.ObjectAttributes
+		event := &webhookEvent{
+			Event: "pipeline", Project: e.Project.PathWithNamespace, projectID: e.Project.ID, Action: attrs.Status,
+			Message:   fmt.Sprintf("Pipeline %d on %s: %s", attrs.ID, attrs.Ref, attrs.Status),
+			URL:       attrs.URL,
+			level:     mcp.LoggingLevelInfo,
+			resources: []webhookResource{{kind: subscriptionPipeline, id: attrs.ID}},
+		}
+		if e.MergeRequest.IID > 0 {
+			event.Message += fmt.Sprintf(" (merge request !%d %q)", e.MergeRequest.IID, e.MergeRequest.Title)
+			event.resources = append(event.resources, webhookResource{kind: subscriptionMergeRequest, id: e.MergeRequest.IID})
+		}
+		if attrs.Status == "failed" {
+			event.level = mcp.LoggingLevelWarning
+		}
+		return event, nil
+	}
+	return nil, nil
+}
+
+// webhookActionVerb returns the past tense of the action of a merge request or issue event.
+func webhookActionVerb(action string) string {
+	switch action {
+	case "open", "reopen":
+		return action + "ed"
+	case "close", "update", "merge":
+		return action + "d"
+	case "approval":
+		return "approved"
+	case "unapproval":
+		return "unapproved"
+	case "":
+		return "changed"
+	}
+	return action
+}
+
+// eventUsername returns the username of the author of a webhook event.
+func eventUsername(user *gl.EventUser) string {
+	if user == nil {
+		return "someone"
+	}
+	return user.Username
+}
+
+// userUsername returns the username of the author of a note event.
+func userUsername(user *gl.User) string {
+	if user == nil {
+		return "someone"
+	}
+	return user.Username
+}
+
+// noteExcerpt returns the first line of a comment, cut to maxWebhookNoteChars characters.
+func noteExcerpt(note string) string {
+	line, _, more := strings.Cut(strings.TrimSpace(note), "\n")
+	runes := []rune(line)
+	if len(runes) > maxWebhookNoteChars {
+		return string(runes[:maxWebhookNoteChars]) + "…"
+	}
+	if more {
+		return line + " …"
+	}
+	return line
+}
+
+// shortSHA returns the abbreviated form of a commit SHA.
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
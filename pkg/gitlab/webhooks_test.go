@@ -0,0 +1,182 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+// webhookNotifications drains the notifications received by the session and returns the URIs
+// of the updated resources and the data of the log messages.
+func webhookNotifications(t *testing.T, session *fakeSession) (uris []string, messages []map[string]any) {
+	t.Helper()
+	for {
+		select {
+		case notification := <-session.notifications:
+			switch notification.Method {
+			case mcp.MethodNotificationResourceUpdated:
+				uris = append(uris, notification.Params.AdditionalFields["uri"].(string))
+			case "notifications/message":
+				assert.Equal(t, webhookLogger, notification.Params.AdditionalFields["logger"])
+				data := notification.Params.AdditionalFields["data"].(*webhookEvent)
+				messages = append(messages, map[string]any{
+					"level": notification.Params.AdditionalFields["level"], "event": data.Event, "message": data.Message, "url": data.URL,
+				})
+			default:
+				t.Fatalf("Unexpected notification %s", notification.Method)
+			}
+		default:
+			return uris, messages
+		}
+	}
+}
+
+func TestNewWebhookHandler(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	m := NewSubscriptions(func(_ context.Context) (*gl.Client, error) { return &gl.Client{}, nil }, logger)
+	s := NewServer("test", "0.0.0", WithSubscriptions(m))
+	alice, bob := newFakeSession(t, s, "alice"), newFakeSession(t, s, "bob")
+	quiet := newFakeSession(t, s, "quiet")
+	quiet.SetLogLevel(mcp.LoggingLevelWarning)
+
+	mrURI, pipelineURI, issueURI := "gitlab://mike/diaspora/-/merge_requests/1", "gitlab://15/-/pipelines/31", "gitlab://mike%2Fdiaspora/-/issues/17"
+	require.NoError(t, m.Subscribe("alice", mrURI))
+	require.NoError(t, m.Subscribe("alice", pipelineURI))
+	require.NoError(t, m.Subscribe("bob", "gitlab://other/project/-/issues/17"))
+	require.NoError(t, m.Subscribe("quiet", issueURI))
+	handler := NewWebhookHandler("s3cret", m, logger)
+
+	tests := []struct {
+		name             string
+		eventType        string
+		payload          string
+		expectedURIs     []string
+		expectedMessage  map[string]any
+		expectedQuietURI []string
+		quietMessage     bool
+	}{
+		{
+			name:      "Push",
+			eventType: "Push Hook",
+			payload:   "push.json",
+			expectedMessage: map[string]any{
+				"level": mcp.LoggingLevelInfo, "event": "push", "message": "jsmith pushed 2 commit(s) to main", "url": "https://gitlab.example.com/mike/diaspora",
+			},
+		},
+		{
+			name:         "Merge Request",
+			eventType:    "Merge Request Hook",
+			payload:      "merge_request.json",
+			expectedURIs: []string{mrURI},
+			expectedMessage: map[string]any{
+				"level": mcp.LoggingLevelInfo, "event": "merge_request", "message": `root opened merge request !1 "MS-Viewport"`,
+				"url": "https://gitlab.example.com/mike/diaspora/-/merge_requests/1",
+			},
+		},
+		{
+			name:         "Note On Merge Request",
+			eventType:    "Note Hook",
+			payload:      "note_merge_request.json",
+			expectedURIs: []string{mrURI},
+			expectedMessage: map[string]any{
+				"level": mcp.LoggingLevelInfo, "event": "note", "message": "root commented on merge request !1: This MR needs work. …",
+				"url": "https://gitlab.example.com/mike/diaspora/-/merge_requests/1#note_1244",
+			},
+		},
+		{
+			name:      "Note On Issue",
+			eventType: "Note Hook",
+			payload:   "note_issue.json",
+			expectedMessage: map[string]any{
+				"level": mcp.LoggingLevelInfo, "event": "note", "message": "root commented on issue #17: Hello world",
+				"url": "https://gitlab.example.com/mike/diaspora/-/issues/17#note_1241",
+			},
+			expectedQuietURI: []string{issueURI},
+		},
+		{
+			name:      "Issue",
+			eventType: "Issue Hook",
+			payload:   "issue.json",
+			expectedMessage: map[string]any{
+				"level": mcp.LoggingLevelInfo, "event": "issue", "message": `root closed issue #17 "New API: create/update/delete file"`,
+				"url": "https://gitlab.example.com/mike/diaspora/-/issues/17",
+			},
+			expectedQuietURI: []string{issueURI},
+		},
+		{
+			name:         "Failed Pipeline Of Merge Request",
+			eventType:    "Pipeline Hook",
+			payload:      "pipeline_failed.json",
+			expectedURIs: []string{mrURI, pipelineURI},
+			expectedMessage: map[string]any{
+				"level": mcp.LoggingLevelWarning, "event": "pipeline", "message": `Pipeline 31 on ms-viewport: failed (merge request !1 "MS-Viewport")`,
+				"url": "https://gitlab.example.com/mike/diaspora/-/pipelines/31",
+			},
+			quietMessage: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := os.ReadFile(filepath.Join("testdata", "webhooks", tc.payload))
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(string(payload)))
+			req.Header.Set("X-Gitlab-Event", tc.eventType)
+			req.Header.Set("X-Gitlab-Token", "s3cret")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+			uris, messages := webhookNotifications(t, alice)
+			assert.ElementsMatch(t, tc.expectedURIs, uris)
+			assert.Equal(t, []map[string]any{tc.expectedMessage}, messages)
+
+			uris, messages = webhookNotifications(t, bob)
+			assert.Empty(t, uris, "Resources of other projects are not notified")
+			assert.Empty(t, messages, "Events are only sent to sessions subscribed to their project")
+
+			uris, messages = webhookNotifications(t, quiet)
+			assert.Equal(t, tc.expectedQuietURI, uris, "Projects are matched by ID or path")
+			assert.Equal(t, tc.quietMessage, len(messages) == 1, "Events below the log level of the session are not sent")
+		})
+	}
+
+	t.Run("Rejected Requests", func(t *testing.T) {
+		requests := []struct {
+			method, token, eventType, body string
+			expectedCode                   int
+		}{
+			{http.MethodGet, "s3cret", "Push Hook", "", http.StatusMethodNotAllowed},
+			{http.MethodPost, "", "Push Hook", "{}", http.StatusUnauthorized},
+			{http.MethodPost, "wrong", "Push Hook", "{}", http.StatusUnauthorized},
+			{http.MethodPost, "s3cret", "Push Hook", "not json", http.StatusBadRequest},
+			{http.MethodPost, "s3cret", "Job Hook", `{"object_kind": "build"}`, http.StatusNoContent},
+		}
+		for _, r := range requests {
+			req := httptest.NewRequest(r.method, "/webhook", strings.NewReader(r.body))
+			req.Header.Set("X-Gitlab-Event", r.eventType)
+			req.Header.Set("X-Gitlab-Token", r.token)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, r.expectedCode, rec.Code, "%s %s with token %q", r.method, r.eventType, r.token)
+		}
+		uris, messages := webhookNotifications(t, alice)
+		assert.Empty(t, uris)
+		assert.Empty(t, messages, "Rejected and unsupported events are not sent")
+	})
+}
+
+func TestNoteExcerpt(t *testing.T) {
+	assert.Equal(t, "LGTM", noteExcerpt("  LGTM\n"))
+	assert.Equal(t, "First line …", noteExcerpt("First line\nSecond line"))
+	assert.Equal(t, strings.Repeat("é", maxWebhookNoteChars)+"…", noteExcerpt(strings.Repeat("é", maxWebhookNoteChars+1)))
+}
@@ -16,6 +16,9 @@ type Toolset struct {
 	readOnly    bool // Whether the toolset (and its tools) should operate in read-only mode
 	writeTools  []server.ServerTool
 	readTools   []server.ServerTool
+	// resourceTemplates and prompts only read, so they are registered in read-only mode too
+	resourceTemplates []server.ServerResourceTemplate
+	prompts           []server.ServerPrompt
 }
 
 // ToolsetGroup manages a collection of Toolsets.
@@ -34,6 +37,23 @@ func NewServerTool(tool mcp.Tool, handler server.ToolHandlerFunc) server.ServerT
 	}
 }
 
+// NewServerResourceTemplate creates a ServerResourceTemplate struct containing the MCP resource
+// template definition and its handler, as NewServerTool does for tools.
+func NewServerResourceTemplate(template mcp.ResourceTemplate, handler server.ResourceTemplateHandlerFunc) server.ServerResourceTemplate {
+	return server.ServerResourceTemplate{
+		Template: template,
+		Handler:  handler,
+	}
+}
+
+// NewServerPrompt creates a ServerPrompt struct containing the MCP prompt definition and its handler.
+func NewServerPrompt(prompt mcp.Prompt, handler server.PromptHandlerFunc) server.ServerPrompt {
+	return server.ServerPrompt{
+		Prompt:  prompt,
+		Handler: handler,
+	}
+}
+
 // NewToolset creates a new, disabled Toolset instance.
 func NewToolset(name string, description string) *Toolset {
 	return &Toolset{
@@ -75,6 +95,18 @@ func (t *Toolset) AddWriteTools(tools ...server.ServerTool) *Toolset {
 	return t
 }
 
+// AddResourceTemplates adds resource templates to the Toolset.
+func (t *Toolset) AddResourceTemplates(templates ...server.ServerResourceTemplate) *Toolset {
+	t.resourceTemplates = append(t.resourceTemplates, templates...)
+	return t
+}
+
+// AddPrompts adds prompts to the Toolset.
+func (t *Toolset) AddPrompts(prompts ...server.ServerPrompt) *Toolset {
+	t.prompts = append(t.prompts, prompts...)
+	return t
+}
+
 // GetActiveTools returns the list of tools that should be registered based on the
 // Toolset's Enabled and readOnly flags.
 func (t *Toolset) GetActiveTools() []server.ServerTool {
@@ -103,6 +135,28 @@ func (t *Toolset) RegisterTools(s *server.MCPServer) {
 	}
 }
 
+// RegisterResourceTemplates adds the Toolset's resource templates to the provided MCP server
+// instance if the Toolset is enabled.
+func (t *Toolset) RegisterResourceTemplates(s *server.MCPServer) {
+	if !t.Enabled {
+		return
+	}
+	for _, template := range t.resourceTemplates {
+		s.AddResourceTemplate(template.Template, template.Handler)
+	}
+}
+
+// RegisterPrompts adds the Toolset's prompts to the provided MCP server instance if the
+// Toolset is enabled.
+func (t *Toolset) RegisterPrompts(s *server.MCPServer) {
+	if !t.Enabled {
+		return
+	}
+	for _, prompt := range t.prompts {
+		s.AddPrompt(prompt.Prompt, prompt.Handler)
+	}
+}
+
 // SetReadOnly forces the toolset into read-only mode.
 func (t *Toolset) SetReadOnly() {
 	t.readOnly = true
@@ -178,3 +232,33 @@ func (tg *ToolsetGroup) RegisterTools(s *server.MCPServer) {
 		}
 	}
 }
+
+// RegisterResourceTemplates registers the resource templates of the *enabled* Toolsets with
+// the provided MCP server.
+func (tg *ToolsetGroup) RegisterResourceTemplates(s *server.MCPServer) {
+	for _, ts := range tg.Toolsets {
+		ts.RegisterResourceTemplates(s)
+	}
+}
+
+// RegisterPrompts registers the prompts of the *enabled* Toolsets with the provided MCP server.
+func (tg *ToolsetGroup) RegisterPrompts(s *server.MCPServer) {
+	for _, ts := range tg.Toolsets {
+		ts.RegisterPrompts(s)
+	}
+}
+
+// WriteToolNames returns the names of the write tools registered by RegisterTools, that is
+// those of the enabled toolsets when the group is not read-only.
+func (tg *ToolsetGroup) WriteToolNames() []string {
+	var names []string
+	for _, ts := range tg.Toolsets {
+		if !ts.Enabled || ts.readOnly {
+			continue
+		}
+		for _, tool := range ts.writeTools {
+			names = append(names, tool.Tool.Name)
+		}
+	}
+	return names
+}
@@ -1,8 +1,11 @@
 package toolsets
 
 import (
+	"context"
 	"testing"
 
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -185,3 +188,63 @@ func TestToolsetGroup_EnableToolsets(t *testing.T) {
 
 // NOTE: Removing tests for RegisterTools as we cannot easily mock *server.MCPServer
 // func TestToolsetGroup_RegisterTools(t *testing.T) { ... }
+
+func TestToolsetGroup_WriteToolNames(t *testing.T) {
+	newGroup := func(readOnly bool) *ToolsetGroup {
+		tg := NewToolsetGroup(readOnly)
+		ts1 := NewToolset("ts1", "Toolset 1")
+		ts1.AddReadTools(NewServerTool(mcp.NewTool("read1"), nil))
+		ts1.AddWriteTools(NewServerTool(mcp.NewTool("write1"), nil))
+		ts2 := NewToolset("ts2", "Toolset 2")
+		ts2.AddWriteTools(NewServerTool(mcp.NewTool("write2"), nil))
+		tg.AddToolset(ts1)
+		tg.AddToolset(ts2)
+		require.NoError(t, tg.EnableToolset("ts1"))
+		return tg
+	}
+
+	assert.Equal(t, []string{"write1"}, newGroup(false).WriteToolNames(), "Only write tools of enabled toolsets are listed")
+	assert.Empty(t, newGroup(true).WriteToolNames(), "A read-only group registers no write tools")
+}
+
+func TestToolsetGroup_RegisterResourceTemplates(t *testing.T) {
+	tg := NewToolsetGroup(true)
+	ts1 := NewToolset("ts1", "Toolset 1")
+	ts1.AddResourceTemplates(server.ServerResourceTemplate{Template: mcp.NewResourceTemplate("test://{id}/one", "one")})
+	ts2 := NewToolset("ts2", "Toolset 2")
+	ts2.AddResourceTemplates(server.ServerResourceTemplate{Template: mcp.NewResourceTemplate("test://{id}/two", "two")})
+	tg.AddToolset(ts1)
+	tg.AddToolset(ts2)
+	require.NoError(t, tg.EnableToolset("ts1"))
+
+	s := server.NewMCPServer("test", "0.0.0", server.WithResourceCapabilities(false, false))
+	tg.RegisterResourceTemplates(s)
+	response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/templates/list"}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "Expected a JSON-RPC response, got %T", response)
+	result, ok := resp.Result.(mcp.ListResourceTemplatesResult)
+	require.True(t, ok, "Expected a resource templates result, got %T", resp.Result)
+	require.Len(t, result.ResourceTemplates, 1, "Only templates of enabled toolsets are registered, read-only or not")
+	assert.Equal(t, "one", result.ResourceTemplates[0].Name)
+}
+
+func TestToolsetGroup_RegisterPrompts(t *testing.T) {
+	tg := NewToolsetGroup(true)
+	ts1 := NewToolset("ts1", "Toolset 1")
+	ts1.AddPrompts(NewServerPrompt(mcp.NewPrompt("prompt1"), nil))
+	ts2 := NewToolset("ts2", "Toolset 2")
+	ts2.AddPrompts(NewServerPrompt(mcp.NewPrompt("prompt2"), nil))
+	tg.AddToolset(ts1)
+	tg.AddToolset(ts2)
+	require.NoError(t, tg.EnableToolset("ts1"))
+
+	s := server.NewMCPServer("test", "0.0.0")
+	tg.RegisterPrompts(s)
+	response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"prompts/list"}`))
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "Expected a JSON-RPC response, got %T", response)
+	result, ok := resp.Result.(mcp.ListPromptsResult)
+	require.True(t, ok, "Expected a prompts result, got %T", resp.Result)
+	require.Len(t, result.Prompts, 1, "Only prompts of enabled toolsets are registered, read-only or not")
+	assert.Equal(t, "prompt1", result.Prompts[0].Name)
+}